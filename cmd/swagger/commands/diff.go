@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/vikstrous/go-swagger/spec"
+)
+
+// DiffSpec is a command that compares 2 versions of a swagger document
+// and reports the changes that break existing clients
+type DiffSpec struct {
+	Format string `long:"format" short:"f" description:"the format for the report" default:"text" choice:"text" choice:"json"`
+}
+
+type diffReport struct {
+	Changes  spec.SpecDifferences `json:"changes"`
+	Breaking int                  `json:"breaking"`
+}
+
+// Execute compares the specs
+func (c *DiffSpec) Execute(args []string) error {
+	if len(args) != 2 {
+		return errors.New("The diff command requires the urls of the old and the new swagger document")
+	}
+
+	left, err := spec.Load(args[0])
+	if err != nil {
+		return err
+	}
+	right, err := spec.Load(args[1])
+	if err != nil {
+		return err
+	}
+
+	changes := spec.Diff(left, right)
+	if err := writeDiffReport(os.Stdout, c.Format, changes); err != nil {
+		return err
+	}
+
+	if breaking := changes.Breaking(); len(breaking) > 0 {
+		return fmt.Errorf("found %d breaking changes between %q and %q", len(breaking), args[0], args[1])
+	}
+	return nil
+}
+
+func writeDiffReport(w io.Writer, format string, changes spec.SpecDifferences) error {
+	if format == "json" {
+		if changes == nil {
+			changes = spec.SpecDifferences{}
+		}
+		b, err := json.MarshalIndent(diffReport{Changes: changes, Breaking: len(changes.Breaking())}, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(b))
+		return nil
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "No changes detected")
+		return nil
+	}
+	for _, change := range changes {
+		fmt.Fprintf(w, "- %s\n", change)
+	}
+	fmt.Fprintf(w, "%d changes, %d breaking\n", len(changes), len(changes.Breaking()))
+	return nil
+}
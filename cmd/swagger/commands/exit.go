@@ -0,0 +1,29 @@
+package commands
+
+// Exit codes for the swagger command
+const (
+	// ExitFailure the command failed
+	ExitFailure = 1
+	// ExitInvalid the document was loaded but it is not valid
+	ExitInvalid = 2
+	// ExitUnloadable the document could not be read or parsed
+	ExitUnloadable = 3
+)
+
+// ExitError is an error that makes the swagger command exit with a specific code
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+// ExitCode returns the code the swagger command should exit with for the error
+func ExitCode(err error) int {
+	if ee, ok := err.(*ExitError); ok {
+		return ee.Code
+	}
+	return ExitFailure
+}
@@ -1,13 +1,18 @@
 package generate
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
+	"strings"
+	"time"
 
 	"github.com/vikstrous/go-swagger/scan"
 	"github.com/vikstrous/go-swagger/spec"
+	"github.com/vikstrous/go-swagger/swag"
 	"github.com/jessevdk/go-flags"
 )
 
@@ -16,6 +21,18 @@ type SpecFile struct {
 	BasePath string         `long:"base-path" short:"b" description:"the base path to use" default:"."`
 	Output   flags.Filename `long:"output" short:"o" description:"the file to write to"`
 	Input    flags.Filename `long:"input" short:"i" description:"the file to use as input"`
+	Format   string         `long:"format" short:"f" description:"the format for the spec document" default:"json" choice:"json" choice:"yaml"`
+	Compact  bool           `long:"compact" description:"when present, doesn't prettify the json"`
+	Include  []string       `long:"include" description:"only scan this package for annotations, end it with /... to include the packages below it (can be repeated)"`
+	Exclude  []string       `long:"exclude" description:"don't scan this package for annotations, end it with /... to exclude the packages below it (can be repeated)"`
+	Tags     string         `long:"tags" description:"the build tags to use when loading the packages, separated by commas or spaces"`
+	Models   bool           `long:"scan-models" description:"include all the types annotated with swagger:model, even when nothing uses them"`
+	ValTags  string         `long:"validation-tags" description:"the struct tags to read validations from, like validate, binding and json, separated by commas or spaces"`
+	Routes   bool           `long:"discover-routes" description:"compare the annotated routes with the handlers registered in the code, the registered routes without annotations get a stub operation"`
+	CacheDir string         `long:"cache-dir" description:"the directory to cache the scanned packages in, only the packages that changed are scanned again"`
+	Watch    bool           `long:"watch" description:"keep running and generate the spec again when the go files change"`
+	Interval time.Duration  `long:"watch-interval" description:"how often to check the go files for changes when watching" default:"1s"`
+	Types    flags.Filename `long:"type-mappings" description:"a yaml or json file that maps go types, like github.com/shopspring/decimal.Decimal, to swagger types"`
 }
 
 // Execute runs this command
@@ -24,19 +41,72 @@ func (s *SpecFile) Execute(args []string) error {
 	if err != nil {
 		return err
 	}
+	var mappings map[string]scan.TypeMapping
+	if s.Types != "" {
+		if mappings, err = scan.LoadTypeMappings(string(s.Types)); err != nil {
+			return err
+		}
+	}
 
-	swspec, err := scan.Application(s.BasePath, input, nil, nil)
+	opts := &scan.Opts{
+		BasePath:       s.BasePath,
+		Input:          input,
+		Includes:       s.Include,
+		Excludes:       s.Exclude,
+		BuildTags:      splitTags(s.Tags),
+		ScanModels:     s.Models,
+		ValidationTags: splitTags(s.ValTags),
+		DiscoverRoutes: s.Routes,
+		CacheDir:       s.CacheDir,
+		TypeMappings:   mappings,
+		Explain: func(definition, reason string) {
+			fmt.Fprintf(os.Stderr, "definition %s: %s\n", definition, reason)
+		},
+	}
+	if s.Watch {
+		return s.watch(opts)
+	}
+	swspec, err := scan.Run(opts)
 	if err != nil {
 		return err
 	}
 
-	return writeToFile(swspec, string(s.Output))
+	return writeToFile(swspec, s.Format, !s.Compact, string(s.Output))
+}
+
+// watch writes the spec every time the go files change, until the command is interrupted
+func (s *SpecFile) watch(opts *scan.Opts) error {
+	stop := make(chan struct{})
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt)
+	defer signal.Stop(sigs)
+	go func() {
+		<-sigs
+		close(stop)
+	}()
+
+	return scan.Watch(opts, s.Interval, stop, func(swspec *spec.Swagger, err error) {
+		if err == nil {
+			err = writeToFile(swspec, s.Format, !s.Compact, string(s.Output))
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "generating the spec failed: %v\n", err)
+			return
+		}
+		fmt.Fprintln(os.Stderr, "generated the spec")
+	})
 }
 
 var (
 	newLine = []byte("\n")
 )
 
+func splitTags(tags string) []string {
+	return strings.FieldsFunc(tags, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
+
 func loadSpec(input string) (*spec.Swagger, error) {
 	if fi, err := os.Stat(input); err == nil {
 		if fi.IsDir() {
@@ -51,18 +121,47 @@ func loadSpec(input string) (*spec.Swagger, error) {
 	return nil, nil
 }
 
-func writeToFile(swspec *spec.Swagger, output string) error {
-	var wrtr io.WriteCloser = os.Stdout
-	if output != "" {
-		wrtr = os.Stdout
-		defer wrtr.Close()
+// marshalSpec renders the spec in the requested format. Maps are written in order of their keys,
+// so generating the spec again for the same code gives the same document.
+func marshalSpec(swspec *spec.Swagger, format string, pretty bool) ([]byte, error) {
+	b, err := json.Marshal(swspec)
+	if err != nil {
+		return nil, err
 	}
 
-	b, err := json.Marshal(swspec)
+	switch format {
+	case "yaml":
+		return swag.JSONToYAML(b)
+	case "json", "":
+		if !pretty {
+			return append(b, newLine...), nil
+		}
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, b, "", "  "); err != nil {
+			return nil, err
+		}
+		buf.Write(newLine)
+		return buf.Bytes(), nil
+	}
+	return nil, fmt.Errorf("unknown format %q for the spec", format)
+}
+
+func writeToFile(swspec *spec.Swagger, format string, pretty bool, output string) error {
+	b, err := marshalSpec(swspec, format, pretty)
 	if err != nil {
 		return err
 	}
-	wrtr.Write(b)
-	wrtr.Write(newLine)
-	return nil
+
+	var wrtr io.Writer = os.Stdout
+	if output != "" {
+		fle, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer fle.Close()
+		wrtr = fle
+	}
+
+	_, err = wrtr.Write(b)
+	return err
 }
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/vikstrous/go-swagger/lint"
+	"github.com/vikstrous/go-swagger/spec"
+)
+
+// LintSpec is a command that checks a swagger document
+// against a configurable set of style rules
+type LintSpec struct {
+	Config    string `long:"config" short:"c" description:"the yaml or json file that configures the rules"`
+	Format    string `long:"format" short:"f" description:"the format for the report" default:"text" choice:"text" choice:"json"`
+	ListRules bool   `long:"list-rules" description:"list the available rules and their severity"`
+}
+
+// Execute lints the spec
+func (c *LintSpec) Execute(args []string) error {
+	config := new(lint.Config)
+	if c.Config != "" {
+		cfg, err := lint.LoadConfig(c.Config)
+		if err != nil {
+			return err
+		}
+		config = cfg
+	}
+	linter := lint.New(config)
+
+	if c.ListRules {
+		for _, rule := range linter.Rules() {
+			fmt.Printf("%-28s %-8s %s\n", rule.Name(), linter.SeverityFor(rule), rule.Description())
+		}
+		return nil
+	}
+
+	if len(args) == 0 {
+		return errors.New("The lint command requires the swagger document url to be specified")
+	}
+
+	specDoc, err := spec.Load(args[0])
+	if err != nil {
+		return err
+	}
+
+	issues := linter.Lint(specDoc)
+	if err := writeLintReport(os.Stdout, c.Format, issues); err != nil {
+		return err
+	}
+	if issues.HasErrors() {
+		return fmt.Errorf("the swagger spec at %q has %d lint errors", args[0], issues.Count(lint.Error))
+	}
+	return nil
+}
+
+func writeLintReport(w io.Writer, format string, issues lint.Issues) error {
+	if format == "json" {
+		if issues == nil {
+			issues = lint.Issues{}
+		}
+		b, err := json.MarshalIndent(issues, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(b))
+		return nil
+	}
+
+	for _, issue := range issues {
+		fmt.Fprintf(w, "- %s\n", issue)
+	}
+	fmt.Fprintf(w, "%d errors, %d warnings, %d infos\n", issues.Count(lint.Error), issues.Count(lint.Warning), issues.Count(lint.Info))
+	return nil
+}
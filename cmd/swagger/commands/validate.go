@@ -1,10 +1,13 @@
 package commands
 
 import (
+	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
+	"io"
+	"os"
 
-	swaggererrors "github.com/vikstrous/go-swagger/errors"
 	"github.com/vikstrous/go-swagger/spec"
 	"github.com/vikstrous/go-swagger/strfmt"
 	"github.com/vikstrous/go-swagger/validate"
@@ -14,6 +17,16 @@ import (
 // against the swagger json schema
 type ValidateSpec struct {
 	// SchemaURL string `long:"schema" description:"The schema url to use" default:"http://swagger.io/v2/schema.json"`
+	Format string `long:"format" short:"f" description:"the format for the report" default:"text" choice:"text" choice:"json" choice:"junit"`
+}
+
+type validationReport struct {
+	Document string            `json:"document"`
+	Version  string            `json:"version"`
+	Valid    bool              `json:"valid"`
+	Errors   int               `json:"errors"`
+	Warnings int               `json:"warnings"`
+	Findings validate.Findings `json:"findings"`
 }
 
 // Execute validates the spec
@@ -23,20 +36,104 @@ func (c *ValidateSpec) Execute(args []string) error {
 	}
 
 	swaggerDoc := args[0]
-	specDoc, err := spec.Load(swaggerDoc)
+	specDoc, err := spec.LoadWithPositions(swaggerDoc)
 	if err != nil {
+		return &ExitError{Code: ExitUnloadable, Err: fmt.Errorf("The swagger spec at %q could not be loaded: %v", swaggerDoc, err)}
+	}
+
+	findings := validate.SpecFindings(specDoc, strfmt.Default)
+	if findings == nil {
+		findings = validate.Findings{}
+	}
+	report := validationReport{
+		Document: swaggerDoc,
+		Version:  specDoc.Version(),
+		Valid:    !findings.HasErrors(),
+		Errors:   len(findings.Errors()),
+		Warnings: len(findings.Warnings()),
+		Findings: findings,
+	}
+	if err := writeValidationReport(os.Stdout, c.Format, report); err != nil {
+		return err
+	}
+
+	if !report.Valid {
+		return &ExitError{Code: ExitInvalid, Err: fmt.Errorf("The swagger spec at %q is invalid against swagger specification %s", swaggerDoc, report.Version)}
+	}
+	return nil
+}
+
+func writeValidationReport(w io.Writer, format string, report validationReport) error {
+	switch format {
+	case "json":
+		b, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(b))
 		return nil
+	case "junit":
+		return writeJUnitReport(w, report)
 	}
 
-	result := validate.Spec(specDoc, strfmt.Default)
-	if result == nil {
-		fmt.Printf("The swagger spec at %q is valid against swagger specification %s\n", swaggerDoc, specDoc.Version())
+	if report.Valid {
+		fmt.Fprintf(w, "The swagger spec at %q is valid against swagger specification %s\n", report.Document, report.Version)
 	} else {
-		str := fmt.Sprintf("The swagger spec at %q is invalid against swagger specification %s. see errors :\n", swaggerDoc, specDoc.Version())
-		for _, desc := range result.(*swaggererrors.CompositeError).Errors {
-			str += fmt.Sprintf("- %s\n", desc)
+		fmt.Fprintf(w, "The swagger spec at %q is invalid against swagger specification %s. see errors :\n", report.Document, report.Version)
+	}
+	for _, finding := range report.Findings {
+		fmt.Fprintf(w, "- %s\n", finding)
+	}
+	return nil
+}
+
+type junitSuite struct {
+	XMLName  xml.Name    `xml:"testsuite"`
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Type    string `xml:"type,attr"`
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnitReport writes every finding as a test case, errors are failures and
+// warnings are passing test cases with the warning as output
+func writeJUnitReport(w io.Writer, report validationReport) error {
+	suite := junitSuite{Name: report.Document, Failures: report.Errors}
+	for _, finding := range report.Findings {
+		tc := junitCase{Name: finding.Pointer, ClassName: finding.Rule}
+		if tc.Name == "" {
+			tc.Name = "/"
 		}
-		return errors.New(str)
+		if finding.Severity == validate.SeverityError {
+			tc.Failure = &junitFailure{Type: finding.Rule, Message: finding.Message, Text: finding.String()}
+		} else {
+			tc.SystemOut = finding.String()
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+	if len(suite.Cases) == 0 {
+		suite.Cases = append(suite.Cases, junitCase{Name: "/", ClassName: "swagger"})
+	}
+	suite.Tests = len(suite.Cases)
+
+	b, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return err
 	}
+	fmt.Fprint(w, xml.Header)
+	fmt.Fprintln(w, string(b))
 	return nil
 }
@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/vikstrous/go-swagger/scan"
+	"github.com/vikstrous/go-swagger/spec"
+)
+
+// VerifySpec is a command that scans the code of a server
+// and compares it with the swagger document it implements
+type VerifySpec struct {
+	BasePath string `long:"base-path" short:"b" description:"the package of the server to scan" default:"."`
+	Tags     string `long:"tags" description:"the build tags to use when loading the packages, separated by commas or spaces"`
+	Format   string `long:"format" short:"f" description:"the format for the report" default:"text" choice:"text" choice:"json"`
+	Types    string `long:"type-mappings" description:"a yaml or json file that maps go types, like github.com/shopspring/decimal.Decimal, to swagger types"`
+}
+
+type verificationReport struct {
+	Document     string             `json:"document"`
+	BasePath     string             `json:"basePath"`
+	Valid        bool               `json:"valid"`
+	Verification *scan.Verification `json:"verification"`
+}
+
+// Execute verifies the code against the spec
+func (c *VerifySpec) Execute(args []string) error {
+	if len(args) == 0 {
+		return errors.New("The verify command requires the swagger document url to be specified")
+	}
+
+	swaggerDoc := args[0]
+	specDoc, err := spec.Load(swaggerDoc)
+	if err != nil {
+		return &ExitError{Code: ExitUnloadable, Err: fmt.Errorf("The swagger spec at %q could not be loaded: %v", swaggerDoc, err)}
+	}
+
+	var mappings map[string]scan.TypeMapping
+	if c.Types != "" {
+		if mappings, err = scan.LoadTypeMappings(c.Types); err != nil {
+			return err
+		}
+	}
+
+	verification, err := scan.Verify(&scan.Opts{
+		BasePath: c.BasePath,
+		BuildTags: strings.FieldsFunc(c.Tags, func(r rune) bool {
+			return r == ',' || r == ' '
+		}),
+		TypeMappings: mappings,
+	}, specDoc.Spec())
+	if err != nil {
+		return err
+	}
+
+	report := verificationReport{
+		Document:     swaggerDoc,
+		BasePath:     c.BasePath,
+		Valid:        !verification.Failed(),
+		Verification: verification,
+	}
+	if err := writeVerificationReport(os.Stdout, c.Format, report); err != nil {
+		return err
+	}
+	if !report.Valid {
+		return &ExitError{Code: ExitInvalid, Err: fmt.Errorf("The code at %q doesn't match the swagger spec at %q", c.BasePath, swaggerDoc)}
+	}
+	return nil
+}
+
+func writeVerificationReport(w io.Writer, format string, report verificationReport) error {
+	if format == "json" {
+		b, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(b))
+		return nil
+	}
+
+	if report.Valid {
+		fmt.Fprintf(w, "The code at %q matches the swagger spec at %q\n", report.BasePath, report.Document)
+		return nil
+	}
+	fmt.Fprintf(w, "The code at %q doesn't match the swagger spec at %q. see differences :\n", report.BasePath, report.Document)
+	for _, err := range report.Verification.Errors() {
+		for _, line := range strings.Split(err.Error(), "\n") {
+			fmt.Fprintf(w, "- %s\n", line)
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"github.com/jessevdk/go-flags"
 	"github.com/vikstrous/go-swagger/cmd/swagger/commands"
@@ -18,6 +19,9 @@ Swagger tries to support you as best as possible when building API's.
 It aims to represent the contract of your API with a language agnostic description of your application in json or yaml.
 `
 	parser.AddCommand("validate", "validate the swagger document", "validate the provided swagger document against a swagger spec", &commands.ValidateSpec{})
+	parser.AddCommand("lint", "check the swagger document for style issues", "check the provided swagger document against a configurable set of style rules", &commands.LintSpec{})
+	parser.AddCommand("diff", "compare 2 swagger documents", "report the changes between 2 versions of a swagger document and fail when some of them break existing clients", &commands.DiffSpec{})
+	parser.AddCommand("verify", "verify the code of a server against the swagger document", "scan the code of a server and report the operations, parameters and models that differ from the provided swagger document", &commands.VerifySpec{})
 
 	genpar, err := parser.AddCommand("generate", "genererate go code", "generate go code for the swagger spec file", &commands.Generate{})
 	if err != nil {
@@ -46,5 +50,10 @@ It aims to represent the contract of your API with a language agnostic descripti
 		}
 	}
 
-	parser.Parse()
+	if _, err := parser.Parse(); err != nil {
+		if fe, ok := err.(*flags.Error); ok && fe.Type == flags.ErrHelp {
+			return
+		}
+		os.Exit(commands.ExitCode(err))
+	}
 }
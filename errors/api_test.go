@@ -72,4 +72,17 @@ func TestAPIErrors(t *testing.T) {
 	assert.Error(t, err)
 	assert.EqualValues(t, http.StatusNotAcceptable, err.Code())
 	assert.EqualValues(t, "unsupported media type requested, only [application/json application/x-yaml] are available", err.Error())
+
+	err = BodyTooLarge(1024)
+	assert.Error(t, err)
+	assert.EqualValues(t, http.StatusRequestEntityTooLarge, err.Code())
+	assert.EqualValues(t, "request body is larger than 1024 bytes", err.Error())
+
+	err = TooManyFormFields(10)
+	assert.EqualValues(t, http.StatusRequestEntityTooLarge, err.Code())
+	assert.EqualValues(t, "request has more than 10 form fields", err.Error())
+
+	err = TooManyFiles(2)
+	assert.EqualValues(t, http.StatusRequestEntityTooLarge, err.Code())
+	assert.EqualValues(t, "request has more than 2 files", err.Error())
 }
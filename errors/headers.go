@@ -27,6 +27,9 @@ func (e *Validation) Code() int32 {
 const (
 	contentTypeFail    = `unsupported media type %q, only %v are allowed`
 	responseFormatFail = `unsupported media type requested, only %v are available`
+	bodyTooLargeFail   = `request body is larger than %d bytes`
+	formFieldsFail     = `request has more than %d form fields`
+	filesFail          = `request has more than %d files`
 )
 
 // InvalidContentType error for an invalid content type
@@ -60,3 +63,36 @@ func InvalidResponseFormat(value string, allowed []string) *Validation {
 		message: fmt.Sprintf(responseFormatFail, allowed),
 	}
 }
+
+// BodyTooLarge error for a request body that is larger than allowed
+func BodyTooLarge(limit int64) *Validation {
+	return &Validation{
+		code:    http.StatusRequestEntityTooLarge,
+		Name:    "body",
+		In:      "body",
+		Value:   limit,
+		message: fmt.Sprintf(bodyTooLargeFail, limit),
+	}
+}
+
+// TooManyFormFields error for a request with more form fields than allowed
+func TooManyFormFields(limit int) *Validation {
+	return &Validation{
+		code:    http.StatusRequestEntityTooLarge,
+		Name:    "formData",
+		In:      "formData",
+		Value:   limit,
+		message: fmt.Sprintf(formFieldsFail, limit),
+	}
+}
+
+// TooManyFiles error for a multipart request with more files than allowed
+func TooManyFiles(limit int) *Validation {
+	return &Validation{
+		code:    http.StatusRequestEntityTooLarge,
+		Name:    "formData",
+		In:      "formData",
+		Value:   limit,
+		message: fmt.Sprintf(filesFail, limit),
+	}
+}
@@ -9,9 +9,9 @@ import (
 // APIVerificationFailed is an error that contains all the missing info for a mismatched section
 // between the api registrations and the api spec
 type APIVerificationFailed struct {
-	Section              string
-	MissingSpecification []string
-	MissingRegistration  []string
+	Section              string   `json:"section,omitempty"`
+	MissingSpecification []string `json:"missingSpecification,omitempty"`
+	MissingRegistration  []string `json:"missingRegistration,omitempty"`
 }
 
 //
@@ -1,8 +1,6 @@
 package main
 
 import (
-	"io"
-
 	"github.com/vikstrous/go-swagger/errors"
 	"github.com/vikstrous/go-swagger/httpkit"
 
@@ -18,18 +16,18 @@ import (
 func configureAPI(api *operations.SwaggerPetstoreAPI) {
 	// configure the api here
 	api.ServeError = errors.ServeError
+	// to allow browsers on other origins to call the api:
+	// api.CORS = &middleware.CORSOpts{AllowedOrigins: []string{"*"}}
+	// to log the requests that are served:
+	// api.Observers = append(api.Observers, middleware.NewAccessLog(os.Stderr))
 
 	api.JSONConsumer = httpkit.JSONConsumer()
 
-	api.XMLConsumer = httpkit.ConsumerFunc(func(r io.Reader, target interface{}) error {
-		return errors.NotImplemented("xml consumer has not yet been implemented")
-	})
+	api.XMLConsumer = httpkit.XMLConsumer()
 
 	api.JSONProducer = httpkit.JSONProducer()
 
-	api.XMLProducer = httpkit.ProducerFunc(func(w io.Writer, data interface{}) error {
-		return errors.NotImplemented("xml producer has not yet been implemented")
-	})
+	api.XMLProducer = httpkit.XMLProducer()
 
 	api.APIKeyAuth = func(token string) (*models.User, error) {
 		return nil, errors.NotImplemented("api key auth apiKey from header has not yet been implemented")
@@ -30,14 +30,14 @@ func NewAddPet(ctx *middleware.Context, handler AddPetHandler) *AddPet {
 // AddPet
 type AddPet struct {
 	Context *middleware.Context
-	Params  AddPetParams
 	Handler AddPetHandler
 }
 
 func (o *AddPet) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
-	route, _ := o.Context.RouteInfo(r)
+	route, r, _ := o.Context.RouteInfoWithRequest(r)
+	var params AddPetParams
 
-	uprinc, err := o.Context.Authorize(r, route)
+	uprinc, r, err := o.Context.AuthorizeWithRequest(r, route)
 	if err != nil {
 		o.Context.Respond(rw, r, route.Produces, route, err)
 		return
@@ -47,12 +47,12 @@ func (o *AddPet) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		principal = uprinc.(*models.User) // it's ok this is really a models.User
 	}
 
-	if err := o.Context.BindValidRequest(r, route, &o.Params); err != nil { // bind params
+	if _, err := o.Context.BindValidRequestWithRequest(r, route, &params); err != nil { // bind params
 		o.Context.Respond(rw, r, route.Produces, route, err)
 		return
 	}
 
-	err = o.Handler.Handle(o.Params, principal) // actually handle the request
+	err = o.Handler.Handle(params, principal) // actually handle the request
 	if err != nil {
 		o.Context.Respond(rw, r, route.Produces, route, err)
 		return
@@ -29,19 +29,19 @@ func NewDeleteOrder(ctx *middleware.Context, handler DeleteOrderHandler) *Delete
 // DeleteOrder For valid response try integer IDs with value < 1000. Anything above 1000 or nonintegers will generate API errors
 type DeleteOrder struct {
 	Context *middleware.Context
-	Params  DeleteOrderParams
 	Handler DeleteOrderHandler
 }
 
 func (o *DeleteOrder) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
-	route, _ := o.Context.RouteInfo(r)
+	route, r, _ := o.Context.RouteInfoWithRequest(r)
+	var params DeleteOrderParams
 
-	if err := o.Context.BindValidRequest(r, route, &o.Params); err != nil { // bind params
+	if _, err := o.Context.BindValidRequestWithRequest(r, route, &params); err != nil { // bind params
 		o.Context.Respond(rw, r, route.Produces, route, err)
 		return
 	}
 
-	err := o.Handler.Handle(o.Params) // actually handle the request
+	err := o.Handler.Handle(params) // actually handle the request
 	if err != nil {
 		o.Context.Respond(rw, r, route.Produces, route, err)
 		return
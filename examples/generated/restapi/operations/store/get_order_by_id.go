@@ -30,19 +30,19 @@ func NewGetOrderByID(ctx *middleware.Context, handler GetOrderByIDHandler) *GetO
 // GetOrderByID For valid response try integer IDs with value <= 5 or > 10. Other values will generated exceptions
 type GetOrderByID struct {
 	Context *middleware.Context
-	Params  GetOrderByIDParams
 	Handler GetOrderByIDHandler
 }
 
 func (o *GetOrderByID) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
-	route, _ := o.Context.RouteInfo(r)
+	route, r, _ := o.Context.RouteInfoWithRequest(r)
+	var params GetOrderByIDParams
 
-	if err := o.Context.BindValidRequest(r, route, &o.Params); err != nil { // bind params
+	if _, err := o.Context.BindValidRequestWithRequest(r, route, &params); err != nil { // bind params
 		o.Context.Respond(rw, r, route.Produces, route, err)
 		return
 	}
 
-	res, err := o.Handler.Handle(o.Params) // actually handle the request
+	res, err := o.Handler.Handle(params) // actually handle the request
 	if err != nil {
 		o.Context.Respond(rw, r, route.Produces, route, err)
 		return
@@ -114,6 +114,24 @@ type SwaggerPetstoreAPI struct {
 	// ServeError is called when an error is received, there is a default handler
 	// but you can set your own with this
 	ServeError func(http.ResponseWriter, *http.Request, error)
+
+	// CORS enables cross origin requests when it is set,
+	// OPTIONS requests are then answered from the spec
+	CORS *middleware.CORSOpts
+
+	// Observers are told about the requests the API serves,
+	// for access logs and metrics
+	Observers []middleware.Observer
+
+	// ErrorMapper maps the domain errors the handlers return
+	// to the responses documented in the spec
+	ErrorMapper *middleware.ErrorMapper
+
+	// PanicHooks are called with the stack when serving a request panics
+	PanicHooks []middleware.PanicHook
+
+	// Docs serves an API explorer for the spec when it is set
+	Docs *middleware.DocsOpts
 }
 
 // SetDefaultProduces sets the default produces media type
@@ -364,10 +382,26 @@ func (s *SwaggerPetstoreAPI) initHandlerCache() {
 }
 
 // Serve creates a http handler to serve the API over HTTP
-// can be used directly in http.ListenAndServe(":8000", api.Serve())
+// can be used directly in http.ListenAndServe(":8000", api.Serve()),
+// the middlewares are configured the first time the API is served
 func (s *SwaggerPetstoreAPI) Serve() http.Handler {
-	if len(s.handlers) == 0 {
+	if s.context == nil {
 		s.initHandlerCache()
+		if s.CORS != nil {
+			s.context.WithCORS(*s.CORS)
+		}
+		if len(s.Observers) > 0 {
+			s.context.WithObserver(s.Observers...)
+		}
+		if s.ErrorMapper != nil {
+			s.context.WithErrorMapper(s.ErrorMapper)
+		}
+		if len(s.PanicHooks) > 0 {
+			s.context.WithPanicHook(s.PanicHooks...)
+		}
+		if s.Docs != nil {
+			s.context.WithDocs(*s.Docs)
+		}
 	}
 
 	return s.context.APIHandler()
@@ -29,19 +29,19 @@ func NewCreateUsersWithListInput(ctx *middleware.Context, handler CreateUsersWit
 // CreateUsersWithListInput
 type CreateUsersWithListInput struct {
 	Context *middleware.Context
-	Params  CreateUsersWithListInputParams
 	Handler CreateUsersWithListInputHandler
 }
 
 func (o *CreateUsersWithListInput) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
-	route, _ := o.Context.RouteInfo(r)
+	route, r, _ := o.Context.RouteInfoWithRequest(r)
+	var params CreateUsersWithListInputParams
 
-	if err := o.Context.BindValidRequest(r, route, &o.Params); err != nil { // bind params
+	if _, err := o.Context.BindValidRequestWithRequest(r, route, &params); err != nil { // bind params
 		o.Context.Respond(rw, r, route.Produces, route, err)
 		return
 	}
 
-	err := o.Handler.Handle(o.Params) // actually handle the request
+	err := o.Handler.Handle(params) // actually handle the request
 	if err != nil {
 		o.Context.Respond(rw, r, route.Produces, route, err)
 		return
@@ -29,19 +29,19 @@ func NewLoginUser(ctx *middleware.Context, handler LoginUserHandler) *LoginUser
 // LoginUser
 type LoginUser struct {
 	Context *middleware.Context
-	Params  LoginUserParams
 	Handler LoginUserHandler
 }
 
 func (o *LoginUser) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
-	route, _ := o.Context.RouteInfo(r)
+	route, r, _ := o.Context.RouteInfoWithRequest(r)
+	var params LoginUserParams
 
-	if err := o.Context.BindValidRequest(r, route, &o.Params); err != nil { // bind params
+	if _, err := o.Context.BindValidRequestWithRequest(r, route, &params); err != nil { // bind params
 		o.Context.Respond(rw, r, route.Produces, route, err)
 		return
 	}
 
-	res, err := o.Handler.Handle(o.Params) // actually handle the request
+	res, err := o.Handler.Handle(params) // actually handle the request
 	if err != nil {
 		o.Context.Respond(rw, r, route.Produces, route, err)
 		return
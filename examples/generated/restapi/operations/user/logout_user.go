@@ -33,9 +33,9 @@ type LogoutUser struct {
 }
 
 func (o *LogoutUser) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
-	route, _ := o.Context.RouteInfo(r)
+	route, r, _ := o.Context.RouteInfoWithRequest(r)
 
-	if err := o.Context.BindValidRequest(r, route, nil); err != nil { // bind params
+	if _, err := o.Context.BindValidRequestWithRequest(r, route, nil); err != nil { // bind params
 		o.Context.Respond(rw, r, route.Produces, route, err)
 		return
 	}
@@ -25,6 +25,23 @@
 //     - application/json
 //     - application/xml
 //
+//     Tags:
+//     - name: pets
+//       description: Everything about the pets
+//       externalDocs:
+//         url: http://example.com/pets
+//     - name: users
+//
+//     Extensions:
+//       x-api-id: petstore
+//       x-audience:
+//         - internal
+//         - partners
+//
+//     ExternalDocs:
+//       description: The guide for the API
+//       url: http://example.com/guide
+//
 //
 // swagger:meta
 package classification
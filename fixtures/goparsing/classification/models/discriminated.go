@@ -0,0 +1,67 @@
+package models
+
+// Fish is anything that swims in an aquarium
+//
+// swagger:model fish
+// swagger:discriminator kind
+type Fish interface {
+	FishName() string
+}
+
+// A Salmon is a fish that swims upstream
+//
+// swagger:model salmon
+type Salmon struct {
+	// The name of the salmon
+	Name string `json:"name"`
+
+	// How far upstream it swam
+	Distance int64 `json:"distance"`
+}
+
+// FishName the name of the salmon
+func (s Salmon) FishName() string { return s.Name }
+
+// A Shark is a fish with teeth
+//
+// swagger:model shark
+type Shark struct {
+	// The name of the shark
+	Name string `json:"name"`
+
+	// The number of teeth
+	Teeth int32 `json:"teeth"`
+}
+
+// FishName the name of the shark
+func (s *Shark) FishName() string { return s.Name }
+
+// Nemo is a fish that isn't annotated as a model, so it's not one of the fish
+type Nemo struct {
+	Name string `json:"name"`
+}
+
+// FishName the name of the fish
+func (n Nemo) FishName() string { return n.Name }
+
+// Swimmer is an interface without a discriminator
+type Swimmer interface {
+	Swim()
+}
+
+// An Aquarium holds fish
+//
+// swagger:model aquarium
+type Aquarium struct {
+	// The biggest fish in the aquarium
+	Biggest Fish `json:"biggest"`
+
+	// All the fish in the aquarium
+	Fishes []Fish `json:"fishes"`
+
+	// Something that swims by
+	Swimmer Swimmer `json:"swimmer"`
+
+	// Anything at all
+	Decoration interface{} `json:"decoration"`
+}
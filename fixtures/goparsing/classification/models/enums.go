@@ -0,0 +1,36 @@
+package models
+
+// PetStatus is the state a pet is in at the store
+//
+// swagger:enum PetStatus
+type PetStatus string
+
+const (
+	// PetStatusAvailable the pet can be adopted
+	PetStatusAvailable PetStatus = "available"
+	// PetStatusPending the adoption of the pet is being processed
+	PetStatusPending PetStatus = "pending"
+	PetStatusSold    PetStatus = "sold" // the pet has a new home
+)
+
+// Priority is how urgent a task is
+//
+// swagger:enum Priority
+type Priority int32
+
+const (
+	// PriorityLow can wait
+	PriorityLow Priority = iota + 1
+	// PriorityMedium should be done this week
+	PriorityMedium
+	// PriorityHigh has to be done today
+	PriorityHigh
+)
+
+// Color isn't annotated, so the constants aren't turned into an enum
+type Color string
+
+const (
+	// ColorRed is red
+	ColorRed Color = "red"
+)
@@ -0,0 +1,53 @@
+package models
+
+// A Settings model has defaults and examples
+//
+// example: {"name": "dark", "size": 12, "tags": ["night"]}
+// swagger:model settings
+type Settings struct {
+	// The name of the theme
+	//
+	// default: light
+	// example: "dark "
+	Name string `json:"name"`
+
+	// The size of the font
+	//
+	// default: 14
+	// example: 12
+	Size int32 `json:"size"`
+
+	// The ratio of the lines
+	//
+	// default: 1.5
+	Ratio float64 `json:"ratio"`
+
+	// Whether it's enabled
+	//
+	// default: true
+	Enabled bool `json:"enabled"`
+
+	// The number of retries, for example: call it twice
+	// when the first call can fail, the default: 3 is fine
+	//
+	// default: 2
+	Retries int32 `json:"retries"`
+
+	// The tags of the theme
+	//
+	// default: day, light
+	// example: ["night", "dark"]
+	Tags []string `json:"tags"`
+
+	// The extra options
+	//
+	// example: {"contrast": 2, "font": "mono"}
+	Options map[string]string `json:"options"`
+
+	// The pet of the theme
+	//
+	// example: {"name": "fido"}
+	Mascot struct {
+		Name string `json:"name"`
+	} `json:"mascot"`
+}
@@ -0,0 +1,31 @@
+package models
+
+// A Legacy model is kept for the old clients.
+//
+// deprecated: true
+//
+// Extensions:
+//   x-go-type: legacy.Model
+//   x-owner:
+//     team: pets
+//     since: 2015
+//
+// ExternalDocs:
+//   description: How to move to the new model
+//   url: http://example.com/migrate
+//
+// swagger:model legacy
+type Legacy struct {
+	// The id of the model
+	//
+	// deprecated: true
+	// Extensions:
+	//   x-nullable: false
+	ID int64 `json:"id"`
+
+	// The name of the model
+	//
+	// ExternalDocs:
+	//   url: http://example.com/names
+	Name string `json:"name"`
+}
@@ -291,3 +291,18 @@ type MapTastic struct {
 		PtrBaz map[string]*string `json:"ptrBaz"`
 	} `json:"ptrEmbs"`
 }
+
+// An EnumModel has properties with types that have an enum
+type EnumModel struct {
+	// The status of the pet
+	Status PetStatus `json:"status"`
+
+	// The priority of the task
+	Priority *Priority `json:"priority"`
+
+	// The statuses to search for
+	Statuses []PetStatus `json:"statuses"`
+
+	// Color isn't an enum
+	Color Color `json:"color"`
+}
@@ -0,0 +1,37 @@
+package models
+
+// A Tagged model has validations in its struct tags
+//
+// swagger:model tagged
+type Tagged struct {
+	// The id of the model
+	ID int64 `json:"id" validate:"required,gt=0"`
+
+	// The name of the model
+	Name string `json:"name,omitempty" validate:"min=1,max=64,alphanum"`
+
+	// The email of the owner
+	Email string `json:"email,omitempty" binding:"required,email"`
+
+	// The code is exactly 3 characters
+	Code string `json:"code,omitempty" validate:"len=3"`
+
+	// The score of the model
+	//
+	// maximum: 10
+	Score float64 `json:"score,omitempty" validate:"gte=1,lt=100"`
+
+	// The labels of the model
+	Labels []string `json:"labels,omitempty" validate:"max=5,unique,dive,min=2,max=10"`
+
+	// The kind of the model
+	Kind string `json:"kind,omitempty" validate:"oneof=big small"`
+
+	// The note isn't required
+	//
+	// required: false
+	Note string `json:"note" validate:"required"`
+
+	// Either of the alternatives
+	Either string `json:"either,omitempty" validate:"email|url"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"math/big"
+	"time"
+)
+
+// A Money amount is marshalled to json as a decimal string.
+//
+// swagger:type string decimal
+type Money struct {
+	units int64
+	nanos int32
+}
+
+// A Level marshals to its name.
+//
+// swagger:type string
+type Level int
+
+// A Payment is a model with types that are mapped to swagger types
+//
+// swagger:model payment
+type Payment struct {
+	// The id of the payment
+	ID uint64 `json:"id"`
+
+	// The number of attempts
+	Attempts uint16 `json:"attempts"`
+
+	// The sequence of the payment
+	Sequence uint32 `json:"sequence"`
+
+	// The amount that was paid
+	Amount Money `json:"amount"`
+
+	// The amounts that were refunded
+	Refunds []Money `json:"refunds"`
+
+	// The level of the payment
+	Level Level `json:"level"`
+
+	// When the payment was made
+	PaidAt time.Time `json:"paidAt"`
+
+	// The total of all the payments
+	Total big.Int `json:"total"`
+
+	// The exchange rate of the payment
+	Rate big.Float `json:"rate"`
+}
@@ -0,0 +1,26 @@
+package operations
+
+import "github.com/vikstrous/go-swagger/fixtures/goparsing/classification/models"
+
+// EnumParams has params with types that have an enum
+//
+// swagger:parameters listPets
+type EnumParams struct {
+	// The status of the pets to list
+	//
+	// in: query
+	Status models.PetStatus `json:"status"`
+
+	// The priorities of the tasks to list
+	//
+	// in: query
+	Priorities []models.Priority `json:"priorities"`
+}
+
+// EnumResponse has a header with a type that has an enum
+//
+// swagger:response enumResponse
+type EnumResponse struct {
+	// The status of the pet
+	Status models.PetStatus `json:"X-Pet-Status"`
+}
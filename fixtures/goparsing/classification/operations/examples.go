@@ -0,0 +1,43 @@
+package operations
+
+import "github.com/vikstrous/go-swagger/fixtures/goparsing/classification/models"
+
+// ExampleParams has params with defaults and examples
+//
+// swagger:parameters updateSettings
+type ExampleParams struct {
+	// The number of items to return
+	//
+	// in: query
+	// default: 20
+	// example: 50
+	Limit int64 `json:"limit"`
+
+	// The ids to look for
+	//
+	// in: query
+	// default: [1, 2]
+	IDs []int64 `json:"ids"`
+
+	// The settings to store
+	//
+	// in: body
+	// example: {"name": "dark"}
+	Settings models.Settings `json:"settings"`
+}
+
+// ExampleResponse has a header with a default
+//
+// swagger:response exampleResponse
+type ExampleResponse struct {
+	// The rate limit
+	//
+	// default: 100
+	RateLimit int32 `json:"X-Rate-Limit"`
+
+	// The settings
+	//
+	// in: body
+	// example: {"name": "light"}
+	Settings models.Settings `json:"settings"`
+}
@@ -0,0 +1,20 @@
+package operations
+
+// LegacyParams has deprecated params
+//
+// swagger:parameters getLegacy
+type LegacyParams struct {
+	// The id of the model
+	//
+	// in: path
+	// required: true
+	ID int64 `json:"id"`
+
+	// The version of the model
+	//
+	// in: query
+	// deprecated: true
+	// Extensions:
+	//   x-since: 1.2
+	Version string `json:"version"`
+}
@@ -0,0 +1,25 @@
+package operations
+
+// TaggedParams has params with validations in their struct tags
+//
+// swagger:parameters listTagged
+type TaggedParams struct {
+	// The number of items to return
+	//
+	// in: query
+	Limit int32 `json:"limit" validate:"required,min=1,max=100"`
+
+	// The ids to look for
+	//
+	// in: query
+	// min items: 2
+	IDs []int64 `json:"ids" binding:"min=1,dive,gt=0"`
+}
+
+// TaggedResponse has a header with validations in its struct tags
+//
+// swagger:response taggedResponse
+type TaggedResponse struct {
+	// The request id
+	RequestID string `json:"X-Request-Id" validate:"uuid4"`
+}
@@ -0,0 +1,81 @@
+// Package main is an api that registers its handlers in a few different ways,
+// not all of them are annotated and not all the annotated routes are registered.
+//
+//     Schemes: http
+//     Host: localhost
+//     BasePath: /
+//     Version: 0.0.1
+//
+// swagger:meta
+package main
+
+import (
+	"net/http"
+
+	"github.com/vikstrous/go-swagger/httpkit"
+	"github.com/vikstrous/go-swagger/httpkit/middleware/untyped"
+)
+
+const ordersPath = "/orders/{id:[0-9]+}"
+
+// router has the methods of a router like the one in gorilla/mux
+type router struct{}
+
+type route struct{}
+
+func (r *router) HandleFunc(path string, handler http.HandlerFunc) *route { return &route{} }
+
+func (r *route) Methods(methods ...string) *route { return r }
+
+// cache has a Get method that takes a key that looks like a path, it doesn't register routes
+type cache struct{}
+
+func (c *cache) Get(key string, value interface{}) bool { return false }
+
+func main() {
+	http.HandleFunc("/health", health)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /pets", listPets)
+	mux.Handle("/pets/{id}", http.HandlerFunc(getPet))
+
+	r := new(router)
+	r.HandleFunc(ordersPath, getOrder).Methods("GET", "HEAD")
+
+	var api *untyped.API
+	api.RegisterOperation("deleteOrder", httpkit.OperationHandlerFunc(deleteOrder))
+	api.RegisterOperation("archiveOrder", httpkit.OperationHandlerFunc(deleteOrder))
+
+	var pets cache
+	var pet interface{}
+	pets.Get("/pets/1", &pet)
+
+	http.ListenAndServe(":8000", mux)
+}
+
+func health(rw http.ResponseWriter, r *http.Request) {}
+
+// swagger:route GET /pets pets listPets
+//
+// Lists the pets.
+func listPets(rw http.ResponseWriter, r *http.Request) {}
+
+// swagger:route GET /pets/{id} pets getPet
+//
+// Gets a pet.
+func getPet(rw http.ResponseWriter, r *http.Request) {}
+
+// swagger:route POST /pets pets createPet
+//
+// Creates a pet, the handler isn't registered.
+func createPet(rw http.ResponseWriter, r *http.Request) {}
+
+// swagger:route GET /orders/{id} orders getOrder
+//
+// Gets an order.
+func getOrder(rw http.ResponseWriter, r *http.Request) {}
+
+// swagger:route DELETE /orders/{id} orders deleteOrder
+//
+// Deletes an order.
+func deleteOrder(params interface{}) (interface{}, error) { return nil, nil }
@@ -0,0 +1,22 @@
+// Package routes has routes that aren't part of an application, they're parsed on their own
+package routes
+
+// ServeLegacy swagger:route GET /legacy/{id} legacy getLegacy
+//
+// Gets a legacy model.
+//
+// Responses:
+//    default: genericError
+//
+// Deprecated: true
+//
+// Extensions:
+//   x-rate-limit: 100
+//   x-owners:
+//     - name: pets
+//       required: true
+//
+// ExternalDocs:
+//   description: The new way to get the model
+//   url: http://example.com/models
+func ServeLegacy() {}
@@ -68,7 +68,7 @@ type definitionGenerator struct {
 func (m *definitionGenerator) Generate() error {
 	mod, err := makeGenDefinition(m.Name, m.Target, m.Model, m.SpecDoc)
 	if err != nil {
-		return err
+		return errorAt(m.SpecDoc, definitionPointer(m.Name), err)
 	}
 	if m.DumpData {
 		bb, _ := json.MarshalIndent(swag.ToDynamicJSON(mod), "", " ")
@@ -750,14 +750,32 @@ func (sg *schemaGenContext) buildXMLName() error {
 	if sg.Schema.XML == nil {
 		return nil
 	}
-	sg.GenSchema.XMLName = sg.Name
-
+	xmlName := sg.Name
 	if sg.Schema.XML.Name != "" {
-		sg.GenSchema.XMLName = sg.Schema.XML.Name
-		if sg.Schema.XML.Attribute {
-			sg.GenSchema.XMLName += ",attr"
+		xmlName = sg.Schema.XML.Name
+	}
+	if sg.Schema.XML.Namespace != "" {
+		xmlName = sg.Schema.XML.Namespace + " " + xmlName
+	}
+
+	// encoding/xml writes the items of a wrapped array inside the wrapper element
+	// when the tag has the form wrapper>item
+	if sg.Schema.XML.Wrapped && sg.Schema.Items != nil && sg.Schema.Items.Schema != nil {
+		itemName := sg.Name
+		if sg.Schema.XML.Name != "" {
+			itemName = sg.Schema.XML.Name
 		}
+		if sg.Schema.Items.Schema.XML != nil && sg.Schema.Items.Schema.XML.Name != "" {
+			itemName = sg.Schema.Items.Schema.XML.Name
+		}
+		sg.GenSchema.XMLName = xmlName + ">" + itemName
+		return nil
+	}
+
+	if sg.Schema.XML.Attribute {
+		xmlName += ",attr"
 	}
+	sg.GenSchema.XMLName = xmlName
 	return nil
 }
 
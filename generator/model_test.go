@@ -1302,3 +1302,30 @@ func findProperty(properties []GenSchema, name string) *GenSchema {
 func getDefinitionProperty(genModel *GenDefinition, name string) *GenSchema {
 	return findProperty(genModel.Properties, name)
 }
+
+func TestBuildXMLName(t *testing.T) {
+	withXML := func(schema *spec.Schema, xml *spec.XMLObject) spec.Schema {
+		schema.XML = xml
+		return *schema
+	}
+	namedItems := withXML(spec.StringProperty(), &spec.XMLObject{Name: "name"})
+
+	for _, tc := range []struct {
+		schema   spec.Schema
+		expected string
+	}{
+		{*spec.StringProperty(), ""},
+		{withXML(spec.StringProperty(), &spec.XMLObject{}), "petName"},
+		{withXML(spec.StringProperty(), &spec.XMLObject{Name: "name"}), "name"},
+		{withXML(spec.StringProperty(), &spec.XMLObject{Attribute: true}), "petName,attr"},
+		{withXML(spec.StringProperty(), &spec.XMLObject{Name: "name", Namespace: "http://example.com/schema", Attribute: true}), "http://example.com/schema name,attr"},
+		{withXML(spec.ArrayProperty(&namedItems), &spec.XMLObject{Name: "names", Wrapped: true}), "names>name"},
+		{withXML(spec.ArrayProperty(spec.StringProperty()), &spec.XMLObject{Wrapped: true}), "petName>petName"},
+		{withXML(spec.ArrayProperty(spec.StringProperty()), &spec.XMLObject{Name: "tag"}), "tag"},
+	} {
+		sg := schemaGenContext{Name: "petName", Schema: tc.schema}
+		if assert.NoError(t, sg.buildXMLName()) {
+			assert.Equal(t, tc.expected, sg.GenSchema.XMLName)
+		}
+	}
+}
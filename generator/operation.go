@@ -208,10 +208,10 @@ func (b *codeGenOpBuilder) MakeOperation() (GenOperation, error) {
 	operation := b.Operation
 	var params, qp, pp, hp, fp []GenParameter
 	var hasQueryParams bool
-	for _, p := range operation.Parameters {
+	for i, p := range operation.Parameters {
 		cp, err := b.MakeParameter(receiver, &resolver, p)
 		if err != nil {
-			return GenOperation{}, err
+			return GenOperation{}, b.errorAt(fmt.Sprintf("/parameters/%d", i), err)
 		}
 		if cp.IsQueryParam() {
 			hasQueryParams = true
@@ -237,7 +237,7 @@ func (b *codeGenOpBuilder) MakeOperation() (GenOperation, error) {
 			isSuccess := k/100 == 2
 			gr, err := b.MakeResponse(receiver, swag.ToJSONName(b.Name+" "+httpkit.Statuses[k]), isSuccess, &resolver, v)
 			if err != nil {
-				return GenOperation{}, err
+				return GenOperation{}, b.errorAt(fmt.Sprintf("/responses/%d", k), err)
 			}
 			if isSuccess {
 				successResponse = &gr
@@ -251,7 +251,7 @@ func (b *codeGenOpBuilder) MakeOperation() (GenOperation, error) {
 		if operation.Responses.Default != nil {
 			gr, err := b.MakeResponse(receiver, b.Name+" default", false, &resolver, *operation.Responses.Default)
 			if err != nil {
-				return GenOperation{}, err
+				return GenOperation{}, b.errorAt("/responses/default", err)
 			}
 			defaultResponse = &gr
 		}
@@ -289,6 +289,18 @@ func (b *codeGenOpBuilder) MakeOperation() (GenOperation, error) {
 	}, nil
 }
 
+// errorAt annotates an error with the position of a node in the operation that is being built
+func (b *codeGenOpBuilder) errorAt(pointer string, err error) error {
+	if b.Doc == nil {
+		return err
+	}
+	opPtr := operationPointer(b.Doc, b.Operation.ID)
+	if opPtr == "" {
+		return err
+	}
+	return errorAt(b.Doc, opPtr+pointer, err)
+}
+
 func (b *codeGenOpBuilder) MakeResponse(receiver, name string, isSuccess bool, resolver *typeResolver, resp spec.Response) (GenResponse, error) {
 
 	res := GenResponse{
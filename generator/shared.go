@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/vikstrous/go-swagger/jsonpointer"
 	"github.com/vikstrous/go-swagger/spec"
 	"github.com/vikstrous/go-swagger/swag"
 	"golang.org/x/tools/imports"
@@ -113,13 +114,40 @@ func loadSpec(specFile string) (string, *spec.Document, error) {
 	}
 
 	// load swagger spec
-	specDoc, err := spec.Load(specPath)
+	specDoc, err := spec.LoadWithPositions(specPath)
 	if err != nil {
 		return "", nil, err
 	}
 	return specPath, specDoc, nil
 }
 
+// errorAt prefixes the error with the position of the node the json pointer refers to
+func errorAt(doc *spec.Document, pointer string, err error) error {
+	if doc == nil || pointer == "" {
+		return err
+	}
+	if pos, ok := doc.PositionOf(pointer); ok {
+		return fmt.Errorf("%s: %v", pos, err)
+	}
+	return err
+}
+
+func definitionPointer(name string) string {
+	return "/definitions/" + jsonpointer.Escape(name)
+}
+
+// operationPointer finds the json pointer to the operation with the provided id
+func operationPointer(doc *spec.Document, id string) string {
+	for method, paths := range doc.Operations() {
+		for path, op := range paths {
+			if op.ID == id {
+				return "/paths/" + jsonpointer.Escape(path) + "/" + strings.ToLower(method)
+			}
+		}
+	}
+	return ""
+}
+
 func fileExists(target, name string) bool {
 	ffn := swag.ToFileName(name) + ".go"
 	_, err := os.Stat(filepath.Join(target, ffn))
@@ -148,29 +148,42 @@ func (a *appGenerator) generateAPIBuilder(app *GenApp) error {
 }
 
 var mediaTypeNames = map[string]string{
-	"application/json":        "json",
-	"application/x-yaml":      "yaml",
-	"application/x-protobuf":  "protobuf",
-	"application/x-capnproto": "capnproto",
-	"application/x-thrift":    "thrift",
-	"application/xml":         "xml",
-	"text/xml":                "xml",
-	"text/x-markdown":         "markdown",
-	"text/html":               "html",
-	"text/csv":                "csv",
-	"text/tsv":                "tsv",
-	"text/javascript":         "js",
-	"text/css":                "css",
+	"application/json":                  "json",
+	"application/x-yaml":                "yaml",
+	"application/x-protobuf":            "protobuf",
+	"application/x-capnproto":           "capnproto",
+	"application/octet-stream":          "bin",
+	"application/x-thrift":              "thrift",
+	"application/xml":                   "xml",
+	"text/xml":                          "xml",
+	"text/plain":                        "txt",
+	"text/x-markdown":                   "markdown",
+	"text/html":                         "html",
+	"text/csv":                          "csv",
+	"application/x-www-form-urlencoded": "form",
+	"text/tsv":                          "tsv",
+	"text/javascript":                   "js",
+	"text/css":                          "css",
 }
 
 var knownProducers = map[string]string{
 	"json": "httpkit.JSONProducer",
 	"yaml": "httpkit.YAMLProducer",
+	"xml":  "httpkit.XMLProducer",
+	"txt":  "httpkit.TextProducer",
+	"bin":  "httpkit.ByteStreamProducer",
+	"csv":  "httpkit.CSVProducer",
+	"form": "httpkit.FormProducer",
 }
 
 var knownConsumers = map[string]string{
 	"json": "httpkit.JSONConsumer",
 	"yaml": "httpkit.YAMLConsumer",
+	"xml":  "httpkit.XMLConsumer",
+	"txt":  "httpkit.TextConsumer",
+	"bin":  "httpkit.ByteStreamConsumer",
+	"csv":  "httpkit.CSVConsumer",
+	"form": "httpkit.FormConsumer",
 }
 
 func getSerializer(sers []GenSerGroup, ext string) (*GenSerGroup, bool) {
@@ -311,7 +324,7 @@ func (a *appGenerator) makeCodegenApp() (GenApp, error) {
 			a.SpecDoc,
 		)
 		if err != nil {
-			return GenApp{}, err
+			return GenApp{}, errorAt(a.SpecDoc, definitionPointer(mn), err)
 		}
 		mod.ReceiverName = receiver
 		genMods = append(genMods, *mod)
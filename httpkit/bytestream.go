@@ -0,0 +1,76 @@
+package httpkit
+
+import (
+	"encoding"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// ByteStreamConsumer creates a consumer for application/octet-stream bodies.
+// The body is copied into an io.Writer target, so large bodies don't have to fit in memory,
+// or it's read into a byte slice or an encoding.BinaryUnmarshaler.
+func ByteStreamConsumer() Consumer {
+	return ConsumerFunc(func(reader io.Reader, data interface{}) error {
+		switch target := data.(type) {
+		case io.Writer:
+			_, err := io.Copy(target, reader)
+			return err
+		case *io.Reader:
+			*target = reader
+			return nil
+		}
+
+		b, err := ioutil.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+		switch target := data.(type) {
+		case encoding.BinaryUnmarshaler:
+			return target.UnmarshalBinary(b)
+		case *[]byte:
+			*target = b
+			return nil
+		case *string:
+			*target = string(b)
+			return nil
+		case *interface{}:
+			*target = b
+			return nil
+		}
+		return fmt.Errorf("%T is not a supported type for the byte stream consumer", data)
+	})
+}
+
+// ByteStreamProducer creates a producer for application/octet-stream bodies.
+// An io.Reader is streamed to the response and closed when it's an io.ReadCloser,
+// byte slices, strings and encoding.BinaryMarshaler values are written as is.
+func ByteStreamProducer() Producer {
+	return ProducerFunc(func(writer io.Writer, data interface{}) error {
+		switch v := data.(type) {
+		case nil:
+			return nil
+		case io.ReadCloser:
+			defer v.Close()
+			_, err := io.Copy(writer, v)
+			return err
+		case io.Reader:
+			_, err := io.Copy(writer, v)
+			return err
+		case []byte:
+			_, err := writer.Write(v)
+			return err
+		case string:
+			_, err := io.WriteString(writer, v)
+			return err
+		case encoding.BinaryMarshaler:
+			b, err := v.MarshalBinary()
+			if err != nil {
+				return err
+			}
+			_, err = writer.Write(b)
+			return err
+		}
+		return fmt.Errorf("%T is not a supported type for the byte stream producer", data)
+	})
+}
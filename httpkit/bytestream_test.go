@@ -0,0 +1,64 @@
+package httpkit
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var consProdBytes = []byte{0x00, 0x01, 0xfe, 0xff}
+
+type closeRecorder struct {
+	*bytes.Buffer
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestByteStreamConsumer(t *testing.T) {
+	cons := ByteStreamConsumer()
+
+	var b []byte
+	err := cons.Consume(bytes.NewBuffer(consProdBytes), &b)
+	assert.NoError(t, err)
+	assert.Equal(t, consProdBytes, b)
+
+	buf := bytes.NewBuffer(nil)
+	err = cons.Consume(bytes.NewBuffer(consProdBytes), buf)
+	assert.NoError(t, err)
+	assert.Equal(t, consProdBytes, buf.Bytes())
+
+	var data struct{ Name string }
+	err = cons.Consume(bytes.NewBuffer(consProdBytes), &data)
+	assert.Error(t, err)
+}
+
+func TestByteStreamProducer(t *testing.T) {
+	prod := ByteStreamProducer()
+
+	rw := httptest.NewRecorder()
+	err := prod.Produce(rw, consProdBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, consProdBytes, rw.Body.Bytes())
+
+	rw = httptest.NewRecorder()
+	err = prod.Produce(rw, ioutil.NopCloser(bytes.NewBuffer(consProdBytes)))
+	assert.NoError(t, err)
+	assert.Equal(t, consProdBytes, rw.Body.Bytes())
+
+	rc := &closeRecorder{Buffer: bytes.NewBuffer(consProdBytes)}
+	rw = httptest.NewRecorder()
+	err = prod.Produce(rw, rc)
+	assert.NoError(t, err)
+	assert.Equal(t, consProdBytes, rw.Body.Bytes())
+	assert.True(t, rc.closed)
+
+	err = prod.Produce(httptest.NewRecorder(), 42)
+	assert.Error(t, err)
+}
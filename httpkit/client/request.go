@@ -140,8 +140,11 @@ func (r *request) SetHeaderParam(name string, values ...string) error {
 // SetQueryParam adds a query param to the request
 // when there is only 1 value provided for the varargs, it will set it.
 // when there are several values provided for the varargs it will add it (no overriding)
+//
+// Structured params are set with the values from httpkit.DeepObjectValues for the deepObject style,
+// each key of the object is a param like filter[status], or with httpkit.JSONQueryValue for the json style.
 func (r *request) SetQueryParam(name string, values ...string) error {
-	if r.header == nil {
+	if r.query == nil {
 		r.query = make(url.Values)
 	}
 	r.query[name] = values
@@ -46,6 +46,26 @@ func TestBuildRequest_SetQuery(t *testing.T) {
 	assert.Equal(t, []string{"cruel", "world"}, r.query["goodbye"])
 }
 
+func TestBuildRequest_SetQuery_DeepObject(t *testing.T) {
+	r, _ := newRequest("GET", "/pets", nil)
+	r.query = nil
+
+	values, err := httpkit.DeepObjectValues("filter", map[string]interface{}{"status": "sold", "tags": []string{"dog", "cat"}})
+	if assert.NoError(t, err) {
+		for k, v := range values {
+			r.SetQueryParam(k, v...)
+		}
+	}
+	assert.Equal(t, "sold", r.query.Get("filter[status]"))
+	assert.Equal(t, []string{"dog", "cat"}, r.query["filter[tags]"])
+
+	doc, err := httpkit.JSONQueryValue(map[string]int{"limit": 10})
+	if assert.NoError(t, err) {
+		r.SetQueryParam("page", doc)
+	}
+	assert.Equal(t, `{"limit":10}`, r.query.Get("page"))
+}
+
 func TestBuildRequest_SetForm(t *testing.T) {
 	// non-multipart
 	r, _ := newRequest("POST", "/flats", nil)
@@ -14,6 +14,14 @@ const (
 	JSONMime = "application/json"
 	// YAMLMime the yaml mime type
 	YAMLMime = "application/x-yaml"
+	// XMLMime the xml mime type
+	XMLMime = "application/xml"
+	// TextMime the text mime type
+	TextMime = "text/plain"
+	// CSVMime the csv mime type
+	CSVMime = "text/csv"
+	// URLencodedFormMime the url encoded form mime type
+	URLencodedFormMime = "application/x-www-form-urlencoded"
 	// MultipartFormMime the multipart form mime type
 	MultipartFormMime = "multipart/form-data"
 )
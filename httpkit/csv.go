@@ -0,0 +1,295 @@
+package httpkit
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CSVConsumer creates a new CSV consumer.
+// A *[][]string target gets all the records, the records can also be read into
+// a slice of maps or structs, the header row has the keys or json names of the fields.
+func CSVConsumer() Consumer {
+	return ConsumerFunc(func(reader io.Reader, data interface{}) error {
+		records, err := csv.NewReader(reader).ReadAll()
+		if err != nil {
+			return err
+		}
+		if target, ok := data.(*[][]string); ok {
+			*target = records
+			return nil
+		}
+
+		val := reflect.ValueOf(data)
+		if val.Kind() != reflect.Ptr || val.Elem().Kind() != reflect.Slice {
+			return fmt.Errorf("%T is not a supported type for the csv consumer", data)
+		}
+		slice := val.Elem()
+		result := reflect.MakeSlice(slice.Type(), 0, len(records))
+		if len(records) == 0 {
+			slice.Set(result)
+			return nil
+		}
+
+		header := records[0]
+		for _, record := range records[1:] {
+			item := reflect.New(slice.Type().Elem()).Elem()
+			if err := csvRecordToValue(header, record, item); err != nil {
+				return err
+			}
+			result = reflect.Append(result, item)
+		}
+		slice.Set(result)
+		return nil
+	})
+}
+
+// CSVProducer creates a new CSV producer.
+// It writes a [][]string as is, a slice of flat maps or structs is written with a header row.
+// The columns of maps are the sorted keys, the columns of structs their json names.
+func CSVProducer() Producer {
+	return ProducerFunc(func(writer io.Writer, data interface{}) error {
+		w := csv.NewWriter(writer)
+		records, err := csvRecords(data)
+		if err != nil {
+			return err
+		}
+		if err := w.WriteAll(records); err != nil {
+			return err
+		}
+		return w.Error()
+	})
+}
+
+func csvRecords(data interface{}) ([][]string, error) {
+	if records, ok := data.([][]string); ok {
+		return records, nil
+	}
+
+	val := reflect.Indirect(reflect.ValueOf(data))
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return nil, fmt.Errorf("%T is not a supported type for the csv producer", data)
+	}
+
+	itemType := val.Type().Elem()
+	for itemType.Kind() == reflect.Ptr {
+		itemType = itemType.Elem()
+	}
+
+	var header []string
+	switch itemType.Kind() {
+	case reflect.Struct:
+		for _, f := range csvFields(itemType) {
+			header = append(header, f.name)
+		}
+	case reflect.Map, reflect.Interface:
+		keys := make(map[string]struct{})
+		for i := 0; i < val.Len(); i++ {
+			item := reflect.Indirect(val.Index(i))
+			for item.Kind() == reflect.Interface && !item.IsNil() {
+				item = reflect.Indirect(item.Elem())
+			}
+			if item.Kind() != reflect.Map {
+				return nil, fmt.Errorf("csv rows must be flat objects, got %s", item.Kind())
+			}
+			for _, k := range item.MapKeys() {
+				keys[fmt.Sprint(k.Interface())] = struct{}{}
+			}
+		}
+		for k := range keys {
+			header = append(header, k)
+		}
+		sort.Strings(header)
+	default:
+		return nil, fmt.Errorf("csv rows must be flat objects, got %s", itemType.Kind())
+	}
+
+	records := [][]string{header}
+	for i := 0; i < val.Len(); i++ {
+		record, err := csvValueToRecord(header, val.Index(i))
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func csvValueToRecord(header []string, item reflect.Value) ([]string, error) {
+	for item.Kind() == reflect.Ptr || item.Kind() == reflect.Interface {
+		if item.IsNil() {
+			return make([]string, len(header)), nil
+		}
+		item = item.Elem()
+	}
+
+	record := make([]string, len(header))
+	if item.Kind() == reflect.Struct {
+		for i, f := range csvFields(item.Type()) {
+			cell, err := csvCell(item.FieldByIndex(f.index))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", f.name, err)
+			}
+			record[i] = cell
+		}
+		return record, nil
+	}
+
+	for i, name := range header {
+		v := item.MapIndex(reflect.ValueOf(name).Convert(item.Type().Key()))
+		if !v.IsValid() {
+			continue
+		}
+		cell, err := csvCell(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		record[i] = cell
+	}
+	return record, nil
+}
+
+func csvCell(v reflect.Value) (string, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		if s, ok := v.Interface().(fmt.Stringer); ok {
+			return s.String(), nil
+		}
+		return "", fmt.Errorf("nested %s values can't be written as csv", v.Kind())
+	}
+	return fmt.Sprint(v.Interface()), nil
+}
+
+func csvRecordToValue(header, record []string, item reflect.Value) error {
+	target := item
+	if target.Kind() == reflect.Ptr {
+		target.Set(reflect.New(target.Type().Elem()))
+		target = target.Elem()
+	}
+
+	switch target.Kind() {
+	case reflect.Struct:
+		fields := make(map[string][]int)
+		for _, f := range csvFields(target.Type()) {
+			fields[f.name] = f.index
+		}
+		for i, name := range header {
+			index, ok := fields[name]
+			if !ok || i >= len(record) {
+				continue
+			}
+			if err := setCSVCell(target.FieldByIndex(index), record[i]); err != nil {
+				return fmt.Errorf("%s: %v", name, err)
+			}
+		}
+		return nil
+	case reflect.Map:
+		m := reflect.MakeMap(target.Type())
+		for i, name := range header {
+			if i >= len(record) {
+				continue
+			}
+			v := reflect.New(target.Type().Elem()).Elem()
+			if err := setCSVCell(v, record[i]); err != nil {
+				return fmt.Errorf("%s: %v", name, err)
+			}
+			m.SetMapIndex(reflect.ValueOf(name).Convert(target.Type().Key()), v)
+		}
+		target.Set(m)
+		return nil
+	case reflect.Interface:
+		m := make(map[string]interface{}, len(header))
+		for i, name := range header {
+			if i < len(record) {
+				m[name] = record[i]
+			}
+		}
+		target.Set(reflect.ValueOf(m))
+		return nil
+	}
+	return fmt.Errorf("csv rows can't be read into %s", target.Type())
+}
+
+func setCSVCell(v reflect.Value, cell string) error {
+	if v.Kind() == reflect.Ptr {
+		if cell == "" {
+			return nil
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+	if cell == "" && v.Kind() != reflect.String && v.Kind() != reflect.Interface {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(cell)
+	case reflect.Interface:
+		v.Set(reflect.ValueOf(cell))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(cell)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(cell, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(cell, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(cell, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("%s values can't be read from csv", v.Kind())
+	}
+	return nil
+}
+
+type csvField struct {
+	name  string
+	index []int
+}
+
+// csvFields the exported fields of a struct with their json names
+func csvFields(tpe reflect.Type) []csvField {
+	var fields []csvField
+	for i := 0; i < tpe.NumField(); i++ {
+		f := tpe.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name := f.Name
+		if tag := f.Tag.Get("json"); tag != "" {
+			if tag == "-" {
+				continue
+			}
+			if n := strings.Split(tag, ",")[0]; n != "" {
+				name = n
+			}
+		}
+		fields = append(fields, csvField{name: name, index: f.Index})
+	}
+	return fields
+}
@@ -0,0 +1,67 @@
+package httpkit
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type csvPet struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Tag  *string
+	Skip string `json:"-"`
+}
+
+var consProdCSV = "id,name,Tag\n1,fido,dog\n2,\"tom, the cat\",\n"
+
+func TestCSVConsumer(t *testing.T) {
+	cons := CSVConsumer()
+
+	var pets []csvPet
+	err := cons.Consume(bytes.NewBufferString(consProdCSV), &pets)
+	if assert.NoError(t, err) && assert.Len(t, pets, 2) {
+		assert.EqualValues(t, 1, pets[0].ID)
+		assert.Equal(t, "fido", pets[0].Name)
+		assert.Equal(t, "dog", *pets[0].Tag)
+		assert.Equal(t, "tom, the cat", pets[1].Name)
+		assert.Nil(t, pets[1].Tag)
+	}
+
+	var maps []map[string]interface{}
+	err = cons.Consume(bytes.NewBufferString(consProdCSV), &maps)
+	if assert.NoError(t, err) && assert.Len(t, maps, 2) {
+		assert.Equal(t, map[string]interface{}{"id": "1", "name": "fido", "Tag": "dog"}, maps[0])
+	}
+
+	var records [][]string
+	err = cons.Consume(bytes.NewBufferString(consProdCSV), &records)
+	assert.NoError(t, err)
+	assert.Len(t, records, 3)
+
+	err = cons.Consume(bytes.NewBufferString("id\nabc\n"), &pets)
+	assert.Error(t, err)
+}
+
+func TestCSVProducer(t *testing.T) {
+	prod := CSVProducer()
+	dog := "dog"
+
+	rw := httptest.NewRecorder()
+	err := prod.Produce(rw, []csvPet{{ID: 1, Name: "fido", Tag: &dog}, {ID: 2, Name: "tom, the cat"}})
+	assert.NoError(t, err)
+	assert.Equal(t, consProdCSV, rw.Body.String())
+
+	rw = httptest.NewRecorder()
+	err = prod.Produce(rw, []map[string]interface{}{{"name": "fido", "id": 1}, {"name": "tom", "age": 3}})
+	assert.NoError(t, err)
+	assert.Equal(t, "age,id,name\n,1,fido\n3,,tom\n", rw.Body.String())
+
+	err = prod.Produce(httptest.NewRecorder(), []map[string]interface{}{{"tags": []string{"dog"}}})
+	assert.Error(t, err)
+
+	err = prod.Produce(httptest.NewRecorder(), csvPet{})
+	assert.Error(t, err)
+}
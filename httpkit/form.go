@@ -0,0 +1,168 @@
+package httpkit
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"reflect"
+)
+
+// FormConsumer creates a new url encoded form consumer.
+// It reads the body into url.Values, a map of strings or string slices, or a struct,
+// the keys of the form are the json names of the fields. Slice fields get all the values of a key.
+func FormConsumer() Consumer {
+	return ConsumerFunc(func(reader io.Reader, data interface{}) error {
+		b, err := ioutil.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+		values, err := url.ParseQuery(string(b))
+		if err != nil {
+			return err
+		}
+
+		switch target := data.(type) {
+		case *url.Values:
+			*target = values
+			return nil
+		case *map[string][]string:
+			*target = values
+			return nil
+		case *map[string]string:
+			m := make(map[string]string, len(values))
+			for k := range values {
+				m[k] = values.Get(k)
+			}
+			*target = m
+			return nil
+		case *map[string]interface{}:
+			*target = formMap(values)
+			return nil
+		case *interface{}:
+			*target = formMap(values)
+			return nil
+		}
+
+		val := reflect.ValueOf(data)
+		if val.Kind() != reflect.Ptr || val.Elem().Kind() != reflect.Struct {
+			return fmt.Errorf("%T is not a supported type for the form consumer", data)
+		}
+		target := val.Elem()
+		for _, f := range csvFields(target.Type()) {
+			vals, ok := values[f.name]
+			if !ok || len(vals) == 0 {
+				continue
+			}
+			field := target.FieldByIndex(f.index)
+			if field.Kind() == reflect.Slice && field.Type().Elem().Kind() != reflect.Uint8 {
+				slice := reflect.MakeSlice(field.Type(), len(vals), len(vals))
+				for i, v := range vals {
+					if err := setCSVCell(slice.Index(i), v); err != nil {
+						return fmt.Errorf("%s: %v", f.name, err)
+					}
+				}
+				field.Set(slice)
+				continue
+			}
+			if err := setCSVCell(field, vals[0]); err != nil {
+				return fmt.Errorf("%s: %v", f.name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// formMap keys with a single value get a string, the others a string slice
+func formMap(values url.Values) map[string]interface{} {
+	m := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		if len(v) == 1 {
+			m[k] = v[0]
+			continue
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// FormProducer creates a new url encoded form producer.
+// It writes url.Values, flat maps and structs, the keys of structs are the json names of the fields.
+// Slices are written as a key for each value, the keys are sorted.
+func FormProducer() Producer {
+	return ProducerFunc(func(writer io.Writer, data interface{}) error {
+		values, err := formValues(data)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(writer, values.Encode())
+		return err
+	})
+}
+
+func formValues(data interface{}) (url.Values, error) {
+	switch v := data.(type) {
+	case nil:
+		return url.Values{}, nil
+	case url.Values:
+		return v, nil
+	case map[string][]string:
+		return url.Values(v), nil
+	}
+
+	val := reflect.ValueOf(data)
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return url.Values{}, nil
+		}
+		val = val.Elem()
+	}
+
+	values := url.Values{}
+	switch val.Kind() {
+	case reflect.Struct:
+		for _, f := range csvFields(val.Type()) {
+			if err := addFormValue(values, f.name, val.FieldByIndex(f.index)); err != nil {
+				return nil, err
+			}
+		}
+	case reflect.Map:
+		for _, k := range val.MapKeys() {
+			if err := addFormValue(values, fmt.Sprint(k.Interface()), val.MapIndex(k)); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%T is not a supported type for the form producer", data)
+	}
+	return values, nil
+}
+
+func addFormValue(values url.Values, key string, v reflect.Value) error {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem().Kind() != reflect.Uint8 {
+		for i := 0; i < v.Len(); i++ {
+			cell, err := csvCell(v.Index(i))
+			if err != nil {
+				return fmt.Errorf("%s: %v", key, err)
+			}
+			values.Add(key, cell)
+		}
+		return nil
+	}
+	if v.Kind() == reflect.Slice {
+		values.Add(key, string(v.Bytes()))
+		return nil
+	}
+	cell, err := csvCell(v)
+	if err != nil {
+		return fmt.Errorf("%s: %v", key, err)
+	}
+	values.Add(key, cell)
+	return nil
+}
@@ -0,0 +1,75 @@
+package httpkit
+
+import (
+	"bytes"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type formPet struct {
+	ID   int64    `json:"id"`
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+	Age  *int32   `json:"age"`
+	Skip string   `json:"-"`
+}
+
+var consProdForm = "id=1&name=fido+the+dog&tags=dog&tags=good"
+
+func TestFormConsumer(t *testing.T) {
+	cons := FormConsumer()
+
+	var pet formPet
+	err := cons.Consume(bytes.NewBufferString(consProdForm), &pet)
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, 1, pet.ID)
+		assert.Equal(t, "fido the dog", pet.Name)
+		assert.Equal(t, []string{"dog", "good"}, pet.Tags)
+		assert.Nil(t, pet.Age)
+	}
+
+	var values url.Values
+	err = cons.Consume(bytes.NewBufferString(consProdForm), &values)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"dog", "good"}, values["tags"])
+	}
+
+	var m map[string]interface{}
+	err = cons.Consume(bytes.NewBufferString(consProdForm), &m)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{"id": "1", "name": "fido the dog", "tags": []string{"dog", "good"}}, m)
+	}
+
+	err = cons.Consume(bytes.NewBufferString("id=one"), &pet)
+	assert.Error(t, err)
+
+	var i int
+	err = cons.Consume(bytes.NewBufferString(consProdForm), &i)
+	assert.Error(t, err)
+}
+
+func TestFormProducer(t *testing.T) {
+	prod := FormProducer()
+
+	rw := new(bytes.Buffer)
+	err := prod.Produce(rw, &formPet{ID: 1, Name: "fido the dog", Tags: []string{"dog", "good"}, Skip: "skip"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, consProdForm, rw.String())
+	}
+
+	rw = new(bytes.Buffer)
+	err = prod.Produce(rw, map[string]interface{}{"name": "fido", "id": 1})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "id=1&name=fido", rw.String())
+	}
+
+	rw = new(bytes.Buffer)
+	err = prod.Produce(rw, map[string]interface{}{"nested": map[string]string{"a": "b"}})
+	assert.Error(t, err)
+
+	rw = new(bytes.Buffer)
+	err = prod.Produce(rw, "fido")
+	assert.Error(t, err)
+}
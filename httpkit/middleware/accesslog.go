@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// AccessLog is an observer that writes a line of json for every request that was served
+type AccessLog struct {
+	lock   sync.Mutex
+	writer io.Writer
+}
+
+// NewAccessLog creates an access log that writes to the specified writer
+func NewAccessLog(writer io.Writer) *AccessLog {
+	return &AccessLog{writer: writer}
+}
+
+type accessLogEntry struct {
+	Time        string  `json:"time"`
+	Method      string  `json:"method"`
+	Path        string  `json:"path"`
+	PathPattern string  `json:"pathPattern,omitempty"`
+	OperationID string  `json:"operationId,omitempty"`
+	Status      int     `json:"status"`
+	Size        int64   `json:"size"`
+	Duration    float64 `json:"durationMs"`
+	Handler     float64 `json:"handlerMs,omitempty"`
+	RemoteAddr  string  `json:"remoteAddr,omitempty"`
+	UserAgent   string  `json:"userAgent,omitempty"`
+	Error       string  `json:"error,omitempty"`
+}
+
+// RouteMatched is a no-op, the route is logged when the request completes
+func (a *AccessLog) RouteMatched(*http.Request, *MatchedRoute) {}
+
+// Authenticated is a no-op, a failure is logged when the request completes
+func (a *AccessLog) Authenticated(*http.Request, string, interface{}, error) {}
+
+// BindingFailed is a no-op, the error is logged when the request completes
+func (a *AccessLog) BindingFailed(*http.Request, string, error) {}
+
+// Completed writes the log entry for the request
+func (a *AccessLog) Completed(r *http.Request, stats RequestStats) {
+	entry := accessLogEntry{
+		Time:        stats.Start.UTC().Format(time.RFC3339Nano),
+		Method:      stats.Method,
+		Path:        stats.Path,
+		PathPattern: stats.PathPattern,
+		OperationID: stats.OperationID,
+		Status:      stats.Status,
+		Size:        stats.Size,
+		Duration:    float64(stats.Duration) / float64(time.Millisecond),
+		Handler:     float64(stats.HandlerDuration) / float64(time.Millisecond),
+		RemoteAddr:  r.RemoteAddr,
+		UserAgent:   r.UserAgent(),
+	}
+	if stats.Err != nil {
+		entry.Error = stats.Err.Error()
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	// an access log that can't be written shouldn't fail the request
+	json.NewEncoder(a.writer).Encode(entry)
+}
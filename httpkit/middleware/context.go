@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	stdContext "context"
 	"net/http"
 
 	"github.com/vikstrous/go-swagger/errors"
@@ -9,7 +10,6 @@ import (
 	"github.com/vikstrous/go-swagger/spec"
 	"github.com/vikstrous/go-swagger/strfmt"
 	"github.com/golang/gddo/httputil"
-	"github.com/gorilla/context"
 )
 
 // RequestBinder is an interface for types to implement
@@ -18,13 +18,19 @@ type RequestBinder interface {
 	BindRequest(*http.Request, *MatchedRoute) error
 }
 
-// Context is a type safe wrapper around an untyped request context
-// used throughout to store request context with the gorilla context module
+// Context is a type safe wrapper around an untyped request context,
+// the values it computes for a request are stored on the context of that request
 type Context struct {
-	spec    *spec.Document
-	api     RoutableAPI
-	router  Router
-	formats strfmt.Registry
+	spec       *spec.Document
+	api        RoutableAPI
+	router     Router
+	formats    strfmt.Registry
+	cors       *CORSOpts
+	limits     RequestLimits
+	observers  []Observer
+	panicHooks []PanicHook
+	errors     *ErrorMapper
+	docs       *DocsOpts
 }
 
 type routableUntypedAPI struct {
@@ -50,10 +56,10 @@ func newRoutableUntypedAPI(spec *spec.Document, api *untyped.API, context *Conte
 
 				handlers[op.ID] = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 					// lookup route info in the context
-					route, _ := context.RouteInfo(r)
+					route, r, _ := context.RouteInfoWithRequest(r)
 
 					// bind and validate the request using reflection
-					bound, validation := context.BindAndValidate(r, route)
+					bound, r, validation := context.BindAndValidateWithRequest(r, route)
 					if validation != nil {
 						context.Respond(w, r, route.Produces, route, validation)
 						return
@@ -116,17 +122,41 @@ func (r *routableUntypedAPI) DefaultConsumes() string {
 
 // NewRoutableContext creates a new context for a routable API
 func NewRoutableContext(spec *spec.Document, routableAPI RoutableAPI, routes Router) *Context {
-	ctx := &Context{spec: spec, api: routableAPI}
+	ctx := &Context{spec: spec, api: routableAPI, router: routes}
 	return ctx
 }
 
 // NewContext creates a new context wrapper
 func NewContext(spec *spec.Document, api *untyped.API, routes Router) *Context {
-	ctx := &Context{spec: spec}
+	ctx := &Context{spec: spec, router: routes}
 	ctx.api = newRoutableUntypedAPI(spec, api, ctx)
 	return ctx
 }
 
+// WithRouterOpts replaces the router of this context with one built with the specified options,
+// it panics when a path of the spec can't be routed. Use NewRouter and NewRoutableContext to handle the error.
+func (c *Context) WithRouterOpts(opts RouterOpts) *Context {
+	router, err := NewRouter(c.spec, c.api, opts)
+	if err != nil {
+		panic(err)
+	}
+	c.router = router
+	return c
+}
+
+// Routes lists the routes of this context, for diagnostics.
+// It returns nil when the router can't list its routes.
+func (c *Context) Routes() []RouteDescription {
+	router := c.router
+	if router == nil {
+		router = DefaultRouter(c.spec, c.api)
+	}
+	if lister, ok := router.(RouteLister); ok {
+		return lister.Routes()
+	}
+	return nil
+}
+
 // Serve serves the specified spec with the specified api registrations as a http.Handler
 func Serve(spec *spec.Document, api *untyped.API) http.Handler {
 	context := NewContext(spec, api, nil)
@@ -143,6 +173,7 @@ const (
 	ctxAllowedMethods
 	ctxBoundParams
 	ctxSecurityPrincipal
+	ctxRequestState
 
 	ctxConsumer
 )
@@ -152,6 +183,37 @@ type contentTypeValue struct {
 	Charset   string
 }
 
+func withValue(request *http.Request, key contextKey, value interface{}) *http.Request {
+	return request.WithContext(stdContext.WithValue(request.Context(), key, value))
+}
+
+// MatchedRouteFrom returns the route that was matched for the request,
+// nil when the request hasn't been routed yet
+func MatchedRouteFrom(request *http.Request) *MatchedRoute {
+	if v, ok := request.Context().Value(ctxMatchedRoute).(*MatchedRoute); ok {
+		return v
+	}
+	return nil
+}
+
+// SecurityPrincipalFrom returns the principal that was authenticated for the request,
+// nil when the request hasn't been authorized yet
+func SecurityPrincipalFrom(request *http.Request) interface{} {
+	return request.Context().Value(ctxSecurityPrincipal)
+}
+
+// BoundParamsFrom returns the parameters that were bound from the request
+// by BindAndValidate or BindValidRequest
+func BoundParamsFrom(request *http.Request) (interface{}, bool) {
+	switch v := request.Context().Value(ctxBoundParams).(type) {
+	case *validation:
+		return v.bound, true
+	case RequestBinder:
+		return v, true
+	}
+	return nil, false
+}
+
 // BasePath returns the base path for this API
 func (c *Context) BasePath() string {
 	return c.spec.BasePath()
@@ -163,13 +225,31 @@ func (c *Context) RequiredProduces() []string {
 }
 
 // BindValidRequest binds a params object to a request but only when the request is valid
-// if the request is not valid an error will be returned
+// if the request is not valid an error will be returned.
+// Use BindValidRequestWithRequest to get the request that carries the bound params object.
 func (c *Context) BindValidRequest(request *http.Request, route *MatchedRoute, binder RequestBinder) error {
+	_, err := c.BindValidRequestWithRequest(request, route, binder)
+	return err
+}
+
+// BindValidRequestWithRequest binds a params object to a request but only when the request is valid
+// if the request is not valid an error will be returned.
+// When no route is provided the route that was matched for the request is used,
+// the returned request carries the bound params object.
+func (c *Context) BindValidRequestWithRequest(request *http.Request, route *MatchedRoute, binder RequestBinder) (*http.Request, error) {
 	var res []error
+	if route == nil {
+		route = MatchedRouteFrom(request)
+	}
+	if route == nil {
+		return request, errors.NotFound("path %s was not found", request.URL.Path)
+	}
 
 	// check and validate content type, select consumer
 	if httpkit.CanHaveBody(request.Method) {
-		ct, _, err := httpkit.ContentType(request.Header)
+		var ct string
+		var err *errors.ParseError
+		ct, _, request, err = c.ContentTypeWithRequest(request)
 		if err != nil {
 			res = append(res, err)
 		} else {
@@ -182,7 +262,8 @@ func (c *Context) BindValidRequest(request *http.Request, route *MatchedRoute, b
 
 	// check and validate the response format
 	if len(res) == 0 {
-		if str := httputil.NegotiateContentType(request, route.Produces, ""); str == "" {
+		var str string
+		if str, request = c.ResponseFormatWithRequest(request, route.Produces); str == "" {
 			res = append(res, errors.InvalidResponseFormat(request.Header.Get(httpkit.HeaderAccept), route.Produces))
 		}
 	}
@@ -191,31 +272,51 @@ func (c *Context) BindValidRequest(request *http.Request, route *MatchedRoute, b
 	// it's assumed the binder will also validate the request and return an error if the
 	// request is invalid
 	if binder != nil && len(res) == 0 {
+		limits := route.Limits.WithDefaults(c.limits)
+		body, err := applyLimits(request, limits)
+		if err != nil {
+			c.bindingFailed(request, route, err)
+			return request, err
+		}
 		if err := binder.BindRequest(request, route); err != nil {
+			if body != nil && body.exceeded {
+				err = errors.BodyTooLarge(limits.MaxBodySize)
+				c.bindingFailed(request, route, err)
+				return request, err
+			}
 			res = append(res, err)
 		}
 	}
 
 	if len(res) > 0 {
-		return errors.CompositeValidationError(res...)
+		err := errors.CompositeValidationError(res...)
+		c.bindingFailed(request, route, err)
+		return request, err
 	}
-	return nil
+	if binder != nil {
+		request = withValue(request, ctxBoundParams, binder)
+	}
+	return request, nil
 }
 
 // ContentType gets the parsed value of a content type
 func (c *Context) ContentType(request *http.Request) (string, string, *errors.ParseError) {
-	if v, ok := context.GetOk(request, ctxContentType); ok {
-		if val, ok := v.(*contentTypeValue); ok {
-			return val.MediaType, val.Charset, nil
-		}
+	mt, cs, _, err := c.ContentTypeWithRequest(request)
+	return mt, cs, err
+}
+
+// ContentTypeWithRequest gets the parsed value of a content type,
+// the returned request remembers it for the next time
+func (c *Context) ContentTypeWithRequest(request *http.Request) (string, string, *http.Request, *errors.ParseError) {
+	if val, ok := request.Context().Value(ctxContentType).(*contentTypeValue); ok {
+		return val.MediaType, val.Charset, request, nil
 	}
 
 	mt, cs, err := httpkit.ContentType(request.Header)
 	if err != nil {
-		return "", "", err
+		return "", "", request, err
 	}
-	context.Set(request, ctxContentType, &contentTypeValue{mt, cs})
-	return mt, cs, nil
+	return mt, cs, withValue(request, ctxContentType, &contentTypeValue{mt, cs}), nil
 }
 
 // LookupRoute looks a route up and returns true when it is found
@@ -226,35 +327,45 @@ func (c *Context) LookupRoute(request *http.Request) (*MatchedRoute, bool) {
 	return nil, false
 }
 
-// RouteInfo tries to match a route for this request
+// RouteInfo tries to match a route for this request.
+// Use RouteInfoWithRequest to get the request that carries the matched route.
 func (c *Context) RouteInfo(request *http.Request) (*MatchedRoute, bool) {
-	if v, ok := context.GetOk(request, ctxMatchedRoute); ok {
-		if val, ok := v.(*MatchedRoute); ok {
-			return val, ok
-		}
+	route, _, ok := c.RouteInfoWithRequest(request)
+	return route, ok
+}
+
+// RouteInfoWithRequest tries to match a route for this request,
+// the returned request carries the matched route
+func (c *Context) RouteInfoWithRequest(request *http.Request) (*MatchedRoute, *http.Request, bool) {
+	if route := MatchedRouteFrom(request); route != nil {
+		return route, request, true
 	}
 
 	if route, ok := c.LookupRoute(request); ok {
-		context.Set(request, ctxMatchedRoute, route)
-		return route, ok
+		return route, withValue(request, ctxMatchedRoute, route), ok
 	}
 
-	return nil, false
+	return nil, request, false
 }
 
 // ResponseFormat negotiates the response content type
 func (c *Context) ResponseFormat(r *http.Request, offers []string) string {
-	if v, ok := context.GetOk(r, ctxResponseFormat); ok {
-		if val, ok := v.(string); ok {
-			return val
-		}
+	format, _ := c.ResponseFormatWithRequest(r, offers)
+	return format
+}
+
+// ResponseFormatWithRequest negotiates the response content type,
+// the returned request remembers it for the next time
+func (c *Context) ResponseFormatWithRequest(r *http.Request, offers []string) (string, *http.Request) {
+	if val, ok := r.Context().Value(ctxResponseFormat).(string); ok {
+		return val, r
 	}
 
 	format := httputil.NegotiateContentType(r, offers, "")
 	if format != "" {
-		context.Set(r, ctxResponseFormat, format)
+		r = withValue(r, ctxResponseFormat, format)
 	}
-	return format
+	return format, r
 }
 
 // AllowedMethods gets the allowed methods for the path of this request
@@ -262,13 +373,24 @@ func (c *Context) AllowedMethods(request *http.Request) []string {
 	return c.router.OtherMethods(request.Method, request.URL.Path)
 }
 
-// Authorize authorizes the request
+// Authorize authorizes the request.
+// Use AuthorizeWithRequest to get the request that carries the principal.
 func (c *Context) Authorize(request *http.Request, route *MatchedRoute) (interface{}, error) {
-	if len(route.Authenticators) == 0 {
-		return nil, nil
+	usr, _, err := c.AuthorizeWithRequest(request, route)
+	return usr, err
+}
+
+// AuthorizeWithRequest authorizes the request,
+// the returned request carries the principal when the request was authenticated
+func (c *Context) AuthorizeWithRequest(request *http.Request, route *MatchedRoute) (interface{}, *http.Request, error) {
+	if route == nil {
+		route = MatchedRouteFrom(request)
+	}
+	if route == nil || len(route.Authenticators) == 0 {
+		return nil, request, nil
 	}
-	if v, ok := context.GetOk(request, ctxSecurityPrincipal); ok {
-		return v, nil
+	if v := SecurityPrincipalFrom(request); v != nil {
+		return v, request, nil
 	}
 
 	for _, authenticator := range route.Authenticators {
@@ -276,31 +398,41 @@ func (c *Context) Authorize(request *http.Request, route *MatchedRoute) (interfa
 		if !applies || err != nil || usr == nil {
 			continue
 		}
-		context.Set(request, ctxSecurityPrincipal, usr)
-		return usr, nil
+		c.authenticated(request, route, usr, nil)
+		return usr, withValue(request, ctxSecurityPrincipal, usr), nil
 	}
 
-	return nil, errors.Unauthenticated("invalid credentials")
+	err := errors.Unauthenticated("invalid credentials")
+	c.authenticated(request, route, nil, err)
+	return nil, request, err
 }
 
-// BindAndValidate binds and validates the request
+// BindAndValidate binds and validates the request.
+// Use BindAndValidateWithRequest to get the request that carries the bound params and the validation result.
 func (c *Context) BindAndValidate(request *http.Request, matched *MatchedRoute) (interface{}, error) {
-	if v, ok := context.GetOk(request, ctxBoundParams); ok {
-		if val, ok := v.(*validation); ok {
-			if len(val.result) > 0 {
-				return val.bound, errors.CompositeValidationError(val.result...)
-			}
-			return val.bound, nil
-		}
+	bound, _, err := c.BindAndValidateWithRequest(request, matched)
+	return bound, err
+}
+
+// BindAndValidateWithRequest binds and validates the request,
+// the returned request carries the bound params and the validation result
+func (c *Context) BindAndValidateWithRequest(request *http.Request, matched *MatchedRoute) (interface{}, *http.Request, error) {
+	if val, ok := request.Context().Value(ctxBoundParams).(*validation); ok {
+		return val.bound, request, val.err()
 	}
-	result := validateRequest(c, request, matched)
-	if result != nil {
-		context.Set(request, ctxBoundParams, result)
+	if matched == nil {
+		matched = MatchedRouteFrom(request)
+	}
+	if matched == nil {
+		return nil, request, errors.New(http.StatusInternalServerError, "can't bind the request without a matched route")
 	}
-	if len(result.result) > 0 {
-		return result.bound, errors.CompositeValidationError(result.result...)
+	result := validateRequest(c, request, matched)
+	request = withValue(result.request, ctxBoundParams, result)
+	if err := result.err(); err != nil {
+		c.bindingFailed(request, matched, err)
+		return result.bound, request, err
 	}
-	return result.bound, nil
+	return result.bound, request, nil
 }
 
 // NotFound the default not found responder for when no route has been matched yet
@@ -324,6 +456,10 @@ func (c *Context) Respond(rw http.ResponseWriter, r *http.Request, produces []st
 		if format == "" {
 			rw.Header().Set(httpkit.HeaderContentType, httpkit.JSONMime)
 		}
+		if mapping, ok := c.errors.Lookup(operationIDFor(route), err); ok {
+			c.respondMapped(rw, r, route, offers, format, mapping, err)
+			return
+		}
 		if route == nil || route.Operation == nil {
 			c.api.ServeErrorFor("")(rw, r, err)
 			return
@@ -368,5 +504,5 @@ func (c *Context) Respond(rw http.ResponseWriter, r *http.Request, produces []st
 
 // APIHandler returns a handler to serve
 func (c *Context) APIHandler() http.Handler {
-	return specMiddleware(c, newRouter(c, newOperationExecutor(c)))
+	return newObserved(c, newRecoverer(c, specMiddleware(c, newDocs(c, newCORS(c, newRouter(c, newOperationExecutor(c)))))))
 }
@@ -2,13 +2,14 @@ package middleware
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 
 	"github.com/vikstrous/go-swagger/httpkit"
 	"github.com/vikstrous/go-swagger/internal/testing/petstore"
-	"github.com/gorilla/context"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -39,44 +40,115 @@ func TestContextAuthorize(t *testing.T) {
 
 	request, _ := httpkit.JSONRequest("GET", "/pets", nil)
 
-	v, ok := context.GetOk(request, ctxSecurityPrincipal)
+	v, ok := contextValue(request, ctxSecurityPrincipal)
 	assert.False(t, ok)
 	assert.Nil(t, v)
 
-	ri, ok := ctx.RouteInfo(request)
+	ri, request, ok := ctx.RouteInfoWithRequest(request)
 	assert.True(t, ok)
-	p, err := ctx.Authorize(request, ri)
+	p, request, err := ctx.AuthorizeWithRequest(request, ri)
 	assert.Error(t, err)
 	assert.Nil(t, p)
 
-	v, ok = context.GetOk(request, ctxSecurityPrincipal)
+	v, ok = contextValue(request, ctxSecurityPrincipal)
 	assert.False(t, ok)
 	assert.Nil(t, v)
 
 	request.SetBasicAuth("wrong", "wrong")
-	p, err = ctx.Authorize(request, ri)
+	p, request, err = ctx.AuthorizeWithRequest(request, ri)
 	assert.Error(t, err)
 	assert.Nil(t, p)
 
-	v, ok = context.GetOk(request, ctxSecurityPrincipal)
+	v, ok = contextValue(request, ctxSecurityPrincipal)
 	assert.False(t, ok)
 	assert.Nil(t, v)
 
 	request.SetBasicAuth("admin", "admin")
-	p, err = ctx.Authorize(request, ri)
+	p, request, err = ctx.AuthorizeWithRequest(request, ri)
 	assert.NoError(t, err)
 	assert.Equal(t, "admin", p)
 
-	v, ok = context.GetOk(request, ctxSecurityPrincipal)
+	v, ok = contextValue(request, ctxSecurityPrincipal)
 	assert.True(t, ok)
 	assert.Equal(t, "admin", v)
+	assert.Equal(t, "admin", SecurityPrincipalFrom(request))
 
 	request.SetBasicAuth("doesn't matter", "doesn't")
-	pp, rr := ctx.Authorize(request, ri)
+	pp, _, rr := ctx.AuthorizeWithRequest(request, ri)
 	assert.Equal(t, p, pp)
 	assert.Equal(t, err, rr)
 }
 
+func TestContextWithoutRequest(t *testing.T) {
+	spec, api := petstore.NewAPI(t)
+	ctx := NewContext(spec, api, nil)
+	ctx.router = DefaultRouter(spec, ctx.api)
+
+	request, _ := httpkit.JSONRequest("GET", "/pets", nil)
+	request.SetBasicAuth("admin", "admin")
+
+	ri, ok := ctx.RouteInfo(request)
+	assert.True(t, ok)
+	p, err := ctx.Authorize(request, ri)
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", p)
+	_, err = ctx.BindAndValidate(request, ri)
+	assert.NoError(t, err)
+	assert.NoError(t, ctx.BindValidRequest(request, ri, nil))
+	assert.Equal(t, httpkit.JSONMime, ctx.ResponseFormat(request, ri.Produces))
+	mt, _, perr := ctx.ContentType(request)
+	assert.Nil(t, perr)
+	assert.Equal(t, httpkit.JSONMime, mt)
+
+	// the request given to the methods isn't changed
+	assert.Nil(t, MatchedRouteFrom(request))
+	assert.Nil(t, SecurityPrincipalFrom(request))
+}
+
+func TestContextConcurrentRequests(t *testing.T) {
+	spec, api := petstore.NewAPI(t)
+	ctx := NewContext(spec, api, nil)
+	ctx.router = DefaultRouter(spec, ctx.api)
+
+	handler := newRouter(ctx, newSecureAPI(ctx, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		route := MatchedRouteFrom(r)
+		if route == nil {
+			rw.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		rw.Header().Set("X-Operation", route.Operation.ID)
+		rw.Header().Set("X-Pet", route.Params.Get("id"))
+		if p, ok := SecurityPrincipalFrom(r).(string); ok {
+			rw.Header().Set("X-Principal", p)
+		}
+		rw.WriteHeader(http.StatusOK)
+	})))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			request, _ := http.NewRequest("GET", "/api/pets", nil)
+			id := ""
+			if i%2 == 1 {
+				id = fmt.Sprintf("%d", i)
+				request, _ = http.NewRequest("DELETE", "/api/pets/"+id, nil)
+			}
+			request.SetBasicAuth("admin", "admin")
+			request.Header.Set("X-API-KEY", "token123")
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, id, recorder.Header().Get("X-Pet"))
+			assert.Equal(t, "admin", recorder.Header().Get("X-Principal"))
+			assert.Nil(t, MatchedRouteFrom(request))
+		}(i)
+	}
+	wg.Wait()
+}
+
 func TestContextBindAndValidate(t *testing.T) {
 	spec, api := petstore.NewAPI(t)
 	ctx := NewContext(spec, api, nil)
@@ -86,20 +158,24 @@ func TestContextBindAndValidate(t *testing.T) {
 	request.Header.Add("Accept", "*/*")
 	request.Header.Add("content-type", "text/html")
 
-	v, ok := context.GetOk(request, ctxBoundParams)
+	v, ok := contextValue(request, ctxBoundParams)
 	assert.False(t, ok)
 	assert.Nil(t, v)
 
-	ri, _ := ctx.RouteInfo(request)
-	data, result := ctx.BindAndValidate(request, ri) // this requires a much more thorough test
+	ri, request, _ := ctx.RouteInfoWithRequest(request)
+	data, request, result := ctx.BindAndValidateWithRequest(request, ri) // this requires a much more thorough test
 	assert.NotNil(t, data)
 	assert.NotNil(t, result)
 
-	v, ok = context.GetOk(request, ctxBoundParams)
+	v, ok = contextValue(request, ctxBoundParams)
 	assert.True(t, ok)
 	assert.NotNil(t, v)
 
-	dd, rr := ctx.BindAndValidate(request, ri)
+	bound, ok := BoundParamsFrom(request)
+	assert.True(t, ok)
+	assert.Equal(t, data, bound)
+
+	dd, _, rr := ctx.BindAndValidateWithRequest(request, ri)
 	assert.Equal(t, data, dd)
 	assert.Equal(t, result, rr)
 }
@@ -115,7 +191,7 @@ func TestContextRender(t *testing.T) {
 
 	request, _ := http.NewRequest("GET", "pets", nil)
 	request.Header.Set(httpkit.HeaderAccept, ct)
-	ri, _ := ctx.RouteInfo(request)
+	ri, request, _ := ctx.RouteInfoWithRequest(request)
 
 	recorder := httptest.NewRecorder()
 	ctx.Respond(recorder, request, []string{ct}, ri, map[string]interface{}{"name": "hello"})
@@ -135,7 +211,7 @@ func TestContextRender(t *testing.T) {
 
 	request, _ = http.NewRequest("GET", "/pets", nil)
 	request.Header.Set(httpkit.HeaderAccept, ct)
-	ri, _ = ctx.RouteInfo(request)
+	ri, request, _ = ctx.RouteInfoWithRequest(request)
 
 	recorder = httptest.NewRecorder()
 	ctx.Respond(recorder, request, []string{ct}, ri, map[string]interface{}{"name": "hello"})
@@ -155,7 +231,7 @@ func TestContextRender(t *testing.T) {
 
 	recorder = httptest.NewRecorder()
 	request, _ = http.NewRequest("DELETE", "/pets/1", nil)
-	ri, _ = ctx.RouteInfo(request)
+	ri, request, _ = ctx.RouteInfoWithRequest(request)
 	ctx.Respond(recorder, request, ri.Produces, ri, nil)
 	assert.Equal(t, 204, recorder.Code)
 }
@@ -170,21 +246,21 @@ func TestContextValidResponseFormat(t *testing.T) {
 	request.Header.Set(httpkit.HeaderAccept, ct)
 
 	// check there's nothing there
-	cached, ok := context.GetOk(request, ctxResponseFormat)
+	cached, ok := contextValue(request, ctxResponseFormat)
 	assert.False(t, ok)
 	assert.Empty(t, cached)
 
 	// trigger the parse
-	mt := ctx.ResponseFormat(request, []string{ct})
+	mt, request := ctx.ResponseFormatWithRequest(request, []string{ct})
 	assert.Equal(t, ct, mt)
 
 	// check it was cached
-	cached, ok = context.GetOk(request, ctxResponseFormat)
+	cached, ok = contextValue(request, ctxResponseFormat)
 	assert.True(t, ok)
 	assert.Equal(t, ct, cached)
 
 	// check if the cast works and fetch from cache too
-	mt = ctx.ResponseFormat(request, []string{ct})
+	mt, _ = ctx.ResponseFormatWithRequest(request, []string{ct})
 	assert.Equal(t, ct, mt)
 }
 
@@ -199,21 +275,21 @@ func TestContextInvalidResponseFormat(t *testing.T) {
 	request.Header.Set(httpkit.HeaderAccept, ct)
 
 	// check there's nothing there
-	cached, ok := context.GetOk(request, ctxResponseFormat)
+	cached, ok := contextValue(request, ctxResponseFormat)
 	assert.False(t, ok)
 	assert.Empty(t, cached)
 
 	// trigger the parse
-	mt := ctx.ResponseFormat(request, []string{other})
+	mt, request := ctx.ResponseFormatWithRequest(request, []string{other})
 	assert.Empty(t, mt)
 
 	// check it was cached
-	cached, ok = context.GetOk(request, ctxResponseFormat)
+	cached, ok = contextValue(request, ctxResponseFormat)
 	assert.False(t, ok)
 	assert.Empty(t, cached)
 
 	// check if the cast works and fetch from cache too
-	mt = ctx.ResponseFormat(request, []string{other})
+	mt, _ = ctx.ResponseFormatWithRequest(request, []string{other})
 	assert.Empty(t, mt)
 }
 
@@ -225,20 +301,21 @@ func TestContextValidRoute(t *testing.T) {
 	request, _ := http.NewRequest("GET", "/pets", nil)
 
 	// check there's nothing there
-	_, ok := context.GetOk(request, ctxMatchedRoute)
+	_, ok := contextValue(request, ctxMatchedRoute)
 	assert.False(t, ok)
 
-	matched, ok := ctx.RouteInfo(request)
+	matched, request, ok := ctx.RouteInfoWithRequest(request)
 	assert.True(t, ok)
 	assert.NotNil(t, matched)
 
 	// check it was cached
-	_, ok = context.GetOk(request, ctxMatchedRoute)
+	_, ok = contextValue(request, ctxMatchedRoute)
 	assert.True(t, ok)
+	assert.Equal(t, matched, MatchedRouteFrom(request))
 
-	matched, ok = ctx.RouteInfo(request)
+	cached, _, ok := ctx.RouteInfoWithRequest(request)
 	assert.True(t, ok)
-	assert.NotNil(t, matched)
+	assert.True(t, matched == cached)
 }
 
 func TestContextInvalidRoute(t *testing.T) {
@@ -249,18 +326,18 @@ func TestContextInvalidRoute(t *testing.T) {
 	request, _ := http.NewRequest("DELETE", "pets", nil)
 
 	// check there's nothing there
-	_, ok := context.GetOk(request, ctxMatchedRoute)
+	_, ok := contextValue(request, ctxMatchedRoute)
 	assert.False(t, ok)
 
-	matched, ok := ctx.RouteInfo(request)
+	matched, request, ok := ctx.RouteInfoWithRequest(request)
 	assert.False(t, ok)
 	assert.Nil(t, matched)
 
 	// check it was cached
-	_, ok = context.GetOk(request, ctxMatchedRoute)
+	_, ok = contextValue(request, ctxMatchedRoute)
 	assert.False(t, ok)
 
-	matched, ok = ctx.RouteInfo(request)
+	matched, _, ok = ctx.RouteInfoWithRequest(request)
 	assert.False(t, ok)
 	assert.Nil(t, matched)
 }
@@ -273,20 +350,20 @@ func TestContextValidContentType(t *testing.T) {
 	request.Header.Set(httpkit.HeaderContentType, ct)
 
 	// check there's nothing there
-	_, ok := context.GetOk(request, ctxContentType)
+	_, ok := contextValue(request, ctxContentType)
 	assert.False(t, ok)
 
 	// trigger the parse
-	mt, _, err := ctx.ContentType(request)
+	mt, _, request, err := ctx.ContentTypeWithRequest(request)
 	assert.NoError(t, err)
 	assert.Equal(t, ct, mt)
 
 	// check it was cached
-	_, ok = context.GetOk(request, ctxContentType)
+	_, ok = contextValue(request, ctxContentType)
 	assert.True(t, ok)
 
 	// check if the cast works and fetch from cache too
-	mt, _, err = ctx.ContentType(request)
+	mt, _, _, err = ctx.ContentTypeWithRequest(request)
 	assert.NoError(t, err)
 	assert.Equal(t, ct, mt)
 }
@@ -299,19 +376,24 @@ func TestContextInvalidContentType(t *testing.T) {
 	request.Header.Set(httpkit.HeaderContentType, ct)
 
 	// check there's nothing there
-	_, ok := context.GetOk(request, ctxContentType)
+	_, ok := contextValue(request, ctxContentType)
 	assert.False(t, ok)
 
 	// trigger the parse
-	mt, _, err := ctx.ContentType(request)
+	mt, _, request, err := ctx.ContentTypeWithRequest(request)
 	assert.Error(t, err)
 	assert.Empty(t, mt)
 
 	// check it was not cached
-	_, ok = context.GetOk(request, ctxContentType)
+	_, ok = contextValue(request, ctxContentType)
 	assert.False(t, ok)
 
 	// check if the failure continues
-	_, _, err = ctx.ContentType(request)
+	_, _, _, err = ctx.ContentTypeWithRequest(request)
 	assert.Error(t, err)
 }
+
+func contextValue(request *http.Request, key contextKey) (interface{}, bool) {
+	v := request.Context().Value(key)
+	return v, v != nil
+}
@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vikstrous/go-swagger/httpkit"
+)
+
+// CORSOpts configures how an API answers cross origin requests
+type CORSOpts struct {
+	// AllowedOrigins the origins that can make requests, * allows any origin
+	AllowedOrigins []string
+	// AllowCredentials allows requests with cookies or http authentication from the origins that are listed,
+	// the origins that are only allowed by * can't make requests with credentials
+	AllowCredentials bool
+	// ExposedHeaders the response headers a browser may show to the caller
+	ExposedHeaders []string
+	// MaxAge how long the answer to a preflight request can be cached
+	MaxAge time.Duration
+}
+
+func (o *CORSOpts) allowsOrigin(origin string) bool {
+	for _, allowed := range o.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithCORS enables cross origin requests for the API served by this context.
+// OPTIONS requests are answered with the methods the router knows for the path,
+// preflight requests also get the headers that were declared for the requested operation.
+func (c *Context) WithCORS(opts CORSOpts) *Context {
+	c.cors = &opts
+	return c
+}
+
+func newCORS(ctx *Context, next http.Handler) http.Handler {
+	if ctx.cors == nil {
+		return next
+	}
+	if ctx.router == nil {
+		ctx.router = DefaultRouter(ctx.spec, ctx.api)
+	}
+	isRoot := ctx.spec.BasePath() == "" || ctx.spec.BasePath() == "/"
+	opts := ctx.cors
+
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		allowed := origin != "" && opts.allowsOrigin(origin)
+		if allowed {
+			setAllowOrigin(rw, opts, origin)
+			if len(opts.ExposedHeaders) > 0 {
+				rw.Header().Set("Access-Control-Expose-Headers", strings.Join(opts.ExposedHeaders, ", "))
+			}
+		}
+
+		if r.Method != "OPTIONS" {
+			next.ServeHTTP(rw, r)
+			return
+		}
+		prefix, ok := routePrefix(ctx, isRoot, r)
+		if !ok {
+			next.ServeHTTP(rw, r)
+			return
+		}
+		path := strings.TrimPrefix(r.URL.Path, prefix)
+		if _, ok := ctx.router.Lookup("OPTIONS", path); ok {
+			// the API implements OPTIONS for this path itself
+			next.ServeHTTP(rw, r)
+			return
+		}
+		methods := ctx.router.OtherMethods("OPTIONS", path)
+		if len(methods) == 0 {
+			next.ServeHTTP(rw, r)
+			return
+		}
+		sort.Strings(methods)
+		methods = append(methods, "OPTIONS")
+		rw.Header().Set("Allow", strings.Join(methods, ","))
+
+		requested := strings.ToUpper(r.Header.Get("Access-Control-Request-Method"))
+		if allowed && requested != "" {
+			if route, ok := ctx.router.Lookup(requested, path); ok {
+				rw.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+				if headers := ctx.corsHeaders(route); len(headers) > 0 {
+					rw.Header().Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+				}
+				if opts.MaxAge > 0 {
+					rw.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(opts.MaxAge/time.Second)))
+				}
+			}
+		}
+		rw.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func setAllowOrigin(rw http.ResponseWriter, opts *CORSOpts, origin string) {
+	rw.Header().Add("Vary", "Origin")
+	for _, o := range opts.AllowedOrigins {
+		if strings.EqualFold(o, origin) {
+			rw.Header().Set("Access-Control-Allow-Origin", origin)
+			if opts.AllowCredentials {
+				rw.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
+			return
+		}
+	}
+	// only allowed by the wildcard, a wildcard origin can't be used for requests with credentials
+	rw.Header().Set("Access-Control-Allow-Origin", "*")
+}
+
+// corsHeaders the request headers that were declared for a route,
+// these are the header parameters, the content type and the headers used for authentication
+func (c *Context) corsHeaders(route *MatchedRoute) []string {
+	seen := make(map[string]struct{})
+	var headers []string
+	add := func(name string) {
+		name = http.CanonicalHeaderKey(name)
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			headers = append(headers, name)
+		}
+	}
+
+	if len(route.Consumes) > 0 {
+		add(httpkit.HeaderContentType)
+	}
+	for _, param := range route.Parameters {
+		if param.In == "header" {
+			add(param.Name)
+		}
+	}
+	if route.Operation != nil {
+		for _, scheme := range c.spec.SecurityDefinitionsFor(route.Operation) {
+			switch scheme.Type {
+			case "basic", "oauth2":
+				add("Authorization")
+			case "apiKey":
+				if scheme.In == "header" {
+					add(scheme.Name)
+				}
+			}
+		}
+	}
+	sort.Strings(headers)
+	return headers
+}
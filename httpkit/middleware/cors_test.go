@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vikstrous/go-swagger/httpkit"
+	"github.com/vikstrous/go-swagger/internal/testing/petstore"
+	"github.com/vikstrous/go-swagger/spec"
+)
+
+func TestCORSPreflight(t *testing.T) {
+	spec, api := petstore.NewAPI(t)
+	context := NewContext(spec, api, nil).WithCORS(CORSOpts{
+		AllowedOrigins: []string{"http://example.com"},
+		MaxAge:         10 * time.Minute,
+	})
+	handler := context.APIHandler()
+
+	recorder := httptest.NewRecorder()
+	request, _ := http.NewRequest("OPTIONS", "/api/pets", nil)
+	request.Header.Set("Origin", "http://example.com")
+	request.Header.Set("Access-Control-Request-Method", "POST")
+	request.Header.Set("Access-Control-Request-Headers", "content-type")
+	handler.ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	assert.Equal(t, "GET,POST,OPTIONS", recorder.Header().Get("Allow"))
+	assert.Equal(t, "http://example.com", recorder.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET, POST, OPTIONS", recorder.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Authorization, Content-Type", recorder.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "600", recorder.Header().Get("Access-Control-Max-Age"))
+
+	// not an allowed origin
+	recorder = httptest.NewRecorder()
+	request, _ = http.NewRequest("OPTIONS", "/api/pets/1", nil)
+	request.Header.Set("Origin", "http://other.com")
+	request.Header.Set("Access-Control-Request-Method", "DELETE")
+	handler.ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	assert.Equal(t, "DELETE,GET,OPTIONS", recorder.Header().Get("Allow"))
+	assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Methods"))
+
+	// unknown path
+	recorder = httptest.NewRecorder()
+	request, _ = http.NewRequest("OPTIONS", "/api/nopets", nil)
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestCORSRequest(t *testing.T) {
+	spec, api := petstore.NewAPI(t)
+	context := NewContext(spec, api, nil).WithCORS(CORSOpts{
+		AllowedOrigins:   []string{"http://example.com", "*"},
+		AllowCredentials: true,
+		ExposedHeaders:   []string{"X-Rate-Limit"},
+	})
+	handler := context.APIHandler()
+
+	recorder := httptest.NewRecorder()
+	request, _ := http.NewRequest("GET", "/api/pets", nil)
+	request.Header.Set("Origin", "http://example.com")
+	request.Header.Set(httpkit.HeaderAccept, httpkit.JSONMime)
+	request.SetBasicAuth("admin", "admin")
+	handler.ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "http://example.com", recorder.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", recorder.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "X-Rate-Limit", recorder.Header().Get("Access-Control-Expose-Headers"))
+	assert.Equal(t, "Origin", recorder.Header().Get("Vary"))
+
+	// an origin that is only allowed by the wildcard doesn't get credentials
+	recorder = httptest.NewRecorder()
+	request, _ = http.NewRequest("GET", "/api/pets", nil)
+	request.Header.Set("Origin", "http://evil.com")
+	request.Header.Set(httpkit.HeaderAccept, httpkit.JSONMime)
+	request.SetBasicAuth("admin", "admin")
+	handler.ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "*", recorder.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Credentials"))
+
+	// without cors configured there is no OPTIONS support
+	context = NewContext(spec, api, nil)
+	recorder = httptest.NewRecorder()
+	request, _ = http.NewRequest("OPTIONS", "/api/pets", nil)
+	context.APIHandler().ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestCORSHeaders(t *testing.T) {
+	doc, api := petstore.NewAPI(t)
+	context := NewContext(doc, api, nil)
+
+	route := &MatchedRoute{routeEntry: routeEntry{
+		Operation: doc.AllPaths()["/pets/{id}"].Delete,
+		Parameters: map[string]spec.Parameter{
+			"id":             *spec.PathParam("id"),
+			"x-request-id":   *spec.HeaderParam("x-request-id"),
+			"X-Request-Id#2": *spec.HeaderParam("X-Request-ID"),
+		},
+	}}
+	assert.Equal(t, []string{"X-Api-Key", "X-Request-Id"}, context.corsHeaders(route))
+}
@@ -6,24 +6,24 @@ Pseudo middleware handler
   	"net/http"
 
   	"github.com/vikstrous/go-swagger/errors"
-  	"github.com/gorilla/context"
   )
 
   func newCompleteMiddleware(ctx *Context) http.Handler {
   	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
-  		defer context.Clear(r)
-
-  		// use context to lookup routes
-  		if matched, ok := ctx.RouteInfo(r); ok {
+  		// use context to lookup routes, the values found for the request
+  		// are carried on the request that is returned
+  		if matched, r, ok := ctx.RouteInfoWithRequest(r); ok {
 
   			if len(matched.Authenticators) > 0 {
-  				if _, err := ctx.Authorize(r, matched); err != nil {
+  				_, rCtx, err := ctx.AuthorizeWithRequest(r, matched)
+  				if err != nil {
   					ctx.Respond(rw, r, matched.Produces, matched, err)
   					return
   				}
+  				r = rCtx
   			}
 
-  			bound, validation := ctx.BindAndValidate(r, matched)
+  			bound, r, validation := ctx.BindAndValidateWithRequest(r, matched)
   			if validation != nil {
   				ctx.Respond(rw, r, matched.Produces, matched, validation)
   				return
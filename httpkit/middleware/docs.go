@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/vikstrous/go-swagger/httpkit/middleware/explorer"
+	"github.com/vikstrous/go-swagger/spec"
+)
+
+// DocsOpts configures the API explorer
+type DocsOpts struct {
+	// Path the path at which the explorer is served, defaults to /docs
+	Path string
+	// SpecURL the url the page links to for the spec, defaults to /swagger.json
+	SpecURL string
+	// Title the title of the page, defaults to the title of the spec
+	Title string
+}
+
+// WithDocs serves an API explorer for the spec of this context.
+// The page documents the operations, parameters and models of the spec
+// and lets you try the operations against the server that serves it.
+func (c *Context) WithDocs(opts DocsOpts) *Context {
+	c.docs = &opts
+	return c
+}
+
+func newDocs(ctx *Context, next http.Handler) http.Handler {
+	if ctx.docs == nil {
+		return next
+	}
+	return Docs(ctx.spec, *ctx.docs, next)
+}
+
+type docsPage struct {
+	Title      string
+	Version    string
+	SpecURL    string
+	BasePath   string
+	AssetsPath string
+	Spec       template.JS
+}
+
+// Docs creates a middleware to serve an API explorer for a spec.
+// The page and its assets are embedded, so it doesn't need anything but the spec.
+func Docs(doc *spec.Document, opts DocsOpts, next http.Handler) http.Handler {
+	if opts.Path == "" {
+		opts.Path = "/docs"
+	}
+	opts.Path = path.Clean("/" + opts.Path)
+	if opts.SpecURL == "" {
+		opts.SpecURL = "/swagger.json"
+	}
+
+	page, err := renderDocs(doc, opts)
+	if err != nil {
+		// the page is rendered from a spec that was already loaded, so this can only be a bug
+		panic(err)
+	}
+	assets := map[string]string{
+		"explorer.js":  "application/javascript",
+		"explorer.css": "text/css",
+	}
+
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" && r.Method != "HEAD" {
+			serveNext(rw, r, next)
+			return
+		}
+
+		if r.URL.Path == opts.Path || r.URL.Path == opts.Path+"/" {
+			writeDocsAsset(rw, r, "text/html; charset=utf-8", page)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, opts.Path+"/") {
+			name := strings.TrimPrefix(r.URL.Path, opts.Path+"/")
+			if mediaType, ok := assets[name]; ok {
+				writeDocsAsset(rw, r, mediaType, explorer.MustAsset(name))
+				return
+			}
+		}
+		serveNext(rw, r, next)
+	})
+}
+
+func serveNext(rw http.ResponseWriter, r *http.Request, next http.Handler) {
+	if next == nil {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(http.StatusNotFound)
+		return
+	}
+	next.ServeHTTP(rw, r)
+}
+
+func writeDocsAsset(rw http.ResponseWriter, r *http.Request, mediaType string, content []byte) {
+	rw.Header().Set("Content-Type", mediaType)
+	rw.WriteHeader(http.StatusOK)
+	if r.Method == "HEAD" {
+		return
+	}
+	rw.Write(content)
+}
+
+func renderDocs(doc *spec.Document, opts DocsOpts) ([]byte, error) {
+	tpl, err := template.New("docs").Parse(string(explorer.MustAsset("index.html")))
+	if err != nil {
+		return nil, err
+	}
+
+	// the spec is put in a script, the escaped <, > and & keep it from closing the script tag
+	compact := bytes.NewBuffer(nil)
+	if err := json.Compact(compact, doc.Raw()); err != nil {
+		return nil, err
+	}
+	specJSON := bytes.NewBuffer(nil)
+	json.HTMLEscape(specJSON, compact.Bytes())
+
+	data := docsPage{
+		Title:      opts.Title,
+		SpecURL:    opts.SpecURL,
+		BasePath:   doc.BasePath(),
+		AssetsPath: opts.Path,
+		Spec:       template.JS(specJSON.String()),
+	}
+	if info := doc.Spec().Info; info != nil {
+		if data.Title == "" {
+			data.Title = info.Title
+		}
+		data.Version = info.Version
+	}
+	if data.Title == "" {
+		data.Title = "API"
+	}
+	if data.BasePath == "" {
+		data.BasePath = "/"
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := tpl.Execute(buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vikstrous/go-swagger/httpkit"
+	"github.com/vikstrous/go-swagger/internal/testing/petstore"
+	"github.com/vikstrous/go-swagger/spec"
+)
+
+func TestServeDocs(t *testing.T) {
+	spec, api := petstore.NewAPI(t)
+	handler := NewContext(spec, api, nil).WithDocs(DocsOpts{}).APIHandler()
+
+	// serves the page with the spec in it
+	request, _ := http.NewRequest("GET", "/docs", nil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/html; charset=utf-8", recorder.Header().Get(httpkit.HeaderContentType))
+	body := recorder.Body.String()
+	assert.Contains(t, body, "<title>"+spec.Spec().Info.Title+"</title>")
+	assert.Contains(t, body, `href="/swagger.json"`)
+	assert.Contains(t, body, `"operationId":"getAllPets"`)
+	assert.Contains(t, body, `basePath: "/api"`)
+	assert.Contains(t, body, `src="/docs/explorer.js"`)
+
+	request, _ = http.NewRequest("GET", "/docs/", nil)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	// serves the assets
+	request, _ = http.NewRequest("GET", "/docs/explorer.js", nil)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/javascript", recorder.Header().Get(httpkit.HeaderContentType))
+	assert.Contains(t, recorder.Body.String(), "explorerSpec")
+
+	request, _ = http.NewRequest("HEAD", "/docs/explorer.css", nil)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/css", recorder.Header().Get(httpkit.HeaderContentType))
+	assert.Equal(t, 0, recorder.Body.Len())
+
+	request, _ = http.NewRequest("GET", "/docs/missing.js", nil)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	// the api is still served
+	request, _ = http.NewRequest("GET", "/api/pets", nil)
+	request.SetBasicAuth("admin", "admin")
+	request.Header.Set(httpkit.HeaderAccept, httpkit.JSONMime)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestServeDocsOpts(t *testing.T) {
+	spec, _ := petstore.NewAPI(t)
+	next := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusTeapot)
+	})
+	handler := Docs(spec, DocsOpts{Path: "explore/", SpecURL: "/api/swagger.json", Title: "Pets <3"}, next)
+
+	request, _ := http.NewRequest("GET", "/explore", nil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	body := recorder.Body.String()
+	assert.Contains(t, body, "<title>Pets &lt;3</title>")
+	assert.Contains(t, body, `href="/api/swagger.json"`)
+	assert.Contains(t, body, `src="/explore/explorer.js"`)
+
+	// the old path and other methods go to the next handler
+	request, _ = http.NewRequest("GET", "/docs", nil)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusTeapot, recorder.Code)
+
+	request, _ = http.NewRequest("POST", "/explore", nil)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusTeapot, recorder.Code)
+}
+
+func TestServeDocsEscapesSpec(t *testing.T) {
+	doc, _ := petstore.NewAPI(t)
+	doc.Spec().Info.Description = "</script><script>alert(1)</script>"
+	raw, _ := json.Marshal(doc.Spec())
+	doc, err := spec.New(raw, "")
+	if assert.NoError(t, err) {
+		page, err := renderDocs(doc, DocsOpts{Path: "/docs", SpecURL: "/swagger.json"})
+		if assert.NoError(t, err) {
+			assert.Equal(t, 2, strings.Count(string(page), "</script>"))
+			assert.Contains(t, string(page), `\u003c/script\u003e\u003cscript\u003ealert(1)`)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/vikstrous/go-swagger/errors"
+	"github.com/vikstrous/go-swagger/httpkit"
+	"github.com/vikstrous/go-swagger/spec"
+)
+
+// ErrorMapping maps the domain errors that handlers return to a response documented for an operation.
+// It matches an error that is, or wraps, the sentinel Error or an error of the same type as Type,
+// an error wraps the error its Cause or Unwrap method returns.
+type ErrorMapping struct {
+	// Error a sentinel error value
+	Error error
+	// Type an example value of an error type
+	Type error
+	// Code the status code of the response
+	Code int
+	// Payload builds the body of the response from the error,
+	// when it's nil the error is served by the error handler of the API with the code of the mapping
+	Payload func(error) interface{}
+}
+
+func (m *ErrorMapping) matches(err error) bool {
+	for ; err != nil; err = unwrapError(err) {
+		tpe := reflect.TypeOf(err)
+		if m.Error != nil && tpe.Comparable() && err == m.Error {
+			return true
+		}
+		if m.Type != nil && tpe == reflect.TypeOf(m.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// unwrapError the error that is wrapped by an error, nil when it doesn't wrap one
+func unwrapError(err error) error {
+	switch e := err.(type) {
+	case interface {
+		Cause() error
+	}:
+		return e.Cause()
+	case interface {
+		Unwrap() error
+	}:
+		return e.Unwrap()
+	}
+	return nil
+}
+
+// ErrorMapper is a registry of error mappings per operation
+type ErrorMapper struct {
+	spec       *spec.Document
+	common     []ErrorMapping
+	operations map[string][]ErrorMapping
+}
+
+// NewErrorMapper creates a new error mapper for the operations of the specified spec
+func NewErrorMapper(spec *spec.Document) *ErrorMapper {
+	return &ErrorMapper{spec: spec, operations: make(map[string][]ErrorMapping)}
+}
+
+// WithErrorMapper maps the errors of the handlers to responses with the specified mapper
+func (c *Context) WithErrorMapper(mapper *ErrorMapper) *Context {
+	c.errors = mapper
+	return c
+}
+
+// Map adds mappings for the errors of an operation, an empty operation id adds them for every operation.
+// It fails when the operation doesn't exist or it doesn't document a response for the code of a mapping.
+func (e *ErrorMapper) Map(operationID string, mappings ...ErrorMapping) error {
+	var op *spec.Operation
+	if operationID != "" {
+		o, ok := e.spec.OperationForName(operationID)
+		if !ok {
+			return fmt.Errorf("can't map errors for operation %q, it doesn't exist", operationID)
+		}
+		op = o
+	}
+
+	for _, m := range mappings {
+		if (m.Error == nil) == (m.Type == nil) {
+			return fmt.Errorf("an error mapping for operation %q needs either an error or a type", operationID)
+		}
+		if m.Code < 100 || m.Code > 599 {
+			return fmt.Errorf("%d is not a valid status code for an error mapping", m.Code)
+		}
+		if op != nil && !documentsResponse(op, m.Code) {
+			return fmt.Errorf("operation %q doesn't document a response for %d", operationID, m.Code)
+		}
+	}
+
+	if operationID == "" {
+		e.common = append(e.common, mappings...)
+		return nil
+	}
+	e.operations[operationID] = append(e.operations[operationID], mappings...)
+	return nil
+}
+
+func documentsResponse(op *spec.Operation, code int) bool {
+	if op.Responses == nil {
+		return false
+	}
+	if _, ok := op.Responses.StatusCodeResponses[code]; ok {
+		return true
+	}
+	return op.Responses.Default != nil
+}
+
+// Lookup finds the mapping for an error returned by the handler of an operation,
+// the mappings of the operation are tried before the ones for every operation
+func (e *ErrorMapper) Lookup(operationID string, err error) (ErrorMapping, bool) {
+	if e == nil || err == nil {
+		return ErrorMapping{}, false
+	}
+	for _, mappings := range [][]ErrorMapping{e.operations[operationID], e.common} {
+		for _, m := range mappings {
+			if m.matches(err) {
+				return m, true
+			}
+		}
+	}
+	return ErrorMapping{}, false
+}
+
+// respondMapped writes the response of an error mapping
+func (c *Context) respondMapped(rw http.ResponseWriter, r *http.Request, route *MatchedRoute, offers []string, format string, mapping ErrorMapping, err error) {
+	operationID := operationIDFor(route)
+	if mapping.Payload == nil {
+		c.api.ServeErrorFor(operationID)(rw, r, errors.New(int32(mapping.Code), "%s", err.Error()))
+		return
+	}
+
+	if format == "" {
+		format = httpkit.JSONMime
+	}
+	producers := c.api.ProducersFor(offers)
+	if route != nil && route.Producers != nil {
+		producers = route.Producers
+	}
+	prod, ok := producers[format]
+	if !ok {
+		c.api.ServeErrorFor(operationID)(rw, r, errors.New(int32(mapping.Code), "%s", err.Error()))
+		return
+	}
+
+	rw.WriteHeader(mapping.Code)
+	if r.Method == "HEAD" {
+		return
+	}
+	if err := prod.Produce(rw, mapping.Payload(err)); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
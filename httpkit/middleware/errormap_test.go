@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vikstrous/go-swagger/httpkit"
+	"github.com/vikstrous/go-swagger/internal/testing/petstore"
+)
+
+var errNoPets = stderrors.New("there are no pets")
+
+type petError struct {
+	ID int64
+}
+
+func (p *petError) Error() string {
+	return fmt.Sprintf("pet %d is missing", p.ID)
+}
+
+// wrappedError wraps an error with a message like errors.Wrap does
+type wrappedError struct {
+	msg   string
+	cause error
+}
+
+func (w *wrappedError) Error() string {
+	return w.msg + ": " + w.cause.Error()
+}
+
+func (w *wrappedError) Cause() error {
+	return w.cause
+}
+
+// unwrappedError wraps an error with a message like fmt.Errorf with %w does
+type unwrappedError struct {
+	msg string
+	err error
+}
+
+func (u *unwrappedError) Error() string {
+	return u.msg + ": " + u.err.Error()
+}
+
+func (u *unwrappedError) Unwrap() error {
+	return u.err
+}
+
+// errorList can't be compared with ==
+type errorList []string
+
+func (e errorList) Error() string {
+	return strings.Join(e, ", ")
+}
+
+func TestErrorMapper_Map(t *testing.T) {
+	doc, _ := petstore.NewAPI(t)
+	mapper := NewErrorMapper(doc)
+
+	assert.NoError(t, mapper.Map("getAllPets", ErrorMapping{Error: errNoPets, Code: 404}))
+	assert.NoError(t, mapper.Map("", ErrorMapping{Type: new(petError), Code: 410}))
+
+	assert.Error(t, mapper.Map("getNoPets", ErrorMapping{Error: errNoPets, Code: 404}))
+	assert.Error(t, mapper.Map("getAllPets", ErrorMapping{Code: 404}))
+	assert.Error(t, mapper.Map("getAllPets", ErrorMapping{Error: errNoPets, Type: new(petError), Code: 404}))
+	assert.Error(t, mapper.Map("getAllPets", ErrorMapping{Error: errNoPets, Code: 99}))
+
+	doc.AllPaths()["/pets/{id}"].Delete.Responses.Default = nil
+	assert.Error(t, mapper.Map("deletePet", ErrorMapping{Error: errNoPets, Code: 404}))
+	assert.NoError(t, mapper.Map("deletePet", ErrorMapping{Error: errNoPets, Code: 204}))
+
+	m, ok := mapper.Lookup("getAllPets", &wrappedError{"listing", errNoPets})
+	assert.True(t, ok)
+	assert.Equal(t, 404, m.Code)
+	m, ok = mapper.Lookup("getAllPets", &unwrappedError{"listing", &wrappedError{"reading", errNoPets}})
+	assert.True(t, ok)
+	assert.Equal(t, 404, m.Code)
+	m, ok = mapper.Lookup("getPetById", &unwrappedError{"finding", &petError{ID: 1}})
+	assert.True(t, ok)
+	assert.Equal(t, 410, m.Code)
+	_, ok = mapper.Lookup("getPetById", errNoPets)
+	assert.False(t, ok)
+	_, ok = mapper.Lookup("getAllPets", &wrappedError{"listing", errorList{"no pets", "no toys"}})
+	assert.False(t, ok)
+	_, ok = (*ErrorMapper)(nil).Lookup("getPetById", errNoPets)
+	assert.False(t, ok)
+}
+
+func TestErrorMapper_Respond(t *testing.T) {
+	doc, api := petstore.NewAPI(t)
+	var failWith error
+	api.RegisterOperation("getAllPets", httpkit.OperationHandlerFunc(func(interface{}) (interface{}, error) {
+		return nil, failWith
+	}))
+
+	mapper := NewErrorMapper(doc)
+	assert.NoError(t, mapper.Map("getAllPets",
+		ErrorMapping{Error: errNoPets, Code: http.StatusNotFound},
+		ErrorMapping{Type: new(petError), Code: http.StatusGone, Payload: func(err error) interface{} {
+			return map[string]interface{}{"code": 410, "message": err.Error()}
+		}},
+	))
+	handler := NewContext(doc, api, nil).WithErrorMapper(mapper).APIHandler()
+
+	serve := func(err error) *httptest.ResponseRecorder {
+		failWith = err
+		recorder := httptest.NewRecorder()
+		request, _ := http.NewRequest("GET", "/api/pets", nil)
+		request.SetBasicAuth("admin", "admin")
+		request.Header.Set(httpkit.HeaderAccept, httpkit.JSONMime)
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	recorder := serve(errNoPets)
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.JSONEq(t, `{"code":404,"message":"there are no pets"}`, recorder.Body.String())
+
+	recorder = serve(&petError{ID: 3})
+	assert.Equal(t, http.StatusGone, recorder.Code)
+	assert.Equal(t, httpkit.JSONMime, recorder.Header().Get(httpkit.HeaderContentType))
+	assert.JSONEq(t, `{"code":410,"message":"pet 3 is missing"}`, recorder.Body.String())
+
+	recorder = serve(stderrors.New("unmapped"))
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
@@ -0,0 +1,473 @@
+(function (spec, opts) {
+  'use strict';
+
+  var methods = ['get', 'put', 'post', 'delete', 'options', 'head', 'patch'];
+  var credentials = {};
+
+  // el builds an element, text is always set as text content so the spec can't inject markup
+  function el(tag, attrs, children) {
+    var node = document.createElement(tag);
+    Object.keys(attrs || {}).forEach(function (key) {
+      if (key === 'text') {
+        node.textContent = attrs[key];
+      } else {
+        node.setAttribute(key, attrs[key]);
+      }
+    });
+    (children || []).forEach(function (child) {
+      if (child) {
+        node.appendChild(typeof child === 'string' ? document.createTextNode(child) : child);
+      }
+    });
+    return node;
+  }
+
+  function resolve(obj) {
+    var seen = 0;
+    while (obj && obj.$ref && seen < 32) {
+      var ref = obj.$ref;
+      if (ref.indexOf('#/') !== 0) {
+        return obj;
+      }
+      obj = ref.substring(2).split('/').reduce(function (node, token) {
+        token = decodeURIComponent(token).replace(/~1/g, '/').replace(/~0/g, '~');
+        return node ? node[token] : undefined;
+      }, spec);
+      seen++;
+    }
+    return obj;
+  }
+
+  function refName(schema) {
+    if (schema && schema.$ref && schema.$ref.indexOf('#/definitions/') === 0) {
+      return schema.$ref.substring('#/definitions/'.length);
+    }
+    return '';
+  }
+
+  function typeOf(schema) {
+    if (!schema) {
+      return '';
+    }
+    var name = refName(schema);
+    if (name) {
+      return name;
+    }
+    if (schema.type === 'array') {
+      return '[]' + typeOf(schema.items);
+    }
+    if (schema.type === 'file') {
+      return 'file';
+    }
+    var tpe = schema.type || (schema.properties ? 'object' : '');
+    return schema.format ? tpe + ' (' + schema.format + ')' : tpe;
+  }
+
+  // sample builds an example value for a schema, preferring the examples and defaults of the spec
+  function sample(schema, depth) {
+    depth = depth || 0;
+    schema = resolve(schema);
+    if (!schema || depth > 6) {
+      return null;
+    }
+    if (schema.example !== undefined) {
+      return schema.example;
+    }
+    if (schema['default'] !== undefined) {
+      return schema['default'];
+    }
+    if (schema['enum'] && schema['enum'].length) {
+      return schema['enum'][0];
+    }
+    if (schema.allOf) {
+      var merged = {};
+      schema.allOf.forEach(function (part) {
+        var value = sample(part, depth + 1);
+        if (value && typeof value === 'object') {
+          Object.keys(value).forEach(function (key) { merged[key] = value[key]; });
+        }
+      });
+      return merged;
+    }
+    switch (schema.type) {
+      case 'array':
+        return [sample(schema.items, depth + 1)];
+      case 'integer':
+      case 'number':
+        return schema.minimum !== undefined ? schema.minimum : 0;
+      case 'boolean':
+        return true;
+      case 'string':
+        switch (schema.format) {
+          case 'date':
+            return '2006-01-02';
+          case 'date-time':
+            return '2006-01-02T15:04:05Z';
+          case 'uuid':
+            return '00000000-0000-0000-0000-000000000000';
+          case 'email':
+            return 'user@example.com';
+          default:
+            return 'string';
+        }
+      default:
+        var obj = {};
+        Object.keys(schema.properties || {}).forEach(function (key) {
+          obj[key] = sample(schema.properties[key], depth + 1);
+        });
+        return obj;
+    }
+  }
+
+  function pretty(value) {
+    return JSON.stringify(value, null, 2);
+  }
+
+  function renderSchema(schema) {
+    var resolved = resolve(schema) || {};
+    var rows = [];
+    if (resolved.type === 'array' || schema.type === 'array') {
+      return el('p', {}, ['array of ', el('code', {text: typeOf(resolved.items || schema.items)})]);
+    }
+    var required = resolved.required || [];
+    Object.keys(resolved.properties || {}).forEach(function (key) {
+      var prop = resolved.properties[key];
+      rows.push(el('tr', {}, [
+        el('td', {}, [el('code', {text: key}), required.indexOf(key) >= 0 ? el('span', {'class': 'required', text: ' *'}) : null]),
+        el('td', {}, [el('code', {text: typeOf(prop)})]),
+        el('td', {text: (resolve(prop) || {}).description || ''})
+      ]));
+    });
+    if (!rows.length) {
+      return el('p', {}, [el('code', {text: typeOf(schema)})]);
+    }
+    return el('table', {}, [
+      el('thead', {}, [el('tr', {}, [el('th', {text: 'property'}), el('th', {text: 'type'}), el('th', {text: 'description'})])]),
+      el('tbody', {}, rows)
+    ]);
+  }
+
+  function paramsOf(pathItem, op) {
+    var params = {};
+    (pathItem.parameters || []).concat(op.parameters || []).forEach(function (param) {
+      param = resolve(param);
+      params[param['in'] + ':' + param.name] = param;
+    });
+    return Object.keys(params).map(function (key) { return params[key]; });
+  }
+
+  function renderParams(params) {
+    if (!params.length) {
+      return null;
+    }
+    return el('table', {}, [
+      el('thead', {}, [el('tr', {}, [el('th', {text: 'name'}), el('th', {text: 'in'}), el('th', {text: 'type'}), el('th', {text: 'description'})])]),
+      el('tbody', {}, params.map(function (param) {
+        return el('tr', {}, [
+          el('td', {}, [el('code', {text: param.name}), param.required ? el('span', {'class': 'required', text: ' *'}) : null]),
+          el('td', {text: param['in']}),
+          el('td', {}, [el('code', {text: param['in'] === 'body' ? typeOf(param.schema) : typeOf(param)})]),
+          el('td', {text: param.description || ''})
+        ]);
+      }))
+    ]);
+  }
+
+  function renderResponses(op) {
+    var responses = op.responses || {};
+    return el('table', {}, [
+      el('thead', {}, [el('tr', {}, [el('th', {text: 'code'}), el('th', {text: 'description'}), el('th', {text: 'example'})])]),
+      el('tbody', {}, Object.keys(responses).map(function (code) {
+        var response = resolve(responses[code]) || {};
+        var example = null;
+        if (response.examples) {
+          var mime = Object.keys(response.examples)[0];
+          example = response.examples[mime];
+        } else if (response.schema) {
+          example = sample(response.schema);
+        }
+        return el('tr', {}, [
+          el('td', {}, [el('code', {text: code})]),
+          el('td', {}, [response.description || '', response.schema ? renderSchema(response.schema) : null]),
+          el('td', {}, [example !== null ? el('pre', {text: typeof example === 'string' ? example : pretty(example)}) : null])
+        ]);
+      }))
+    ]);
+  }
+
+  function select(name, values) {
+    return el('select', {name: name}, values.map(function (value) {
+      return el('option', {value: value, text: value});
+    }));
+  }
+
+  function paramInput(param) {
+    var name = param['in'] + ':' + param.name;
+    if (param['in'] === 'body') {
+      return el('textarea', {name: name}, [pretty(sample(param.schema))]);
+    }
+    if (param.type === 'file') {
+      return el('input', {name: name, type: 'file'});
+    }
+    if (param['enum']) {
+      return select(name, (param.required ? [] : ['']).concat(param['enum'].map(String)));
+    }
+    var attrs = {name: name, type: 'text'};
+    if (param['default'] !== undefined) {
+      attrs.value = String(param['default']);
+    }
+    if (param.type === 'array') {
+      attrs.placeholder = 'comma separated values';
+    }
+    return el('input', attrs);
+  }
+
+  function splitValues(param, value) {
+    if (param.type !== 'array') {
+      return [value];
+    }
+    return value.split(',').map(function (v) { return v.trim(); });
+  }
+
+  function joinValues(param, values) {
+    var separators = {csv: ',', ssv: ' ', tsv: '\t', pipes: '|'};
+    return values.join(separators[param.collectionFormat || 'csv'] || ',');
+  }
+
+  function applyCredentials(op, headers, query) {
+    var requirements = op.security || spec.security || [];
+    var definitions = spec.securityDefinitions || {};
+    requirements.forEach(function (requirement) {
+      Object.keys(requirement).forEach(function (name) {
+        var scheme = definitions[name];
+        var value = credentials[name];
+        if (!scheme || !value) {
+          return;
+        }
+        if (scheme.type === 'basic' && value.username) {
+          headers.Authorization = 'Basic ' + btoa(value.username + ':' + (value.password || ''));
+        } else if (scheme.type === 'apiKey' && value.key) {
+          if (scheme['in'] === 'header') {
+            headers[scheme.name] = value.key;
+          } else {
+            query.push(encodeURIComponent(scheme.name) + '=' + encodeURIComponent(value.key));
+          }
+        }
+      });
+    });
+  }
+
+  function submit(form, path, method, op, params, output) {
+    var url = path;
+    var query = [];
+    var headers = {};
+    var formParams = [];
+    var body;
+
+    params.forEach(function (param) {
+      var field = form.elements[param['in'] + ':' + param.name];
+      if (param['in'] === 'formData') {
+        formParams.push({param: param, field: field});
+        return;
+      }
+      var value = field.value;
+      if (value === '') {
+        return;
+      }
+      switch (param['in']) {
+        case 'path':
+          url = url.replace('{' + param.name + '}', encodeURIComponent(value));
+          break;
+        case 'query':
+          var values = splitValues(param, value);
+          if (param.collectionFormat === 'multi') {
+            values.forEach(function (v) {
+              query.push(encodeURIComponent(param.name) + '=' + encodeURIComponent(v));
+            });
+          } else {
+            query.push(encodeURIComponent(param.name) + '=' + encodeURIComponent(joinValues(param, values)));
+          }
+          break;
+        case 'header':
+          headers[param.name] = joinValues(param, splitValues(param, value));
+          break;
+        case 'body':
+          body = value;
+          break;
+      }
+    });
+
+    var consumes = form.elements.consumes ? form.elements.consumes.value : '';
+    if (formParams.length) {
+      if (consumes === 'multipart/form-data' || formParams.some(function (p) { return p.param.type === 'file'; })) {
+        body = new FormData();
+        formParams.forEach(function (p) {
+          if (p.param.type === 'file') {
+            if (p.field.files.length) {
+              body.append(p.param.name, p.field.files[0]);
+            }
+          } else if (p.field.value !== '') {
+            body.append(p.param.name, p.field.value);
+          }
+        });
+        consumes = '';
+      } else {
+        body = formParams.filter(function (p) { return p.field.value !== ''; }).map(function (p) {
+          return encodeURIComponent(p.param.name) + '=' + encodeURIComponent(p.field.value);
+        }).join('&');
+        consumes = 'application/x-www-form-urlencoded';
+      }
+    }
+    if (body !== undefined && consumes) {
+      headers['Content-Type'] = consumes;
+    }
+    if (form.elements.produces) {
+      headers.Accept = form.elements.produces.value;
+    }
+    applyCredentials(op, headers, query);
+
+    var base = opts.basePath === '/' ? '' : opts.basePath;
+    var target = base + url + (query.length ? '?' + query.join('&') : '');
+    output.textContent = 'loading ' + method.toUpperCase() + ' ' + target;
+
+    fetch(target, {method: method.toUpperCase(), headers: headers, body: body, credentials: 'same-origin'}).then(function (res) {
+      return res.text().then(function (text) {
+        var lines = [];
+        res.headers.forEach(function (value, key) { lines.push(key + ': ' + value); });
+        try {
+          text = pretty(JSON.parse(text));
+        } catch (e) {
+          // not json, show it as is
+        }
+        output.textContent = '';
+        output.appendChild(el('p', {'class': 'status', text: method.toUpperCase() + ' ' + target + ' ' + res.status + ' ' + res.statusText}));
+        output.appendChild(el('pre', {text: lines.join('\n')}));
+        output.appendChild(el('pre', {text: text}));
+      });
+    })['catch'](function (err) {
+      output.textContent = 'request failed: ' + err;
+    });
+  }
+
+  function renderTryIt(path, method, op, params) {
+    var output = el('div', {'class': 'response'});
+    var fields = params.map(function (param) {
+      return el('div', {}, [
+        el('label', {text: param.name + ' (' + param['in'] + ')' + (param.required ? ' *' : '')}),
+        paramInput(param)
+      ]);
+    });
+    var consumes = op.consumes || spec.consumes || [];
+    var produces = op.produces || spec.produces || [];
+    if (consumes.length && params.some(function (p) { return p['in'] === 'body' || p['in'] === 'formData'; })) {
+      fields.push(el('div', {}, [el('label', {text: 'Content-Type'}), select('consumes', consumes)]));
+    }
+    if (produces.length) {
+      fields.push(el('div', {}, [el('label', {text: 'Accept'}), select('produces', produces)]));
+    }
+    fields.push(el('button', {type: 'submit', text: 'Try it'}));
+
+    var form = el('form', {}, fields);
+    form.addEventListener('submit', function (event) {
+      event.preventDefault();
+      submit(form, path, method, op, params, output);
+    });
+    return el('div', {}, [el('h4', {text: 'Try it'}), form, output]);
+  }
+
+  function renderOperation(path, pathItem, method, op) {
+    var params = paramsOf(pathItem, op);
+    var body = el('div', {'class': 'body'}, [
+      op.description ? el('p', {'class': 'description', text: op.description}) : null,
+      op.operationId ? el('p', {}, ['operation id ', el('code', {text: op.operationId})]) : null,
+      params.length ? el('h4', {text: 'Parameters'}) : null,
+      renderParams(params),
+      el('h4', {text: 'Responses'}),
+      renderResponses(op),
+      renderTryIt(path, method, op, params)
+    ]);
+    return el('details', {'class': 'operation' + (op.deprecated ? ' deprecated' : '')}, [
+      el('summary', {}, [
+        el('span', {'class': 'method ' + method, text: method}),
+        el('span', {'class': 'path', text: path}),
+        op.summary ? ' ' + op.summary : ''
+      ]),
+      body
+    ]);
+  }
+
+  function renderAuth() {
+    var definitions = spec.securityDefinitions || {};
+    var names = Object.keys(definitions).filter(function (name) {
+      return definitions[name].type === 'basic' || definitions[name].type === 'apiKey';
+    });
+    if (!names.length) {
+      return null;
+    }
+    return el('section', {}, [el('h2', {text: 'Authentication'})].concat(names.map(function (name) {
+      var scheme = definitions[name];
+      var inputs = scheme.type === 'basic' ? ['username', 'password'] : ['key'];
+      credentials[name] = {};
+      return el('div', {}, [
+        el('h4', {text: name + ' (' + scheme.type + (scheme.name ? ' ' + scheme['in'] + ' ' + scheme.name : '') + ')'})
+      ].concat(inputs.map(function (input) {
+        var field = el('input', {type: input === 'password' ? 'password' : 'text', placeholder: input});
+        field.addEventListener('input', function () { credentials[name][input] = field.value; });
+        return el('form', {}, [el('label', {text: input}), field]);
+      })));
+    })));
+  }
+
+  function renderOperations() {
+    var groups = {};
+    var order = [];
+    Object.keys(spec.paths || {}).sort().forEach(function (path) {
+      var pathItem = spec.paths[path];
+      methods.forEach(function (method) {
+        var op = pathItem[method];
+        if (!op) {
+          return;
+        }
+        var tag = (op.tags && op.tags[0]) || 'default';
+        if (!groups[tag]) {
+          groups[tag] = [];
+          order.push(tag);
+        }
+        groups[tag].push(renderOperation(path, pathItem, method, op));
+      });
+    });
+    return order.map(function (tag) {
+      return el('section', {}, [el('h2', {text: tag})].concat(groups[tag]));
+    });
+  }
+
+  function renderModels() {
+    var names = Object.keys(spec.definitions || {}).sort();
+    if (!names.length) {
+      return null;
+    }
+    return el('section', {}, [el('h2', {text: 'Models'})].concat(names.map(function (name) {
+      var schema = spec.definitions[name];
+      return el('details', {'class': 'operation', id: 'model-' + name}, [
+        el('summary', {}, [el('span', {'class': 'path', text: name})]),
+        el('div', {'class': 'body'}, [
+          schema.description ? el('p', {'class': 'description', text: schema.description}) : null,
+          renderSchema(schema),
+          el('h4', {text: 'Example'}),
+          el('pre', {text: pretty(sample(schema))})
+        ])
+      ]);
+    })));
+  }
+
+  var root = document.getElementById('explorer');
+  root.textContent = '';
+  if (spec.info && spec.info.description) {
+    root.appendChild(el('p', {'class': 'description', text: spec.info.description}));
+  }
+  [renderAuth()].concat(renderOperations(), [renderModels()]).forEach(function (section) {
+    if (section) {
+      root.appendChild(section);
+    }
+  });
+})(explorerSpec, explorerOpts);
@@ -0,0 +1,284 @@
+// Code generated by go-bindata.
+// sources:
+// assets/explorer.css
+// assets/explorer.js
+// assets/index.html
+// DO NOT EDIT!
+
+package explorer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+	"os"
+	"time"
+	"io/ioutil"
+	"path/filepath"
+)
+
+func bindataRead(data []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewBuffer(data))
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, gz)
+	clErr := gz.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+	if clErr != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type asset struct {
+	bytes []byte
+	info  os.FileInfo
+}
+
+type bindataFileInfo struct {
+	name string
+	size int64
+	mode os.FileMode
+	modTime time.Time
+}
+
+func (fi bindataFileInfo) Name() string {
+	return fi.name
+}
+func (fi bindataFileInfo) Size() int64 {
+	return fi.size
+}
+func (fi bindataFileInfo) Mode() os.FileMode {
+	return fi.mode
+}
+func (fi bindataFileInfo) ModTime() time.Time {
+	return fi.modTime
+}
+func (fi bindataFileInfo) IsDir() bool {
+	return false
+}
+func (fi bindataFileInfo) Sys() interface{} {
+	return nil
+}
+
+var _explorerCss = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x54\xcb\x6e\xa4\x30\x10\xbc\xcf\x57\xb4\x12\xed\x0d\x46\xcc\x33\x13\x46\x5a\x69\x6f\x7b\xda\x7f\x68\xec\x06\xac\x18\xec\xb5\x4d\x32\xd9\x28\xff\xbe\x36\x18\x06\x26\x89\x14\x21\x21\xb0\xdb\xd5\xd5\xd5\xd5\x2e\x14\x7f\x85\xb7\x15\x40\x83\xa6\x12\x6d\x0e\xd9\xd9\xff\x94\xaa\x75\x69\x89\x8d\x90\xaf\x39\xa4\xa8\xb5\xa4\xd4\xbe\x5a\x47\x4d\x02\x77\xbf\x49\x3e\x93\x13\x0c\xe1\x0f\x75\x74\x97\xc0\x2f\x23\x50\x26\x60\xb1\xb5\xa9\x25\x23\xca\x09\xc2\x8a\x7f\x94\xc3\x66\xaf\x2f\x61\x89\x29\xa9\x4c\x0e\xf7\xdb\xed\x36\xfc\x16\xc8\x9e\x2a\xa3\xba\x96\xfb\xb5\x12\xc3\x73\x5e\xbd\xaf\x6a\x42\x4e\x26\xf1\x84\x44\x1b\x99\x5d\xd2\x17\xc1\x5d\x9d\xc3\xe3\x31\x1b\xa0\x26\xb6\x80\x9d\x53\x61\x45\x23\xe7\xa2\xad\xc2\xd2\xe6\x18\x82\x46\x24\xa8\x37\xb3\x02\xd3\x42\x39\xa7\x9a\x1c\xf6\x43\xcc\xba\x21\x87\x09\xac\x39\x59\x66\x84\x76\x42\x0d\x49\x47\xae\x87\xc3\xa1\x87\xda\xce\x31\x9c\xd2\x39\xec\xb6\x03\x95\x42\x19\x9f\x65\xc2\xdd\xe8\x0b\x58\x25\x05\x87\x7b\xce\x79\x9f\x42\x69\x32\x38\x21\x8f\xd4\x4f\x3e\x30\xbb\x02\x7c\x3c\x39\x41\x1b\xe4\xa2\xb3\x91\xf2\xad\x6e\x65\x79\x93\xe3\x27\xd8\xae\xf1\x49\x86\xb6\x4e\xb2\x9c\x62\x0f\x3a\x63\x43\x61\x5a\x89\xd6\x91\xb9\x39\xbb\x2e\x46\x3b\xcc\xe5\xf4\x85\xf6\xaf\x51\xaf\x5a\xf1\x3e\x86\x0b\xab\x25\x7a\x87\x88\x56\x8a\x96\xd2\x42\x2a\xf6\x14\x92\xc4\x6e\x1d\x23\xe1\xde\x0a\x2f\x24\xaa\xda\xe5\xbe\x26\xd9\xd7\xe6\xe8\xe2\x52\x67\xbc\x67\x4a\x65\xbc\x6e\x9d\xf6\x2c\x18\x5a\x9a\x65\x59\x57\xe4\xe0\xed\xea\x1b\x7c\xc0\x22\x3b\xc3\x75\x5f\x2b\x3b\x0f\xd8\x15\x8f\x6c\x57\x2c\x02\xba\xf9\x3e\x7b\x38\xe1\x06\x17\xfb\xe8\x58\x3d\x8b\x38\xe1\xe1\x26\x05\x27\x49\x8e\xe6\x20\xd9\xae\x88\x49\xfc\xe9\xba\x57\x62\x31\x2f\x8d\x6a\x95\xd5\xc8\xe8\x76\x0c\x0e\x51\x42\x4e\xda\x10\x43\x47\x1c\xae\x10\xbd\x1e\x9c\x98\x1a\x5a\x91\x43\x2f\xa9\xab\x7d\xa3\xab\x3a\x1c\x73\x58\x48\xea\x43\xa3\xbc\x9b\x2c\xfb\x31\x73\x89\xe7\x27\x51\x5b\x9f\x68\xfc\x3a\x7f\x74\x9b\x87\xa9\x13\x70\x7c\xd9\x63\xdf\xa7\xd1\x1f\x5f\xbb\x99\x88\xa6\xbe\xa1\x14\x55\xa0\x48\xa5\x0b\x6b\xcf\x64\xc2\x7d\x20\xc7\x75\x3f\x1f\x7d\xa1\x86\xfe\x76\xc2\x10\x5f\x4c\x54\xd4\xcf\xef\x7b\x15\x16\x13\xb1\x1f\x27\xe2\xd6\xb4\xca\x27\x28\xa5\x7a\xc9\xa7\x69\x5f\xce\xc0\x3e\x3c\x5f\x0d\xcc\xfb\x2a\x18\x0c\x24\x16\x24\x97\xb6\x9d\xfc\x3a\x12\x38\x06\x02\xb0\x9d\xb9\xf6\x93\x9e\x46\x3c\xd1\x7a\x6f\x25\xd0\x7f\x07\x4d\xd0\x10\xc6\x5f\xeb\x2d\xc3\xdc\xe7\xad\xba\x04\x33\xf4\xb5\x4d\x4a\x7f\x27\xdb\x98\x61\xd0\xcb\x5f\x41\x75\x1c\xa7\xc7\xe3\xac\xc6\xa2\xf3\x5d\x6b\x3f\xdc\x54\xa7\xe8\x3b\x43\x56\xab\xd6\x12\xac\xad\x43\xd7\xd9\xab\x75\x97\xc3\xf9\xbe\xfa\x0f\x5e\x76\xf2\x8f\x16\x06\x00\x00")
+
+func explorerCssBytes() ([]byte, error) {
+	return bindataRead(
+		_explorerCss,
+		"explorer.css",
+	)
+}
+
+func explorerCss() (*asset, error) {
+	bytes, err := explorerCssBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "explorer.css", size: 1558, mode: os.FileMode(420), modTime: time.Unix(1476489600, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _explorerJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbd\x1c\xdb\x76\xdb\xc6\xf1\xdd\x5f\x01\x9f\xf6\x04\x64\x4d\x51\x8a\x9b\xf4\x81\xaa\xea\xa6\x4e\x72\x8e\x7b\x89\x7d\xea\xb4\x0f\x65\xf9\x00\x91\x2b\x11\x09\x49\x20\xc0\x42\xb2\xea\x30\xdf\xde\xb9\xec\x65\xf6\x02\x53\x4e\x2f\x7e\x10\x89\xc5\xcc\xec\xec\xec\xdc\x76\x66\xe9\xc9\xcd\x70\x58\xeb\xba\x39\x14\x93\xbe\x55\xeb\x59\xd1\xb4\xba\x9f\x16\xef\x9f\x14\x45\x39\xf4\xaa\xe8\x75\x57\xaf\x75\x79\xf9\x04\x06\xee\xaa\xae\xd8\x2b\xbd\x6d\x36\x7d\x71\x55\x2c\xcb\x5b\xa5\xcb\x59\x51\xb6\x03\x7f\x34\x3d\x7d\x6e\xd4\x4e\x69\x85\xdf\x80\x14\x10\xee\xf1\xeb\x56\x55\x1b\x02\xaa\xf4\x7a\x5b\xae\x2e\x0d\xb5\x75\xa7\x36\xea\xa0\xeb\x6a\x87\x14\xdf\x1f\x69\x9a\xf3\xf3\x42\xed\x8a\xeb\xa1\xde\xc1\x3c\xd5\x01\x1e\xd4\x1e\x80\x66\x85\x56\xef\x74\x51\xc3\xd8\xee\xbe\x7a\xe8\x8b\x5e\xe9\xa2\xea\x79\x74\xdd\x1c\x34\xc0\x14\x7d\x53\xe8\x2d\x70\x0d\x4b\x29\xd6\xd5\xa1\x04\xf8\xc3\x77\x6a\xad\x8b\x7d\xd5\x7d\x3f\xb4\x40\xdd\xad\x57\xed\x26\xba\xba\x9d\x15\x95\xd6\x5d\x3f\x2b\xd6\x5b\x98\xaf\x53\x07\x5e\x3b\xb3\x77\x68\x36\x0a\xf8\xda\x34\xeb\x01\x39\x98\x03\xbb\x95\x56\x5f\x31\x3f\x88\x3d\xbd\x24\xd8\xd7\xd7\x38\xc7\xfc\x7b\xf5\xd0\x4f\x88\x5c\xf1\xe3\x8f\xb0\x9a\xe9\xfc\xa6\xe9\xbe\xaa\xd6\xdb\x89\x17\x32\xc0\xd8\x19\x8a\xa2\xbe\xa1\x81\xe2\xea\xea\xaa\x28\x71\x1d\xa5\x7f\x57\xd0\xec\x73\x1c\x7d\x69\x16\x77\xc5\xbc\x2e\x01\x65\x75\x69\xc0\x8e\xb0\x0e\xd8\xa6\x08\x0b\x44\xf3\x05\x80\xd6\xd7\x83\x56\x38\xc3\x4c\x60\x4e\x1d\x2a\x7d\x1e\xcd\xf3\xc4\x0a\x00\x79\x5f\xae\x72\xbc\x13\x44\xc8\x7d\x34\x64\xa6\xaf\xda\x56\x1d\x36\x2f\xf1\xdd\x44\x3f\xb4\xaa\xb9\x61\xf1\xf2\x42\x51\xa5\x0e\xb7\x65\xf1\x22\x16\xec\xb7\xb0\xd6\x6f\x00\xdf\x52\x5d\x30\xd6\x08\xc3\x9d\xd2\x43\x77\xa0\x09\x71\xe0\xf8\x44\x6e\x6e\xa7\xfa\x66\x77\xa7\x26\xcd\xf5\x77\x72\x43\x7b\x05\xeb\xbb\x2a\x2e\x98\xc2\x3d\x50\x57\x05\xc2\x14\x9f\x7c\x52\xc0\xc7\xfc\x97\x9d\xba\xc1\xef\x04\xf7\xdb\xe2\xd7\xcf\xfd\xd2\x10\x1d\xdf\x5e\x39\xc0\x4b\x21\x07\x78\x9c\xd7\x87\x8d\x7a\xf7\xfa\x66\x52\xfe\xe2\x1c\xf6\xf1\x29\x2c\xf5\x42\x4a\xc6\xf0\x0b\xd8\xe1\x7a\x0a\x1c\x02\xb2\x48\xa2\x1f\xae\x59\x3a\x93\xe7\xd3\x79\xdf\xee\x6a\x3d\x29\x81\xd8\x1c\xcc\x64\x58\x2b\xb1\x15\xb8\x6a\x30\x88\xe6\x7b\xaf\xb0\xf8\x8f\x06\x50\x63\xd5\x1a\x00\xfe\xf6\xd7\x57\x2f\x9b\x7d\xdb\x1c\x48\x5b\x09\x16\x28\xb5\xbb\x0a\x48\x9d\xff\xf4\xe9\x39\x28\xbf\xa1\x6e\xc7\x2e\x68\xec\xa7\xd2\x89\x3c\x10\x33\xec\x18\x7e\x2c\x89\xd4\x0a\xb6\x67\x80\x15\xdf\xd4\x07\xb5\x71\x2b\x9a\x91\xe9\x39\x74\x94\xe3\xb3\x67\xfc\x74\x7c\x92\x4a\x21\xde\xb4\x9b\x6f\xaa\xbd\x9a\xf4\xeb\xad\xda\x57\x76\x5d\x28\x5e\x1e\xa1\x9d\xa1\x6f\x7e\xa3\xfc\xa3\x94\x3f\xb1\x55\x93\xf7\xc1\xbd\xb8\x0a\xf7\xc2\xf0\x20\x71\xbd\xe0\x63\xec\xf9\x4e\x1d\x6e\xf5\x76\x9a\x59\x44\x59\xa6\x6b\x40\x75\x07\x1e\xd2\x25\x3c\x0d\x87\x22\x22\x96\x32\x79\x1d\x90\x01\xeb\x83\x94\xc6\xa5\xa3\x84\xef\x13\x32\x38\x28\x09\x79\xa9\xcd\x91\x25\xb6\xbc\xaa\xeb\xaa\x87\x32\x65\x61\xb9\x2a\x8b\x67\x21\xeb\xf3\x5a\xab\x7d\x3f\x3d\x49\xf2\x06\x4c\x28\x43\x91\x86\xe3\x85\x69\x44\x2a\x24\x09\x70\x35\x96\x64\xdb\x35\xad\xea\x74\xad\x7a\x50\xb3\xb2\x21\x8f\x5a\x82\x8e\x95\x65\x68\xef\x06\x1c\xbc\xd3\xbe\xd2\x00\x8a\x44\x9f\x15\x65\x31\xc1\x25\x84\x2f\x61\x78\x8a\x24\x00\xc4\x6d\x14\x04\x97\xbe\xda\xb7\x60\xf7\x22\xc0\xbc\xe3\x91\xbb\x6a\x37\xa8\x02\x90\x8b\xca\x50\x9a\x15\x2d\x6c\x83\xea\x50\x33\x28\xb0\x18\x50\x44\xdb\x80\x95\xdd\x54\xc3\x4e\xf7\x05\xf8\x37\x1b\x75\xa4\x2e\xf0\x44\x13\x4b\x6a\xa3\x5a\xd0\x23\x23\x2a\x7a\x20\x4b\xc5\x4f\x90\x83\xf1\x49\x46\xd3\xaf\x9c\x07\x4b\xb6\xdf\x28\x12\xe2\x30\xf2\xef\x8a\xdf\xa4\xea\x30\xec\x76\x23\x7b\x67\x57\x8b\x0e\xca\x19\xf0\x98\x71\x18\xe0\x3c\xa9\x65\x69\x24\x50\xae\x1e\x45\x4d\xc0\x8f\x11\x54\x87\x61\x0f\xd4\x9c\x65\xdb\x11\x6b\x85\x63\x84\x19\x6a\x79\x31\x42\x78\x5e\xed\x76\xaf\x6f\x42\x67\xbe\x57\xdd\xad\xda\x98\xb4\xc3\xb8\x2b\x01\x9c\x89\x7f\x6d\xd5\x69\xe9\x6d\x91\x0a\xeb\xcc\x95\xdd\x6b\x04\x31\x3b\x0d\xea\xf7\xa9\xf0\xa3\xc8\x0c\x03\xc3\xe2\x4c\x50\x34\xc8\x68\x47\x46\xe1\x25\xf9\x30\xaf\x20\xd8\xf1\x8c\xc2\x2c\x87\x22\x3c\xb0\x43\xd0\x9c\x28\xb8\x80\x29\x43\x8e\x1f\x33\x82\x64\x74\x29\xbd\xfe\xbe\x86\x5c\x2d\x30\x79\xcf\xdd\xba\x82\xa4\xc3\xf8\x93\x45\x1c\x2b\x96\x81\xe2\xb3\x27\x91\x42\x71\xc9\x0b\x53\xa9\x21\xbb\xb9\x55\x9d\xa3\xc3\xa3\xb0\xa1\xd7\x62\x30\x56\xcc\x3d\x78\xe8\xfd\xb0\x0f\xf5\x0e\xdc\x41\xf4\x7a\x61\xed\xca\x92\xbd\x6e\x9a\x9d\x82\xc4\x30\xa1\xab\xbb\x41\x85\xa0\x26\x4f\xf1\x90\x91\x44\xd8\xcb\x84\x3b\xc6\x98\x1b\x48\x66\x04\x9e\x74\x8c\xcf\x2f\x2e\x7e\x73\x76\xf1\xe9\xd9\xc5\xf3\xf2\x32\x8b\x77\xa6\xeb\xfd\x69\xe4\x6f\x3f\xfd\x7c\x71\xf1\xd9\xe2\xe2\xf3\x7f\x64\xc8\x0c\x43\xbd\x19\xa1\x70\x61\xfe\x9d\x65\xfe\xd8\x7f\x19\x8a\xb0\xdc\x7a\x37\x42\x12\x4e\x09\xdd\xef\x8d\x9f\x98\xaf\x9b\x7d\x80\x6e\x0c\x3e\x8f\x69\x04\x9c\x6a\x67\x82\x85\x96\xc6\x59\x92\x37\xd6\xd0\x3c\xd2\x28\xf2\xb8\x14\xdc\xe4\x5f\xd6\x6e\x42\xcd\xf5\xd4\xe8\x7d\xde\xb0\x8f\x69\xb2\xe4\x72\xbc\x63\x92\x1f\x40\x3c\xd1\xfa\xc1\x58\xb3\xe1\xc2\x60\xfd\xf1\xed\xeb\x6f\xe6\x2c\x93\xfa\xc6\x80\xcc\xc8\x93\xcf\x8a\xe7\xd3\x5c\xba\x04\x7a\xdf\xbd\x25\x56\xa3\x84\x83\x93\x55\x0a\x20\x9b\x34\x96\xb0\x68\x2e\x3d\x64\x73\x4f\x07\xba\x95\x0f\x32\x16\x39\x49\x1e\x10\xf7\xb1\x79\x05\x9c\xb0\xca\x16\x4e\x7d\xef\x21\x2d\x5c\x32\x10\x86\x4a\x18\xc1\x37\x98\xa1\xe2\x4b\x3c\xe1\x2c\x6c\xf2\xe1\xe6\x25\xa7\x21\x26\xe3\x74\xe4\x38\x5d\x4d\xe3\xac\xa2\x53\x3f\x0c\x75\x27\xd7\xb9\x99\xbb\x31\x3a\xcb\xa4\x87\x34\x07\xf8\xd1\xfa\x82\x33\x22\x92\x9c\x2d\x52\x13\xe7\x59\x41\xac\xf3\x76\xe8\xb7\x13\x5c\xae\xee\xac\x24\x9c\xb6\xd0\xf0\xc6\x0e\xa7\x32\x01\x62\xc7\xe9\xcc\x2d\xd0\x25\xb8\xc4\xd0\xef\x20\xa5\x05\x7f\x87\x58\x7d\x0b\x0e\x0d\xb0\xca\xf5\xae\xea\xfb\x12\x72\x26\x8b\x52\xf2\xa1\x19\x46\x8a\x5f\x95\x47\x3c\x51\xa1\x3a\xad\xa6\xb3\x47\xf3\x60\xf6\x05\x97\x48\xd2\xcf\x61\x32\xa4\x15\x2a\xc3\x5a\x71\x6e\x54\xbf\xee\x6a\x2a\x04\xe0\x50\x09\x5c\x18\x0a\xab\xa9\xdd\x4a\x99\xdc\x90\xd0\x46\x82\x7d\xa0\x4f\xa3\xac\x1a\x25\x8f\x55\x45\xd0\xd0\xd5\xf5\x4e\x45\xbb\x41\xe3\xa6\x4a\xe1\xe8\xfb\x3d\xe3\xd7\x7e\xae\xd2\xec\xf9\x43\x89\x3b\x94\xbc\x45\x56\xf2\x6f\x84\x3c\x4a\x64\xd1\x4b\x94\x40\xaf\x9b\xcd\x83\x99\x13\x25\xc1\xb2\x5a\x65\x8c\x1f\x12\x8d\x6a\xdf\xe3\xce\x54\x7a\xfb\x0a\x8c\x03\x2b\x37\xd2\xfc\x19\x40\x78\x4c\x07\x39\xa7\x57\x4a\x2b\xae\x53\xe0\x59\x7f\xdd\x1c\xd6\x95\x9e\x34\x6d\xe6\x5d\x36\x0f\xaa\xf6\x7e\x77\xe8\x51\x78\x19\x7e\x7d\x29\xdf\xf6\x4b\xfa\x58\x42\xac\x87\xec\x0e\xf2\xf2\x05\x66\xeb\x34\x36\xc7\x93\x0b\xba\x5e\x7a\xba\xcc\x1d\xf1\xa5\xe5\x32\xb9\xe9\x7c\x5f\xb5\x69\x0e\x64\xe0\xcd\x94\x32\xfb\xc9\x79\xce\x37\x04\x66\x29\xca\xa3\x1a\x0f\x8d\xa9\x61\x9c\x5b\xff\x97\x35\x0b\xe5\x91\xd7\x9d\xfa\xf0\xbf\xd3\x36\xb3\xe4\x50\xac\xd1\x46\x87\x4b\x4d\xfd\xd9\x69\x6f\xe2\x77\x1c\xd9\xe5\x27\xe7\xab\xff\x73\x67\x96\x3a\x25\xa1\x76\xc7\x11\xc0\x0f\x70\x6a\xf4\x95\x62\x1c\xc9\x0a\xcf\x9b\xc6\x21\x12\xef\x36\xa0\x2e\x82\xe1\xc8\x51\x8e\x70\xf5\x01\xd7\x68\x4d\x9e\x6d\x61\xdc\x09\xb0\x1e\xff\x55\xf5\x6d\x73\xe8\x55\x3f\x09\x3d\x40\x67\xc7\xb1\x66\xd5\xce\xfd\xa3\x08\xff\xff\x65\xdd\x25\x19\x3e\x42\x13\x33\x00\x26\x83\x3c\xa1\xa6\x51\x10\xe7\x05\xc5\xde\x00\xb9\x88\xcf\x69\x16\x58\xf8\x29\x87\xbf\x44\x84\x55\x90\x16\x59\x34\x7b\x58\xbe\x12\x56\x2f\x72\x24\xc2\xb7\x87\xe4\x3e\xcc\x2a\xe9\x8c\x59\x53\x29\x27\xc7\xb5\xc7\x72\xa7\x56\xb3\x64\x37\x65\x02\xba\x44\x7a\x02\xd8\x14\x7f\x03\x66\xe2\x32\x53\x48\xd2\xe4\xb7\x31\x74\x9a\x82\xff\xe7\xb6\x8e\x4f\xe3\x86\x80\x38\x8e\x8b\xc4\x12\x66\x45\xc4\x21\x18\x5e\x90\xee\x26\xcb\xfd\xb0\x33\x20\x0e\x45\xdd\x03\x61\x8d\xbf\x81\x84\x3c\x4c\x21\x20\x43\x75\xf2\x0a\xcb\xd5\x76\x78\x61\xb3\x78\x33\x30\x15\xce\xe8\x23\x2d\xb8\x57\x3b\xd0\x0c\x2a\xe9\xcd\xf8\xd0\xde\x47\xa7\x02\xf2\x89\x04\x85\x6c\x22\xe0\x82\x8a\x7d\x47\x0b\x1f\x29\x7f\x70\xb2\x08\xa8\x70\x3b\x06\xa9\x10\xcc\xa2\x30\x27\x0c\x5e\x3a\x3d\x1c\x5d\x4a\x36\x96\x72\xbc\x3a\xb4\x83\x0e\x03\x83\xa8\x59\x7e\x38\xca\xfb\x34\x2f\xef\x5d\xb3\x5c\x23\x77\x55\xa7\xaa\x64\xf5\x4b\xb3\x09\xbe\xf2\xe2\x1d\x72\x94\xfb\xb9\x29\x4f\x16\x2e\x71\xc6\xfa\xc0\x1d\x2d\x31\xdd\x8c\x34\x63\x61\xb0\x8e\x79\xe2\xb6\x0a\x95\x16\xa9\xe4\x26\x4f\x92\xa8\xb7\xc4\x52\xfa\xb2\x2c\x7d\x1e\x16\x90\xa3\xfd\x7d\x4b\x4a\x38\x9d\x26\xa7\x1f\x6e\x37\x5d\x65\x99\xa5\x8e\xd2\x31\x91\xfa\xc9\xa2\x1d\xd1\x9c\xdb\xca\x16\x4f\x9d\x20\x9f\x14\x70\x7c\x28\x64\xaa\xd4\x69\xd8\x36\x3b\x30\x65\xa0\x0d\x2e\x63\x0f\xc6\xdd\x2b\x44\xd6\x20\x0d\x56\xe9\x72\x24\xbd\xb2\x3b\x43\xa4\x72\xd6\x84\x1d\x93\xbf\x13\x09\x66\xc7\xd8\x88\x4c\xee\x04\x9b\x4f\x3f\x70\x76\x5d\x12\xe2\x2a\xc3\x08\xbd\xb0\xcd\x99\x59\x19\x07\x9f\x3b\x91\x88\xde\xcd\x41\x76\xfb\xc9\x34\x9f\x85\x7e\xd7\xd4\x87\x0c\xb3\x7d\xd8\xb0\x22\xc9\x34\xbc\xc7\xeb\xfe\x0e\xb6\x75\x06\x12\xe8\xe9\x1b\x1e\xa5\x35\x7d\xfb\x27\x8a\xa5\xad\x5b\xd5\xc3\xc3\x8f\x65\x18\xdd\x8d\x9f\xc0\xf9\x26\x9e\x20\xa7\xe4\xa0\x72\x3b\xd4\x4e\x60\xe8\x6b\xae\x9a\xa3\x03\x86\x89\x40\x3d\xf0\xdb\xac\xcc\x70\x5e\xb5\xed\xee\xe1\xa5\xef\xdd\x42\xe2\x31\x2b\x30\x4d\x50\xd8\x49\xfd\x61\x50\xdd\x43\x98\x88\x90\xae\x63\xa7\xcf\xe4\x22\xbd\x5a\x0f\x5d\xad\x1f\xe8\x80\xdf\xaa\x75\x30\x60\x0f\xec\x88\x2a\x5a\x31\x18\xbd\x24\xe8\x97\xe2\x55\x90\xd1\xf8\xc9\x32\x67\x17\xf1\xda\xef\x79\x18\x9f\x3d\x40\x06\x3f\x6c\xbe\x98\x4d\x42\xa7\x43\x8d\x62\xcf\xd2\x92\x8e\x35\x97\xd9\x5a\xb1\xe8\x7a\xc7\x60\xbe\xc0\x4f\xcd\x91\xa7\x91\x37\xf7\x9b\x9a\x8b\xd8\xae\xde\xad\x84\x19\x5e\x57\x7d\xbd\x2e\xb1\xe8\xcc\x8a\x8b\xe5\xba\x78\x11\x85\xdd\xbc\xf9\x17\x83\xde\x36\x5d\xfd\xaf\x8a\x56\x0b\xe8\x7f\x40\xf4\x02\xfd\xf8\xb5\x6e\xaa\x49\x48\xc3\xf9\x78\x33\xde\x42\xc6\x7e\xdf\x74\x1b\x0e\xe2\xd3\x69\x36\x53\x49\x38\xac\xda\xfa\x4f\xea\x41\xb0\x98\x14\xe8\x3c\x9a\x8c\x19\xcc\x73\x54\x32\x77\x4b\x59\x9a\x79\xec\xf1\xd2\x91\x96\xb9\x56\xd2\x3d\xc7\x7f\xa4\xbf\xa6\x70\x73\x48\xfa\xa8\x82\xec\x14\xd7\x7f\x85\xeb\xcf\xc0\xf9\xa5\x4c\x83\x19\x47\x4b\xf1\x39\x17\xd1\x0f\xd7\x7b\x70\x33\x58\x69\xc6\xe3\x92\xde\xce\xcc\x05\x0c\x3c\xed\xdb\x43\x1b\x7c\x1f\x34\x38\x46\x69\x72\x43\xb7\xa3\x70\xac\xb7\xde\x96\x68\x5d\xa2\xce\x87\x63\x46\x58\xa2\x4c\x80\xa3\x38\xdf\x1b\x5b\x3f\x90\xe0\x18\xa6\xe9\x8e\x86\x3d\xd7\x9f\xae\x0e\x10\xbd\x5a\x61\xf3\x9f\xe8\xce\xcd\x75\x8e\x93\x25\x01\xd9\x5e\x4f\xf2\x05\xa4\xf4\x65\xa5\xab\x60\xf7\x3d\xdb\xbc\x7b\xef\x09\xcd\x1c\xb7\x66\xcc\xc5\x82\x3f\xd2\xa2\x6d\xdc\x94\x97\x06\x4b\x28\x1c\x10\x25\x57\xa2\x7d\x53\xa6\xa7\xe4\x98\xa0\xed\x1e\x88\xb5\x48\x24\x2e\xb4\xe3\x8e\x05\x75\x76\xde\x48\xf8\xeb\xba\xf4\xe5\xfb\x50\x50\x28\xbb\x23\xd6\x54\x47\x74\x30\xd4\xbf\x6b\x48\xa5\xbe\xbf\x8c\x66\x25\xcd\x08\xa6\x75\xab\x67\xb7\x3b\x12\x56\x2f\x23\x1b\x1d\x89\x28\x24\xa1\x3d\x64\x0d\x75\x62\xac\x26\x34\xa5\x5a\x74\x17\x43\x9e\xb2\x4b\x2f\x90\x0f\x9b\x65\x28\x8e\xb0\x7c\xff\xb3\x1c\xc2\x23\x27\x1e\x8d\xf6\x63\xfe\x61\x64\xaf\x8c\xd7\x5b\xa4\xce\x7b\x19\xd6\xd3\xd2\x09\x47\xf7\xf1\xb4\x86\x50\x7e\x2e\xe7\xc4\x01\xeb\x55\x47\xb1\xfd\x85\x1d\xe7\x41\x20\xc3\xed\x87\x3d\xa9\x55\xe0\x0d\xe6\xee\xc5\x8b\x91\x17\x26\x21\x5d\xb8\x1b\x13\xa8\x71\xc2\xe4\xe3\x6a\x1d\x5d\x4f\x72\xb3\x39\x15\xc4\x0e\xed\x39\xa2\x9d\x6d\xd0\x7f\x60\xa8\x12\x44\xfa\x66\x2f\xaf\xd9\xb4\xb2\xaa\x38\xcf\x1e\x21\x30\xb7\x93\xba\x6a\x04\x73\x50\xf7\xc5\xd7\xc6\x49\x4d\x84\x7c\xc5\x5c\x19\xd7\x99\x86\xbd\x91\x59\x63\xeb\x60\x50\xf6\x53\x08\x90\x8a\x43\xf2\x67\x2e\x68\x39\xe2\x7c\x6a\x08\xf0\x97\x17\xab\xd8\x50\x52\x33\x91\xb3\xf2\xee\x3c\x4d\x9c\xe1\xe3\xe6\x4c\xfd\xc9\x31\xdb\x60\x13\xea\x53\x96\xa3\x77\xe0\xcc\x1e\x48\x59\xd7\x3b\xad\xba\xd1\x9d\x4d\x97\x80\xdb\x1a\x57\x43\x73\x79\x58\xce\xd4\xe5\x12\x3f\xe8\x13\xc6\x56\x0f\x53\x53\xb2\x5e\x7e\x52\x8e\xac\x1d\x33\xf0\x7a\x4d\x59\xda\xf9\xbb\xb3\xfb\xfb\xfb\x33\x52\x69\x88\x13\x3c\xcd\xa6\x8c\x6c\xd0\x59\x0c\xc9\x26\xec\x9a\x43\xe2\x65\x69\xfb\x25\x5a\xa7\x52\x9a\xdb\x87\x67\xdf\x62\x95\x19\x1d\x8b\x85\x8d\x0f\x80\xa1\xd9\xb6\x5d\x83\x77\xd6\x52\x8a\xf3\x2f\xd6\x6b\xd5\xea\xc4\x01\x58\x04\x19\x67\x99\xf8\x63\x8e\x1b\xc2\xc5\x5c\x57\x54\xe6\xc3\x8b\xac\x73\xfc\xfe\xa6\xc2\x9b\x36\xb8\xab\xe7\x58\xc5\x29\xf1\x4a\x50\xf0\xd2\xe7\x37\xba\xea\x6e\x15\xb2\x46\x34\x9e\x51\xf4\x85\xec\x96\x03\x00\x5b\x14\x52\x78\x81\xfb\xc9\x83\x6e\x9f\xe4\x55\x25\xce\xc7\xa2\xbb\x9b\xe5\xae\xa9\x36\x78\x8f\x08\x91\x39\x8b\x9b\xeb\xe6\x6f\x60\x15\xdd\x4b\x98\x6d\x42\x9a\x42\x2f\x99\x0b\xb3\xa2\x1b\x05\x59\xc3\x84\x87\x66\xc5\x7b\x46\x5c\x64\x09\x38\xa1\x2c\xbc\x74\x70\xbb\x17\xf4\x77\x26\xcf\x1d\xc0\x6c\x0f\xda\x79\x06\xb9\xfe\x2d\xb5\x15\xe6\x7a\xab\x0e\xc1\x39\xa9\x4f\xce\xc4\x30\x46\x6b\x9a\x24\xd0\x38\x1a\x9f\x8a\x76\xa0\x5b\x32\x7f\x64\x42\xfd\xdc\xaa\x41\x26\xe6\x73\x3d\xca\x34\x73\x08\x9f\xa3\x2e\xde\x91\xc5\x0c\x91\xa4\x63\x8c\x25\x70\x0b\x1a\x12\x5b\x69\x9b\x74\x27\xf8\xca\x96\xe9\xa8\xb1\x0e\x26\x09\x32\x22\x46\x83\x73\xc9\xba\xa2\xac\x2c\x3a\x0f\x9d\x9f\x17\x87\x46\x17\xdf\xf5\xcd\x01\xe2\xe6\xb6\xb9\x2f\x6a\xba\x6c\x5c\xf7\x99\x73\x57\x7e\xbb\xc5\xbd\x06\x03\x20\xaf\xc5\xba\x5e\xa6\xef\x73\xf4\xba\xd2\x43\xef\xba\x1c\x8f\x50\x11\xf7\x88\x72\x65\xf4\xcc\x10\x5e\xab\x3d\xca\x45\x8f\xb1\x23\x2b\xa1\x2c\x7d\xd6\xee\x7f\x1e\xca\xe9\x47\x13\xd0\xe1\xac\xfe\x94\xb3\x2c\xd7\x7c\x09\x5c\xec\xbc\xea\x3a\x2f\xff\xbc\x38\xf1\x5c\xae\x7a\x5d\xdc\x54\x10\x9e\x36\xac\x0a\x80\x36\x7e\x76\xe2\x7a\xf1\xb7\xdd\xc3\x2b\x3d\x19\x3b\x3a\xc9\x23\x13\x4f\x0b\x53\xe1\x52\x36\xf5\x5d\xdc\x84\xe2\x82\xb3\x2b\xfe\xb9\x73\x4d\x6f\xeb\x9e\x27\xba\x67\xa2\x8e\x65\xa8\x27\x97\x01\x76\xd5\xb5\xda\xe5\xba\x65\xfe\x86\x63\x74\x68\x9a\xd2\xe9\x3b\x29\x29\x62\x87\x8c\x1d\x92\xec\x7a\x25\x05\xdc\x27\x61\xa9\x5a\x2e\x4d\x04\x9b\xa6\xf5\xc9\x99\xad\xd7\xc8\x01\x79\x40\xb4\x4e\x9c\xd1\xdc\x93\x45\x93\x03\xf2\xba\x89\xcb\xf1\x8c\x8f\x85\x88\xd4\x9e\x4e\xc8\xd2\xf6\x1c\x90\x6d\xb3\xc7\xc4\x30\x4b\xe3\x7d\xf3\x77\x33\xc4\x7e\x64\xb6\x21\x8c\x80\xd8\xbc\x32\x05\xdd\xd2\xb2\x0d\xb0\x2e\x80\xae\xa6\x69\x65\xd4\x46\xb6\x38\x27\xfb\x48\x3e\x38\x70\x06\x1c\x58\xd2\x58\xfd\xb3\x01\x37\xe2\x20\x9e\xe4\x7a\xd0\x9a\xdb\x00\xa6\x4c\xcc\x45\x06\xdf\x5d\x05\x93\x01\x6f\x57\x92\xf1\x06\x25\x01\x63\x1b\xf8\xd5\xb0\xc9\xb4\xcd\x74\x14\xce\xab\xcd\xe6\xab\x3b\x10\xd6\x9f\xeb\x1e\x64\x06\x29\x97\x27\x2f\xcc\xfd\x2e\x28\xbf\xd1\x23\xe8\x06\x7d\x7e\xc9\x05\x66\x9f\x33\x7f\x5c\x0d\x24\x7b\x81\x20\x23\xdb\xed\x67\x42\xb0\x6e\xc5\xb3\x82\xa7\x61\x62\xe3\x2d\xd7\xd7\xe0\x91\x29\xf9\x32\x9e\xc5\x5f\xc2\xf0\xac\x65\x2f\x63\xe4\xaf\x6d\x84\x05\x96\x31\x17\x44\x2a\x2e\x7c\x06\x18\x98\x6c\x9c\xbd\x28\x32\x31\x45\xb6\x5d\xed\x06\x87\x78\xae\x77\x35\xf3\x64\x1b\xbb\xbc\x57\x1b\x49\x96\xae\x71\xb9\x77\x45\xbd\xc9\x5f\xe5\x0a\x09\x60\x0b\x30\x9a\x21\xb8\x5e\x61\x26\x08\xf6\xe3\x8d\xbb\x84\x52\x26\xec\xe5\xee\x6e\xc8\x6e\x71\x40\xc8\xb5\xc6\x4b\xef\x07\x33\x4d\xf3\xf0\xd5\x89\x90\xf1\x44\xba\x4c\xa9\x60\x4a\x43\x68\xea\x43\xf9\x3b\x41\x90\x9f\x26\xc9\x83\x9e\xaf\xa9\xd7\x81\x6e\xda\x3f\x5a\x7f\x1d\x36\xdf\xc1\xa7\xec\xab\xee\x21\x1b\x2d\xd2\x9b\x12\xcc\xad\x48\x30\xc3\x5c\xe2\x18\xdd\xde\x4a\x09\x50\xf9\xc9\x22\xe1\x83\x44\xc1\xba\x3d\xb3\x43\xac\xe3\x2c\x62\x08\xb9\x77\xf1\xc4\x22\xa1\xca\x9e\xb8\xbd\x80\x15\xe7\x89\xb4\x95\x8f\x2f\xf9\xdb\x06\x64\x1f\xb5\xda\x05\xa5\x69\x7a\x02\xcc\xfe\x8c\x22\xa9\xe0\xa7\x95\x74\xba\x77\x3f\x0e\x65\xaa\xd9\x99\xab\x6d\xc4\xe2\xcf\xb8\x54\xd4\x73\x31\x2d\xf0\x5e\xcf\x65\x58\x18\x30\x1b\xd7\xe6\x38\x88\x17\x28\x6c\x1f\x91\x67\x0c\xb3\x92\x70\xd9\x8f\x6b\x5f\x20\x14\xf5\xde\x7a\xfb\xfb\x8d\x4c\x87\xe1\x05\x38\x07\xdb\x18\xe0\x9f\xfa\x71\x33\xa0\xe4\xf6\x26\xec\x48\xe9\x2f\x7e\xc7\x1d\x90\xe0\x66\xef\x23\x32\x25\x69\xe5\x61\x82\x24\xd9\x7b\x56\xc8\x72\xbd\xd3\xda\xa0\x95\xf0\x2c\x18\x64\x40\xb2\x44\x4e\xaf\xfc\x75\x45\x2b\x54\x16\x44\x24\x55\x1a\x8c\x4f\x40\xb6\xec\x1d\x34\x95\x39\xe8\xd2\x23\x8b\xcf\xc9\x09\xf9\xf3\x0f\xb6\x7f\x0b\x7e\xc7\xb7\x4c\x0d\xa2\x3c\xfa\x70\x05\x21\x0d\xbb\x76\x42\xcf\x23\xa6\x4e\x89\xe0\x97\x04\xb7\x8a\x8a\xdc\xb9\x4b\xcd\x51\xe8\xcf\x65\x28\x86\x39\x93\x17\x04\x37\x20\xfc\xc5\x82\xd3\x41\xb5\x0f\xfc\xc1\x6d\xd7\x0c\x6d\xdc\xa1\x00\x11\x51\x03\x39\x77\xb9\x97\x33\x4d\x70\x5e\xee\x4e\x6f\xdf\x74\x78\x68\xcd\xf5\x2a\xa4\x29\x72\xa4\xe6\xb8\x6c\x5d\x0f\xd1\x59\xe2\x5f\xa7\xbc\xe6\x27\xb0\x19\x72\xfc\x26\x56\x03\xba\x2c\x6c\xe9\x2e\x19\x26\x6e\xf9\x35\xed\x63\x9b\x7c\x5c\xa6\xb8\x05\x92\x18\x4e\xe0\x5b\x4f\x3f\x5e\xe4\xaf\x58\xb5\xa3\xe6\x9b\x6d\xd3\x47\xd3\xb0\x2c\x97\x00\xba\x0a\xe7\x13\x2f\xa2\x23\x7b\xc1\xb2\xe6\xfc\xd1\xff\xe4\x35\x64\x4a\xa0\x33\xe0\x47\xa4\x49\x99\x23\x62\x10\x5b\x79\xfa\xd0\xdc\x90\x8f\xdc\xc1\xea\x84\xab\x04\x34\xe1\x1d\xa5\x30\xa6\xa7\xce\x91\x7f\x81\x14\x67\x17\x2a\x66\x2e\xe4\x90\xce\x6c\xe2\x30\x65\x35\xf0\xff\x10\x0c\x98\xcf\x9f\x19\x04\x2a\xab\xf5\xa3\x91\xe0\xf1\xf9\xce\x0c\xf2\x43\x4c\x49\x90\x9f\x33\x74\xb0\xf6\xda\x4e\x98\x82\x84\xf9\xcd\x23\xb2\x12\xbe\x3c\x1a\x5f\x44\x3f\x99\x2c\x8b\x9f\x77\xfd\xac\xa4\x39\xc5\x4d\x32\x53\x9f\x41\x86\xbf\xc9\x88\xaf\xa4\x05\x19\xea\x57\xee\xda\x63\x0c\x16\x54\x53\xc2\xbb\x4e\xf6\x96\x53\x70\x61\x34\x39\xcc\x0b\x4f\xcb\xbf\xf2\x68\xb4\xfc\x31\xfb\xad\xd2\xe6\x97\xec\x7f\x78\x78\xb5\x99\x94\xea\x5d\xbb\x6b\x3a\x6c\xa8\x23\x1a\x42\x67\x2b\x5a\xd4\x8b\x47\x1d\xa9\x0f\x37\x0d\xfd\x50\xcf\x3e\x48\xe1\xb8\x4b\x6c\x48\xe6\x44\xdd\x2b\x2b\xee\x1c\x51\x77\x2b\xad\xc0\xbb\x83\x3e\x79\x74\xaa\x9e\xc6\x91\x99\x85\xb4\xf6\x9b\xbd\xd0\x6e\x8c\x2a\xf8\xc5\x71\x38\x94\x59\x89\x85\x10\xbf\xf4\x81\xef\xc7\xe9\xc4\x0a\xf2\x2d\xfd\x7f\x0a\xf6\xe9\x35\xfe\xbf\x0a\x97\x4f\xfe\x0d\x47\x63\x8a\x6a\x70\x41\x00\x00")
+
+func explorerJsBytes() ([]byte, error) {
+	return bindataRead(
+		_explorerJs,
+		"explorer.js",
+	)
+}
+
+func explorerJs() (*asset, error) {
+	bytes, err := explorerJsBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "explorer.js", size: 16752, mode: os.FileMode(420), modTime: time.Unix(1476489600, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _indexHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7d\x52\xcb\x4e\xc3\x30\x10\xbc\xf7\x2b\x16\x1f\xb8\x51\x8b\x1b\xa2\x8e\xa5\xf2\x38\x20\x21\xb5\x82\x82\xc4\xd1\x4d\xb6\x4a\x8a\xf3\x90\x77\xa9\xa8\x2a\xff\x3b\x76\x9c\x36\x45\x48\x9c\xb2\xb3\x9e\x9d\x19\xed\x46\x5d\x3c\x2c\xee\x57\x1f\xcb\x47\x28\xb9\xb6\x7a\xa2\x8e\x1f\x34\x85\x9e\x00\xa8\x1a\xd9\x40\x5e\x1a\x47\xc8\x99\xf8\xe2\xcd\xd5\x8d\xe8\x1f\xb8\x62\x8b\xfa\x70\x98\xae\x62\xe1\xbd\x92\xa9\x13\xdf\x6c\xd5\x7c\x82\x43\x9b\x09\xe2\xbd\x45\x2a\x11\x59\x40\xe9\x70\x93\x89\x30\x31\xa7\x20\x46\x4b\xc3\xa5\xf7\x12\xbf\x3b\xdb\x3a\x74\xd3\x9c\x28\x28\x2b\x99\xac\xd5\xba\x2d\xf6\xbd\x58\xc4\xe8\x62\x19\xc1\xf5\x2f\xcb\x00\x53\xbf\x83\xdc\x1a\xa2\x4c\xc4\xbc\x42\xef\xd0\x51\xd5\x36\x10\xb8\xef\xa9\xf4\x1e\x2e\xeb\xaa\x28\x5a\x9e\x81\x32\x63\x96\xd7\x0e\xf3\xb7\x97\x67\xef\x85\x3e\x47\x4a\x1a\xad\x64\xd7\x07\x90\x63\x02\x55\x9b\xaa\x81\xaa\xc8\xc4\x31\xb6\x18\x02\x34\x2d\xe5\xae\xea\x58\xaf\x4a\x84\xf9\xf2\x09\x8e\x04\x68\x10\x0b\x82\xad\xd9\x99\xc4\x00\x6e\xc3\x6e\x9a\xa0\x08\x1c\xb8\x14\x2c\xa7\x4a\x9e\xe6\x7b\xc7\x68\xd3\x57\x63\x13\x60\x67\xdc\x49\x35\x06\x85\x0c\x86\xc8\xde\xcf\xfe\x30\x16\x1d\x53\x64\xac\x0d\x61\x5c\xf5\x6d\x24\xdf\x0d\xc0\xa7\x09\x25\xcf\x4c\x87\x74\xe4\xf2\x7f\x8e\xb4\x0d\x37\x1a\xa7\x94\x4c\x57\x0a\x2b\xea\x7f\x9b\x1f\xde\x08\x5c\x72\x4e\x02\x00\x00")
+
+func indexHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_indexHtml,
+		"index.html",
+	)
+}
+
+func indexHtml() (*asset, error) {
+	bytes, err := indexHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "index.html", size: 590, mode: os.FileMode(420), modTime: time.Unix(1476489600, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+// Asset loads and returns the asset for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func Asset(name string) ([]byte, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("Asset %s can't read by error: %v", name, err)
+		}
+		return a.bytes, nil
+	}
+	return nil, fmt.Errorf("Asset %s not found", name)
+}
+
+// MustAsset is like Asset but panics when Asset would return an error.
+// It simplifies safe initialization of global variables.
+func MustAsset(name string) []byte {
+	a, err := Asset(name)
+	if (err != nil) {
+		panic("asset: Asset(" + name + "): " + err.Error())
+	}
+
+	return a
+}
+
+// AssetInfo loads and returns the asset info for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func AssetInfo(name string) (os.FileInfo, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("AssetInfo %s can't read by error: %v", name, err)
+		}
+		return a.info, nil
+	}
+	return nil, fmt.Errorf("AssetInfo %s not found", name)
+}
+
+// AssetNames returns the names of the assets.
+func AssetNames() []string {
+	names := make([]string, 0, len(_bindata))
+	for name := range _bindata {
+		names = append(names, name)
+	}
+	return names
+}
+
+// _bindata is a table, holding each asset generator, mapped to its name.
+var _bindata = map[string]func() (*asset, error){
+	"explorer.css": explorerCss,
+	"explorer.js": explorerJs,
+	"index.html": indexHtml,
+}
+
+// AssetDir returns the file names below a certain
+// directory embedded in the file by go-bindata.
+// For example if you run go-bindata on data/... and data contains the
+// following hierarchy:
+//     data/
+//       foo.txt
+//       img/
+//         a.png
+//         b.png
+// then AssetDir("data") would return []string{"foo.txt", "img"}
+// AssetDir("data/img") would return []string{"a.png", "b.png"}
+// AssetDir("foo.txt") and AssetDir("notexist") would return an error
+// AssetDir("") will return []string{"data"}.
+func AssetDir(name string) ([]string, error) {
+	node := _bintree
+	if len(name) != 0 {
+		cannonicalName := strings.Replace(name, "\\", "/", -1)
+		pathList := strings.Split(cannonicalName, "/")
+		for _, p := range pathList {
+			node = node.Children[p]
+			if node == nil {
+				return nil, fmt.Errorf("Asset %s not found", name)
+			}
+		}
+	}
+	if node.Func != nil {
+		return nil, fmt.Errorf("Asset %s not found", name)
+	}
+	rv := make([]string, 0, len(node.Children))
+	for childName := range node.Children {
+		rv = append(rv, childName)
+	}
+	return rv, nil
+}
+
+type bintree struct {
+	Func func() (*asset, error)
+	Children map[string]*bintree
+}
+var _bintree = &bintree{nil, map[string]*bintree{
+	"explorer.css": &bintree{explorerCss, map[string]*bintree{
+	}},
+	"explorer.js": &bintree{explorerJs, map[string]*bintree{
+	}},
+	"index.html": &bintree{indexHtml, map[string]*bintree{
+	}},
+}}
+
+// RestoreAsset restores an asset under the given directory
+func RestoreAsset(dir, name string) error {
+        data, err := Asset(name)
+        if err != nil {
+                return err
+        }
+        info, err := AssetInfo(name)
+        if err != nil {
+                return err
+        }
+        err = os.MkdirAll(_filePath(dir, filepath.Dir(name)), os.FileMode(0755))
+        if err != nil {
+                return err
+        }
+        err = ioutil.WriteFile(_filePath(dir, name), data, info.Mode())
+        if err != nil {
+                return err
+        }
+        err = os.Chtimes(_filePath(dir, name), info.ModTime(), info.ModTime())
+        if err != nil {
+                return err
+        }
+        return nil
+}
+
+// RestoreAssets restores an asset under the given directory recursively
+func RestoreAssets(dir, name string) error {
+        children, err := AssetDir(name)
+        // File
+        if err != nil {
+                return RestoreAsset(dir, name)
+        }
+        // Dir
+        for _, child := range children {
+                err = RestoreAssets(dir, filepath.Join(name, child))
+                if err != nil {
+                        return err
+                }
+        }
+        return nil
+}
+
+func _filePath(dir, name string) string {
+        cannonicalName := strings.Replace(name, "\\", "/", -1)
+        return filepath.Join(append([]string{dir}, strings.Split(cannonicalName, "/")...)...)
+}
+
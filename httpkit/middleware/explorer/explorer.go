@@ -0,0 +1,7 @@
+// Package explorer contains the assets of the API explorer that the middleware can serve for a spec.
+//
+// The page is an html/template, it renders the spec it's given with the scripts and styles
+// in this package, which are embedded with go-bindata so a server doesn't depend on any files.
+package explorer
+
+//go:generate go-bindata -pkg=explorer -prefix=./assets ./assets/...
@@ -0,0 +1,224 @@
+package middleware
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/vikstrous/go-swagger/errors"
+	"github.com/vikstrous/go-swagger/httpkit"
+	"github.com/vikstrous/go-swagger/spec"
+)
+
+// The vendor extensions that set request limits, on the spec for the whole API or on an operation
+const (
+	ExtMaxBodySize        = "x-max-body-size"
+	ExtMaxMultipartMemory = "x-max-multipart-memory"
+	ExtMaxFormFields      = "x-max-form-fields"
+	ExtMaxFiles           = "x-max-files"
+)
+
+// RequestLimits the limits for reading a request, a zero value means there is no limit.
+// Sizes in the vendor extensions are a number of bytes or a number with a KB, MB or GB suffix.
+type RequestLimits struct {
+	// MaxBodySize the maximum size of the request body in bytes
+	MaxBodySize int64
+	// MaxMultipartMemory the bytes of a multipart form that are kept in memory,
+	// the files that don't fit are streamed to temporary files. Defaults to 32MB
+	MaxMultipartMemory int64
+	// MaxFormFields the maximum number of form fields
+	MaxFormFields int
+	// MaxFiles the maximum number of files in a multipart form
+	MaxFiles int
+}
+
+// WithDefaults fills the limits that aren't set from the defaults
+func (l RequestLimits) WithDefaults(defaults RequestLimits) RequestLimits {
+	if l.MaxBodySize == 0 {
+		l.MaxBodySize = defaults.MaxBodySize
+	}
+	if l.MaxMultipartMemory == 0 {
+		l.MaxMultipartMemory = defaults.MaxMultipartMemory
+	}
+	if l.MaxFormFields == 0 {
+		l.MaxFormFields = defaults.MaxFormFields
+	}
+	if l.MaxFiles == 0 {
+		l.MaxFiles = defaults.MaxFiles
+	}
+	return l
+}
+
+// WithRequestLimits sets the limits for the operations that don't set them in the spec
+func (c *Context) WithRequestLimits(limits RequestLimits) *Context {
+	c.limits = limits
+	return c
+}
+
+// limitsFromExtensions reads the request limits from vendor extensions,
+// invalid values are ignored
+func limitsFromExtensions(ext spec.Extensions) RequestLimits {
+	var limits RequestLimits
+	if v, ok := extensionSize(ext, ExtMaxBodySize); ok {
+		limits.MaxBodySize = v
+	}
+	if v, ok := extensionSize(ext, ExtMaxMultipartMemory); ok {
+		limits.MaxMultipartMemory = v
+	}
+	if v, ok := extensionSize(ext, ExtMaxFormFields); ok {
+		limits.MaxFormFields = int(v)
+	}
+	if v, ok := extensionSize(ext, ExtMaxFiles); ok {
+		limits.MaxFiles = int(v)
+	}
+	return limits
+}
+
+// specLimits reads the request limits from the vendor extensions at the root of the spec
+func specLimits(doc *spec.Document) RequestLimits {
+	var raw map[string]interface{}
+	if doc == nil || json.Unmarshal(doc.Raw(), &raw) != nil {
+		return RequestLimits{}
+	}
+	ext := make(spec.Extensions)
+	for k, v := range raw {
+		if strings.HasPrefix(strings.ToLower(k), "x-") {
+			ext.Add(k, v)
+		}
+	}
+	return limitsFromExtensions(ext)
+}
+
+func extensionSize(ext spec.Extensions, key string) (int64, bool) {
+	v, ok := ext[key]
+	if !ok {
+		return 0, false
+	}
+	switch tv := v.(type) {
+	case float64:
+		return int64(tv), tv > 0
+	case int:
+		return int64(tv), tv > 0
+	case int64:
+		return tv, tv > 0
+	case string:
+		return parseSize(tv)
+	}
+	return 0, false
+}
+
+func parseSize(str string) (int64, bool) {
+	str = strings.ToUpper(strings.TrimSpace(str))
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{{"KB", 1 << 10}, {"MB", 1 << 20}, {"GB", 1 << 30}, {"B", 1}} {
+		if strings.HasSuffix(str, unit.suffix) {
+			str = strings.TrimSpace(strings.TrimSuffix(str, unit.suffix))
+			multiplier = unit.size
+			break
+		}
+	}
+	v, err := strconv.ParseInt(str, 10, 64)
+	if err != nil || v <= 0 {
+		return 0, false
+	}
+	return v * multiplier, true
+}
+
+var errBodyTooLarge = stderrors.New("request body too large")
+
+// limitedBody fails the reads once more than max bytes were read
+// and remembers it did, so the error can be told apart from other read errors
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	exceeded  bool
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.exceeded {
+		return 0, errBodyTooLarge
+	}
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.ReadCloser.Read(p)
+	if int64(n) > l.remaining {
+		l.exceeded = true
+		return int(l.remaining), errBodyTooLarge
+	}
+	l.remaining -= int64(n)
+	return n, err
+}
+
+// applyLimits enforces the limits on a request before its parameters are bound.
+// The body is wrapped so it can't be read past the maximum size and
+// multipart forms are parsed here, with the configured memory and counted.
+// The returned body reports when the maximum size was exceeded while binding.
+func applyLimits(request *http.Request, limits RequestLimits) (*limitedBody, error) {
+	if limits == (RequestLimits{}) {
+		return nil, nil
+	}
+	if limits.MaxBodySize > 0 && request.ContentLength > limits.MaxBodySize {
+		return nil, errors.BodyTooLarge(limits.MaxBodySize)
+	}
+
+	var body *limitedBody
+	if limits.MaxBodySize > 0 && request.Body != nil {
+		body = &limitedBody{ReadCloser: request.Body, remaining: limits.MaxBodySize}
+		request.Body = body
+	}
+
+	if !httpkit.CanHaveBody(request.Method) {
+		return body, nil
+	}
+	mt, _, err := httpkit.ContentType(request.Header)
+	if err != nil {
+		return body, nil
+	}
+	if mt == "application/x-www-form-urlencoded" && limits.MaxFormFields > 0 {
+		if err := request.ParseForm(); err != nil {
+			if body != nil && body.exceeded {
+				return body, errors.BodyTooLarge(limits.MaxBodySize)
+			}
+			return body, errors.NewParseError("", "formData", "", err)
+		}
+		if len(request.PostForm) > limits.MaxFormFields {
+			return body, errors.TooManyFormFields(limits.MaxFormFields)
+		}
+		return body, nil
+	}
+	if mt != "multipart/form-data" {
+		return body, nil
+	}
+
+	memory := limits.MaxMultipartMemory
+	if memory <= 0 {
+		memory = defaultMaxMemory
+	}
+	if err := request.ParseMultipartForm(memory); err != nil {
+		if body != nil && body.exceeded {
+			return body, errors.BodyTooLarge(limits.MaxBodySize)
+		}
+		return body, errors.NewParseError("", "formData", "", err)
+	}
+
+	if limits.MaxFormFields > 0 && len(request.MultipartForm.Value) > limits.MaxFormFields {
+		return body, errors.TooManyFormFields(limits.MaxFormFields)
+	}
+	if limits.MaxFiles > 0 {
+		var files int
+		for _, fh := range request.MultipartForm.File {
+			files += len(fh)
+		}
+		if files > limits.MaxFiles {
+			return body, errors.TooManyFiles(limits.MaxFiles)
+		}
+	}
+	return body, nil
+}
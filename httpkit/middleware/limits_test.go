@@ -0,0 +1,176 @@
+package middleware
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vikstrous/go-swagger/errors"
+	"github.com/vikstrous/go-swagger/httpkit"
+	"github.com/vikstrous/go-swagger/internal/testing/petstore"
+	"github.com/vikstrous/go-swagger/spec"
+)
+
+func TestLimitsFromExtensions(t *testing.T) {
+	ext := spec.Extensions{}
+	ext.Add(ExtMaxBodySize, "2MB")
+	ext.Add(ExtMaxMultipartMemory, float64(1024))
+	ext.Add(ExtMaxFormFields, float64(10))
+	ext.Add(ExtMaxFiles, "many")
+
+	limits := limitsFromExtensions(ext)
+	assert.EqualValues(t, 2<<20, limits.MaxBodySize)
+	assert.EqualValues(t, 1024, limits.MaxMultipartMemory)
+	assert.Equal(t, 10, limits.MaxFormFields)
+	assert.Equal(t, 0, limits.MaxFiles)
+
+	limits = limits.WithDefaults(RequestLimits{MaxBodySize: 1, MaxFiles: 3})
+	assert.EqualValues(t, 2<<20, limits.MaxBodySize)
+	assert.Equal(t, 3, limits.MaxFiles)
+}
+
+func TestParseSize(t *testing.T) {
+	for _, tc := range []struct {
+		str  string
+		size int64
+		ok   bool
+	}{
+		{"100", 100, true},
+		{"100B", 100, true},
+		{"1kb", 1024, true},
+		{" 5 MB ", 5 << 20, true},
+		{"1GB", 1 << 30, true},
+		{"-1", 0, false},
+		{"lots", 0, false},
+	} {
+		size, ok := parseSize(tc.str)
+		assert.Equal(t, tc.ok, ok, tc.str)
+		assert.Equal(t, tc.size, size, tc.str)
+	}
+}
+
+func TestLimitedBody(t *testing.T) {
+	body := &limitedBody{ReadCloser: ioutil.NopCloser(strings.NewReader("0123456789")), remaining: 10}
+	b, err := ioutil.ReadAll(body)
+	assert.NoError(t, err)
+	assert.Equal(t, "0123456789", string(b))
+	assert.False(t, body.exceeded)
+
+	body = &limitedBody{ReadCloser: ioutil.NopCloser(strings.NewReader("0123456789")), remaining: 5}
+	b, err = ioutil.ReadAll(body)
+	assert.Equal(t, errBodyTooLarge, err)
+	assert.Equal(t, "01234", string(b))
+	assert.True(t, body.exceeded)
+}
+
+func TestApplyLimits_Multipart(t *testing.T) {
+	newRequest := func() *http.Request {
+		buf := bytes.NewBuffer(nil)
+		writer := multipart.NewWriter(buf)
+		writer.WriteField("name", "fido")
+		writer.WriteField("tag", "dog")
+		for _, name := range []string{"a.txt", "b.txt"} {
+			part, _ := writer.CreateFormFile("file", name)
+			part.Write([]byte("some content"))
+		}
+		writer.Close()
+		req, _ := http.NewRequest("POST", "/pets", buf)
+		req.Header.Set(httpkit.HeaderContentType, writer.FormDataContentType())
+		return req
+	}
+
+	req := newRequest()
+	_, err := applyLimits(req, RequestLimits{MaxMultipartMemory: 16, MaxFormFields: 2, MaxFiles: 2})
+	if assert.NoError(t, err) {
+		assert.Len(t, req.MultipartForm.File["file"], 2)
+		assert.Equal(t, "fido", req.FormValue("name"))
+	}
+
+	_, err = applyLimits(newRequest(), RequestLimits{MaxFormFields: 1})
+	if assert.Error(t, err) {
+		assert.EqualValues(t, http.StatusRequestEntityTooLarge, err.(errors.Error).Code())
+	}
+
+	_, err = applyLimits(newRequest(), RequestLimits{MaxFiles: 1})
+	if assert.Error(t, err) {
+		assert.EqualValues(t, http.StatusRequestEntityTooLarge, err.(errors.Error).Code())
+	}
+
+	_, err = applyLimits(newRequest(), RequestLimits{MaxBodySize: 64})
+	if assert.Error(t, err) {
+		assert.EqualValues(t, http.StatusRequestEntityTooLarge, err.(errors.Error).Code())
+	}
+
+	// a body without a content length is only caught while reading it
+	req = newRequest()
+	req.ContentLength = -1
+	_, err = applyLimits(req, RequestLimits{MaxBodySize: 64})
+	if assert.Error(t, err) {
+		assert.Equal(t, errors.BodyTooLarge(64), err)
+	}
+}
+
+func TestBodyTooLarge(t *testing.T) {
+	spec, api := petstore.NewAPI(t)
+	context := NewContext(spec, api, nil).WithRequestLimits(RequestLimits{MaxBodySize: 16})
+	handler := newRouter(context, newValidation(context, http.HandlerFunc(terminator)))
+
+	for _, length := range []int64{-1, 25} {
+		recorder := httptest.NewRecorder()
+		request, _ := http.NewRequest("POST", "/api/pets", strings.NewReader("name: a very long name"))
+		request.ContentLength = length
+		request.Header.Set(httpkit.HeaderContentType, "application/x-yaml")
+		request.Header.Set(httpkit.HeaderAccept, "application/x-yaml")
+		handler.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	}
+
+	recorder := httptest.NewRecorder()
+	request, _ := http.NewRequest("POST", "/api/pets", strings.NewReader("name: fido"))
+	request.Header.Set(httpkit.HeaderContentType, "application/x-yaml")
+	request.Header.Set(httpkit.HeaderAccept, "application/x-yaml")
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestBodyTooLargeWithInvalidParams(t *testing.T) {
+	doc, api := petstore.NewAPI(t)
+	doc.AllPaths()["/pets"].Post.AddParam(spec.QueryParam("dryRun").Typed("boolean", "").AsRequired())
+	context := NewContext(doc, api, nil).WithRequestLimits(RequestLimits{MaxBodySize: 16})
+	context.router = DefaultRouter(doc, context.api)
+
+	// the dryRun param is missing, the limit error is returned instead of a validation error
+	for _, length := range []int64{-1, 25} {
+		request, _ := http.NewRequest("POST", "/pets", strings.NewReader("name: a very long name"))
+		request.ContentLength = length
+		request.Header.Set(httpkit.HeaderContentType, "application/x-yaml")
+		request.Header.Set(httpkit.HeaderAccept, "application/x-yaml")
+		route, request, ok := context.RouteInfoWithRequest(request)
+		if assert.True(t, ok) {
+			_, request, err := context.BindAndValidateWithRequest(request, route)
+			assert.Equal(t, errors.BodyTooLarge(16), err)
+			_, _, err = context.BindAndValidateWithRequest(request, route)
+			assert.Equal(t, errors.BodyTooLarge(16), err)
+		}
+	}
+}
+
+func TestOperationLimits(t *testing.T) {
+	doc, api := petstore.NewAPI(t)
+	doc.AllPaths()["/pets"].Post.AddExtension(ExtMaxBodySize, "1KB")
+	router := DefaultRouter(doc, newRoutableUntypedAPI(doc, api, new(Context)))
+
+	route, ok := router.Lookup("POST", "/pets")
+	if assert.True(t, ok) {
+		assert.EqualValues(t, 1024, route.Limits.MaxBodySize)
+	}
+	route, ok = router.Lookup("GET", "/pets")
+	if assert.True(t, ok) {
+		assert.Equal(t, RequestLimits{}, route.Limits)
+	}
+}
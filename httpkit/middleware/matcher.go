@@ -0,0 +1,457 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/naoina/denco"
+)
+
+// PathMatcher matches request paths against the path templates that were added for a http method.
+// The templates use the swagger syntax (/pets/{id}), implementations adapt a muxer to the router.
+type PathMatcher interface {
+	// Add registers a value for a path template
+	Add(template string, value interface{}) error
+	// Build is called once all the templates were added and before the first match
+	Build() error
+	// Match finds the value for a request path and the params captured from it
+	Match(path string) (interface{}, RouteParams, bool)
+}
+
+var pathConverter = regexp.MustCompile(`{(\w+)}`)
+
+// DencoMatcher creates a path matcher backed by a denco router, this is the default matcher
+func DencoMatcher() PathMatcher {
+	return &dencoMatcher{router: denco.New()}
+}
+
+type dencoMatcher struct {
+	records []denco.Record
+	router  *denco.Router
+}
+
+func (d *dencoMatcher) Add(template string, value interface{}) error {
+	d.records = append(d.records, denco.NewRecord(pathConverter.ReplaceAllString(template, ":$1"), value))
+	return nil
+}
+
+func (d *dencoMatcher) Build() error {
+	return d.router.Build(d.records)
+}
+
+func (d *dencoMatcher) Match(path string) (interface{}, RouteParams, bool) {
+	value, rp, ok := d.router.Lookup(path)
+	if !ok || value == nil {
+		return nil, nil, false
+	}
+	var params RouteParams
+	for _, p := range rp {
+		params = append(params, RouteParam{Name: p.Name, Value: p.Value})
+	}
+	return value, params, true
+}
+
+// TreeMatcher creates a path matcher that walks a tree of path segments,
+// static segments take precedence over segments with params.
+// A segment can hold a single param with a static prefix and suffix, like {name}.json
+func TreeMatcher() PathMatcher {
+	return &treeMatcher{root: new(treeNode)}
+}
+
+type treeMatcher struct {
+	root *treeNode
+}
+
+type treeNode struct {
+	static   map[string]*treeNode
+	params   []*treeNode
+	name     string
+	prefix   string
+	suffix   string
+	value    interface{}
+	hasValue bool
+}
+
+func (t *treeMatcher) Add(template string, value interface{}) error {
+	node := t.root
+	for _, segment := range splitPath(template) {
+		node = node.child(segment)
+	}
+	node.value = value
+	node.hasValue = true
+	return nil
+}
+
+func (t *treeMatcher) Build() error {
+	t.root.sort()
+	return nil
+}
+
+func (t *treeMatcher) Match(path string) (interface{}, RouteParams, bool) {
+	return t.root.match(splitPath(path), nil)
+}
+
+func (n *treeNode) child(segment string) *treeNode {
+	start, end := strings.Index(segment, "{"), strings.LastIndex(segment, "}")
+	if start < 0 || end < start {
+		if n.static == nil {
+			n.static = make(map[string]*treeNode)
+		}
+		if c, ok := n.static[segment]; ok {
+			return c
+		}
+		c := new(treeNode)
+		n.static[segment] = c
+		return c
+	}
+
+	name, prefix, suffix := segment[start+1:end], segment[:start], segment[end+1:]
+	for _, c := range n.params {
+		if c.name == name && c.prefix == prefix && c.suffix == suffix {
+			return c
+		}
+	}
+	c := &treeNode{name: name, prefix: prefix, suffix: suffix}
+	n.params = append(n.params, c)
+	return c
+}
+
+// sort puts the params with the most specific prefix and suffix first
+func (n *treeNode) sort() {
+	sort.Stable(paramNodes(n.params))
+	for _, c := range n.static {
+		c.sort()
+	}
+	for _, c := range n.params {
+		c.sort()
+	}
+}
+
+type paramNodes []*treeNode
+
+func (p paramNodes) Len() int      { return len(p) }
+func (p paramNodes) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p paramNodes) Less(i, j int) bool {
+	return len(p[i].prefix)+len(p[i].suffix) > len(p[j].prefix)+len(p[j].suffix)
+}
+
+func (n *treeNode) match(segments []string, params RouteParams) (interface{}, RouteParams, bool) {
+	if len(segments) == 0 {
+		return n.value, params, n.hasValue
+	}
+
+	segment, rest := segments[0], segments[1:]
+	if c, ok := n.static[segment]; ok {
+		if value, p, ok := c.match(rest, params); ok {
+			return value, p, true
+		}
+	}
+	for _, c := range n.params {
+		if len(segment) <= len(c.prefix)+len(c.suffix) || !strings.HasPrefix(segment, c.prefix) || !strings.HasSuffix(segment, c.suffix) {
+			continue
+		}
+		p := append(params[:len(params):len(params)], RouteParam{Name: c.name, Value: segment[len(c.prefix) : len(segment)-len(c.suffix)]})
+		if value, p, ok := c.match(rest, p); ok {
+			return value, p, true
+		}
+	}
+	return nil, nil, false
+}
+
+// ServeMuxMatcher creates a path matcher for the patterns of a net/http ServeMux as of go 1.22,
+// the patterns are parsed here so they match the same way with every go version.
+// A {name} wildcard captures a segment, a {name...} wildcard at the end captures the rest of the path,
+// a pattern that ends in a slash matches every path below it unless it ends in {$}.
+// The most specific pattern wins, Build fails for patterns that match the same paths
+// without one being more specific, like a ServeMux panics for them.
+// The router matches the method and the host, so the patterns can't have them.
+func ServeMuxMatcher() PathMatcher {
+	return new(serveMuxMatcher)
+}
+
+type serveMuxMatcher struct {
+	patterns []*muxPattern
+}
+
+type muxPattern struct {
+	template string
+	segments []muxSegment
+	value    interface{}
+}
+
+// muxSegment is a literal or a wildcard, {$} is the literal "/"
+type muxSegment struct {
+	s     string
+	wild  bool
+	multi bool
+}
+
+func (m *serveMuxMatcher) Add(template string, value interface{}) error {
+	segments, err := parseMuxPattern(template)
+	if err != nil {
+		return fmt.Errorf("invalid pattern %q: %v", template, err)
+	}
+	m.patterns = append(m.patterns, &muxPattern{template: template, segments: segments, value: value})
+	return nil
+}
+
+func (m *serveMuxMatcher) Build() error {
+	for i, p1 := range m.patterns {
+		for _, p2 := range m.patterns[i+1:] {
+			switch p1.compare(p2) {
+			case muxEquivalent:
+				return fmt.Errorf("pattern %q matches the same paths as %q", p2.template, p1.template)
+			case muxOverlaps:
+				return fmt.Errorf("pattern %q and %q both match some paths but neither is more specific", p2.template, p1.template)
+			}
+		}
+	}
+	return nil
+}
+
+func (m *serveMuxMatcher) Match(path string) (interface{}, RouteParams, bool) {
+	var best *muxPattern
+	var bestParams RouteParams
+	for _, p := range m.patterns {
+		params, ok := p.match(path)
+		if !ok {
+			continue
+		}
+		if best == nil || p.compare(best) == muxMoreSpecific {
+			best, bestParams = p, params
+		}
+	}
+	if best == nil {
+		return nil, nil, false
+	}
+	return best.value, bestParams, true
+}
+
+func parseMuxPattern(pattern string) ([]muxSegment, error) {
+	if strings.IndexAny(pattern, " \t") >= 0 {
+		return nil, errors.New("a path matcher can't match the method")
+	}
+	i := strings.Index(pattern, "/")
+	if i < 0 {
+		return nil, errors.New("missing /")
+	}
+	if i > 0 {
+		return nil, errors.New("a path matcher can't match the host")
+	}
+
+	var segments []muxSegment
+	seen := make(map[string]bool)
+	for rest := pattern; len(rest) > 0; {
+		rest = rest[1:]
+		if len(rest) == 0 {
+			// a trailing slash matches the paths below it
+			segments = append(segments, muxSegment{wild: true, multi: true})
+			break
+		}
+		i := strings.Index(rest, "/")
+		if i < 0 {
+			i = len(rest)
+		}
+		var seg string
+		seg, rest = rest[:i], rest[i:]
+		start := strings.Index(seg, "{")
+		if start < 0 {
+			segments = append(segments, muxSegment{s: seg})
+			continue
+		}
+		if start != 0 || seg[len(seg)-1] != '}' {
+			return nil, fmt.Errorf("wildcard segment %q must be a whole segment", seg)
+		}
+		name := seg[1 : len(seg)-1]
+		if name == "$" {
+			if len(rest) != 0 {
+				return nil, errors.New("{$} isn't at the end")
+			}
+			segments = append(segments, muxSegment{s: "/"})
+			break
+		}
+		multi := strings.HasSuffix(name, "...")
+		name = strings.TrimSuffix(name, "...")
+		if multi && len(rest) != 0 {
+			return nil, fmt.Errorf("wildcard %q isn't at the end", seg)
+		}
+		if !validWildcardName(name) {
+			return nil, fmt.Errorf("invalid wildcard name %q", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate wildcard name %q", name)
+		}
+		seen[name] = true
+		segments = append(segments, muxSegment{s: name, wild: true, multi: multi})
+	}
+	return segments, nil
+}
+
+func validWildcardName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		if !unicode.IsLetter(c) && c != '_' && (i == 0 || !unicode.IsDigit(c)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *muxPattern) match(path string) (RouteParams, bool) {
+	var params RouteParams
+	rest := path
+	for _, seg := range p.segments {
+		if !strings.HasPrefix(rest, "/") {
+			return nil, false
+		}
+		switch {
+		case seg.multi:
+			if seg.s != "" {
+				params = append(params, RouteParam{Name: seg.s, Value: rest[1:]})
+			}
+			return params, true
+		case seg.s == "/" && !seg.wild:
+			return params, rest == "/"
+		}
+
+		value := rest[1:]
+		rest = ""
+		if i := strings.Index(value, "/"); i >= 0 {
+			value, rest = value[:i], value[i:]
+		}
+		if !seg.wild {
+			if value != seg.s {
+				return nil, false
+			}
+			continue
+		}
+		if value == "" {
+			return nil, false
+		}
+		params = append(params, RouteParam{Name: seg.s, Value: value})
+	}
+	return params, rest == ""
+}
+
+// muxRelation how the paths two patterns match relate to each other
+type muxRelation int
+
+const (
+	muxEquivalent muxRelation = iota
+	muxMoreSpecific
+	muxMoreGeneral
+	muxOverlaps
+	muxDisjoint
+)
+
+// compare tells how the paths this pattern matches relate to the paths the other pattern matches
+func (p *muxPattern) compare(other *muxPattern) muxRelation {
+	segs1, segs2 := p.segments, other.segments
+	if len(segs1) != len(segs2) && !segs1[len(segs1)-1].multi && !segs2[len(segs2)-1].multi {
+		return muxDisjoint
+	}
+
+	rel := muxEquivalent
+	for ; len(segs1) > 0 && len(segs2) > 0; segs1, segs2 = segs1[1:], segs2[1:] {
+		rel = rel.combine(compareMuxSegments(segs1[0], segs2[0]))
+		if rel == muxDisjoint {
+			return rel
+		}
+	}
+	switch {
+	case len(segs1) == 0 && len(segs2) == 0:
+		return rel
+	case len(segs1) < len(segs2) && p.segments[len(p.segments)-1].multi:
+		return rel.combine(muxMoreGeneral)
+	case len(segs2) < len(segs1) && other.segments[len(other.segments)-1].multi:
+		return rel.combine(muxMoreSpecific)
+	}
+	return muxDisjoint
+}
+
+func compareMuxSegments(s1, s2 muxSegment) muxRelation {
+	switch {
+	case s1.multi && s2.multi:
+		return muxEquivalent
+	case s1.multi:
+		return muxMoreGeneral
+	case s2.multi:
+		return muxMoreSpecific
+	case s1.wild && s2.wild:
+		return muxEquivalent
+	case s1.wild:
+		// a wildcard doesn't match the trailing slash of {$}
+		if s2.s == "/" {
+			return muxDisjoint
+		}
+		return muxMoreGeneral
+	case s2.wild:
+		if s1.s == "/" {
+			return muxDisjoint
+		}
+		return muxMoreSpecific
+	case s1.s == s2.s:
+		return muxEquivalent
+	}
+	return muxDisjoint
+}
+
+func (r muxRelation) combine(other muxRelation) muxRelation {
+	switch r {
+	case muxEquivalent:
+		return other
+	case muxDisjoint:
+		return muxDisjoint
+	case muxOverlaps:
+		if other == muxDisjoint {
+			return muxDisjoint
+		}
+		return muxOverlaps
+	}
+	switch other {
+	case muxEquivalent:
+		return r
+	case muxMoreSpecific, muxMoreGeneral:
+		if other != r {
+			return muxOverlaps
+		}
+	}
+	return other
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.TrimPrefix(path, "/"), "/")
+}
+
+// pathParams extracts the params of a path template from a request path
+// that is known to match the template
+func pathParams(template, path string) RouteParams {
+	var params RouteParams
+	segments := splitPath(path)
+	for i, segment := range splitPath(template) {
+		start, end := strings.Index(segment, "{"), strings.LastIndex(segment, "}")
+		if start < 0 || end < start || i >= len(segments) {
+			continue
+		}
+		name, value := segment[start+1:end], segments[i]
+		if name == "$" {
+			continue
+		}
+		if strings.HasSuffix(name, "...") {
+			// a ServeMux wildcard that captures the rest of the path
+			name, value = strings.TrimSuffix(name, "..."), strings.Join(segments[i:], "/")
+		}
+		prefix, suffix := len(segment[:start]), len(segment[end+1:])
+		if len(value) < prefix+suffix {
+			continue
+		}
+		params = append(params, RouteParam{Name: name, Value: value[prefix : len(value)-suffix]})
+	}
+	return params
+}
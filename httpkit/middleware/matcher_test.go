@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreeMatcher(t *testing.T) {
+	matcher := TreeMatcher()
+	for _, template := range []string{"/", "/pets", "/pets/{id}", "/pets/mine", "/pets/{id}/toys/{toy}", "/files/{name}.json", "/files/{name}"} {
+		assert.NoError(t, matcher.Add(template, template))
+	}
+	assert.NoError(t, matcher.Build())
+
+	for _, tc := range []struct {
+		path, template string
+		params         RouteParams
+	}{
+		{"/", "/", nil},
+		{"/pets", "/pets", nil},
+		{"/pets/mine", "/pets/mine", nil},
+		{"/pets/1", "/pets/{id}", RouteParams{{Name: "id", Value: "1"}}},
+		{"/pets/1/toys/ball", "/pets/{id}/toys/{toy}", RouteParams{{Name: "id", Value: "1"}, {Name: "toy", Value: "ball"}}},
+		{"/files/spec.json", "/files/{name}.json", RouteParams{{Name: "name", Value: "spec"}}},
+		{"/files/spec.yaml", "/files/{name}", RouteParams{{Name: "name", Value: "spec.yaml"}}},
+	} {
+		value, params, ok := matcher.Match(tc.path)
+		if assert.True(t, ok, tc.path) {
+			assert.Equal(t, tc.template, value, tc.path)
+			assert.Equal(t, tc.params, params, tc.path)
+		}
+	}
+
+	for _, path := range []string{"/pets/", "/pets/1/toys", "/dogs", "/files/"} {
+		_, _, ok := matcher.Match(path)
+		assert.False(t, ok, path)
+	}
+}
+
+func TestServeMuxMatcher(t *testing.T) {
+	matcher := ServeMuxMatcher()
+	for _, template := range []string{"/", "/pets", "/pets/{id}", "/pets/mine", "/pets/{id}/toys/{toy}", "/files/{path...}", "/static/", "/docs/{$}"} {
+		assert.NoError(t, matcher.Add(template, template))
+	}
+	assert.NoError(t, matcher.Build())
+
+	for _, tc := range []struct {
+		path, template string
+		params         RouteParams
+	}{
+		{"/", "/", nil},
+		{"/dogs", "/", nil},
+		{"/pets", "/pets", nil},
+		{"/pets/", "/", nil},
+		{"/pets/mine", "/pets/mine", nil},
+		{"/pets/1", "/pets/{id}", RouteParams{{Name: "id", Value: "1"}}},
+		{"/pets/1/toys/ball", "/pets/{id}/toys/{toy}", RouteParams{{Name: "id", Value: "1"}, {Name: "toy", Value: "ball"}}},
+		{"/files/specs/petstore.json", "/files/{path...}", RouteParams{{Name: "path", Value: "specs/petstore.json"}}},
+		{"/files/", "/files/{path...}", RouteParams{{Name: "path", Value: ""}}},
+		{"/static/css/site.css", "/static/", nil},
+		{"/static", "/", nil},
+		{"/docs/", "/docs/{$}", nil},
+		{"/docs/index.html", "/", nil},
+	} {
+		value, params, ok := matcher.Match(tc.path)
+		if assert.True(t, ok, tc.path) {
+			assert.Equal(t, tc.template, value, tc.path)
+			assert.Equal(t, tc.params, params, tc.path)
+		}
+	}
+
+	matcher = ServeMuxMatcher()
+	assert.NoError(t, matcher.Add("/pets/{id}", nil))
+	_, _, ok := matcher.Match("/pets/")
+	assert.False(t, ok)
+	_, _, ok = matcher.Match("/pets/1/toys")
+	assert.False(t, ok)
+
+	for _, template := range []string{"GET /pets", "example.com/pets", "pets", "/files/{name}.json", "/files/{path...}/raw", "/pets/{id}/toys/{id}", "/docs/{$}/index", "/pets/{}"} {
+		assert.Error(t, ServeMuxMatcher().Add(template, nil), template)
+	}
+
+	for _, templates := range [][]string{
+		{"/pets/{id}", "/pets/{name}"},
+		{"/pets/{id}/toys", "/pets/mine/{toy}"},
+	} {
+		matcher = ServeMuxMatcher()
+		for _, template := range templates {
+			assert.NoError(t, matcher.Add(template, nil))
+		}
+		assert.Error(t, matcher.Build(), templates[0])
+	}
+}
+
+func TestPathParams(t *testing.T) {
+	assert.Equal(t, RouteParams{{Name: "id", Value: "Fido"}}, pathParams("/pets/{id}", "/PETS/Fido"))
+	assert.Equal(t, RouteParams{{Name: "name", Value: "Spec"}}, pathParams("/files/{name}.json", "/FILES/Spec.JSON"))
+	assert.Equal(t, RouteParams{{Name: "path", Value: "Specs/Pets.JSON"}}, pathParams("/files/{path...}", "/FILES/Specs/Pets.JSON"))
+	assert.Nil(t, pathParams("/pets", "/pets"))
+}
@@ -0,0 +1,207 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultLatencyBuckets the upper bounds in seconds of the buckets of the latency histograms
+var DefaultLatencyBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// MetricsOpts the options for the metrics collector
+type MetricsOpts struct {
+	// Namespace the prefix of the metric names, defaults to swagger
+	Namespace string
+	// Buckets the upper bounds in seconds of the latency histogram buckets, defaults to DefaultLatencyBuckets
+	Buckets []float64
+}
+
+// Metrics is an observer that keeps per operation counters and latency histograms in memory.
+// It's also a http.Handler that serves them in the Prometheus text format.
+type Metrics struct {
+	lock          sync.Mutex
+	namespace     string
+	buckets       []float64
+	requests      map[requestKey]uint64
+	authFailures  map[string]uint64
+	bindingErrors map[string]uint64
+	latencies     map[string]*histogram
+}
+
+type requestKey struct {
+	operationID string
+	method      string
+	status      int
+}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewMetrics creates a new metrics collector
+func NewMetrics(opts MetricsOpts) *Metrics {
+	if opts.Namespace == "" {
+		opts.Namespace = "swagger"
+	}
+	if len(opts.Buckets) == 0 {
+		opts.Buckets = DefaultLatencyBuckets
+	}
+	buckets := append([]float64(nil), opts.Buckets...)
+	sort.Float64s(buckets)
+
+	return &Metrics{
+		namespace:     opts.Namespace,
+		buckets:       buckets,
+		requests:      make(map[requestKey]uint64),
+		authFailures:  make(map[string]uint64),
+		bindingErrors: make(map[string]uint64),
+		latencies:     make(map[string]*histogram),
+	}
+}
+
+// RouteMatched is a no-op, requests are counted when they complete
+func (m *Metrics) RouteMatched(*http.Request, *MatchedRoute) {}
+
+// Authenticated counts the failed authentications
+func (m *Metrics) Authenticated(_ *http.Request, operationID string, _ interface{}, err error) {
+	if err == nil {
+		return
+	}
+	m.lock.Lock()
+	m.authFailures[operationID]++
+	m.lock.Unlock()
+}
+
+// BindingFailed counts the requests with params that couldn't be bound
+func (m *Metrics) BindingFailed(_ *http.Request, operationID string, _ error) {
+	m.lock.Lock()
+	m.bindingErrors[operationID]++
+	m.lock.Unlock()
+}
+
+// Completed counts the request and records its latency
+func (m *Metrics) Completed(_ *http.Request, stats RequestStats) {
+	seconds := stats.Duration.Seconds()
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.requests[requestKey{stats.OperationID, stats.Method, stats.Status}]++
+
+	h, ok := m.latencies[stats.OperationID]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		m.latencies[stats.OperationID] = h
+	}
+	for i, bound := range m.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// WriteTo writes the metrics in the Prometheus text format
+func (m *Metrics) WriteTo(writer io.Writer) (int64, error) {
+	buf := bytes.NewBuffer(nil)
+	m.lock.Lock()
+	m.writeRequests(buf)
+	m.writeCounter(buf, "auth_failures_total", "Requests that failed to authenticate.", m.authFailures)
+	m.writeCounter(buf, "binding_errors_total", "Requests with parameters that failed to bind or validate.", m.bindingErrors)
+	m.writeLatencies(buf)
+	m.lock.Unlock()
+	return buf.WriteTo(writer)
+}
+
+// ServeHTTP serves the metrics in the Prometheus text format
+func (m *Metrics) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	rw.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	m.WriteTo(rw)
+}
+
+func (m *Metrics) writeRequests(buf *bytes.Buffer) {
+	name := m.namespace + "_requests_total"
+	fmt.Fprintf(buf, "# HELP %s Requests served by operation, method and status code.\n", name)
+	fmt.Fprintf(buf, "# TYPE %s counter\n", name)
+
+	keys := make([]requestKey, 0, len(m.requests))
+	for k := range m.requests {
+		keys = append(keys, k)
+	}
+	sort.Sort(requestKeys(keys))
+	for _, k := range keys {
+		fmt.Fprintf(buf, "%s{operation_id=%s,method=%s,code=\"%d\"} %d\n",
+			name, quoteLabel(k.operationID), quoteLabel(k.method), k.status, m.requests[k])
+	}
+}
+
+func (m *Metrics) writeCounter(buf *bytes.Buffer, suffix, help string, counts map[string]uint64) {
+	name := m.namespace + "_" + suffix
+	fmt.Fprintf(buf, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(buf, "# TYPE %s counter\n", name)
+	for _, id := range sortedOperationIDs(counts) {
+		fmt.Fprintf(buf, "%s{operation_id=%s} %d\n", name, quoteLabel(id), counts[id])
+	}
+}
+
+func (m *Metrics) writeLatencies(buf *bytes.Buffer) {
+	name := m.namespace + "_request_duration_seconds"
+	fmt.Fprintf(buf, "# HELP %s Time spent serving requests by operation.\n", name)
+	fmt.Fprintf(buf, "# TYPE %s histogram\n", name)
+
+	ids := make([]string, 0, len(m.latencies))
+	for id := range m.latencies {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		h, label := m.latencies[id], quoteLabel(id)
+		for i, bound := range m.buckets {
+			fmt.Fprintf(buf, "%s_bucket{operation_id=%s,le=\"%s\"} %d\n", name, label, formatFloat(bound), h.counts[i])
+		}
+		fmt.Fprintf(buf, "%s_bucket{operation_id=%s,le=\"+Inf\"} %d\n", name, label, h.count)
+		fmt.Fprintf(buf, "%s_sum{operation_id=%s} %s\n", name, label, formatFloat(h.sum))
+		fmt.Fprintf(buf, "%s_count{operation_id=%s} %d\n", name, label, h.count)
+	}
+}
+
+func sortedOperationIDs(counts map[string]uint64) []string {
+	ids := make([]string, 0, len(counts))
+	for id := range counts {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func quoteLabel(value string) string {
+	return `"` + labelEscaper.Replace(value) + `"`
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+type requestKeys []requestKey
+
+func (r requestKeys) Len() int      { return len(r) }
+func (r requestKeys) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r requestKeys) Less(i, j int) bool {
+	if r[i].operationID != r[j].operationID {
+		return r[i].operationID < r[j].operationID
+	}
+	if r[i].method != r[j].method {
+		return r[i].method < r[j].method
+	}
+	return r[i].status < r[j].status
+}
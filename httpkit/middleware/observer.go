@@ -0,0 +1,188 @@
+package middleware
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Observer is told about the stages a request goes through while it's served,
+// the events are keyed by the id of the operation that was matched.
+// The methods are called on the goroutine that serves the request, so they should return quickly.
+type Observer interface {
+	// RouteMatched is called when the router found the operation for a request
+	RouteMatched(*http.Request, *MatchedRoute)
+	// Authenticated is called after the request was authenticated,
+	// the error is not nil when none of the authenticators accepted the request
+	Authenticated(r *http.Request, operationID string, principal interface{}, err error)
+	// BindingFailed is called when the params of a request can't be bound or aren't valid
+	BindingFailed(r *http.Request, operationID string, err error)
+	// Completed is called when the response has been written
+	Completed(*http.Request, RequestStats)
+}
+
+// RequestStats describes a request that was served
+type RequestStats struct {
+	// OperationID the id of the matched operation, empty when no route was matched
+	OperationID string
+	// Method the method of the request
+	Method string
+	// Path the path of the request
+	Path string
+	// PathPattern the path template of the matched route
+	PathPattern string
+	// Status the status code of the response
+	Status int
+	// Size the number of bytes written in the response body
+	Size int64
+	// Start the time at which the request started being served
+	Start time.Time
+	// Duration the time it took to serve the request
+	Duration time.Duration
+	// HandlerDuration the time the operation handler took, it's zero when the request didn't reach the handler
+	HandlerDuration time.Duration
+	// Err the authentication or binding error that the request failed with, if any
+	Err error
+}
+
+// WithObserver adds observers that are told about the requests this context serves
+func (c *Context) WithObserver(observers ...Observer) *Context {
+	c.observers = append(c.observers, observers...)
+	return c
+}
+
+// requestState the state that the outer middlewares need to know about while a request is served,
+// it's shared by all the copies of the request made downstream
+type requestState struct {
+	route           *MatchedRoute
+	err             error
+	handlerDuration time.Duration
+}
+
+func withRequestState(request *http.Request) *http.Request {
+	return withValue(request, ctxRequestState, new(requestState))
+}
+
+func requestStateFrom(request *http.Request) *requestState {
+	if v, ok := request.Context().Value(ctxRequestState).(*requestState); ok {
+		return v
+	}
+	return nil
+}
+
+func (c *Context) routeMatched(request *http.Request, route *MatchedRoute) {
+	if state := requestStateFrom(request); state != nil {
+		state.route = route
+	}
+	for _, o := range c.observers {
+		o.RouteMatched(request, route)
+	}
+}
+
+func (c *Context) authenticated(request *http.Request, route *MatchedRoute, principal interface{}, err error) {
+	if len(c.observers) == 0 {
+		return
+	}
+	if state := requestStateFrom(request); state != nil && err != nil {
+		state.err = err
+	}
+	for _, o := range c.observers {
+		o.Authenticated(request, operationIDFor(route), principal, err)
+	}
+}
+
+func (c *Context) bindingFailed(request *http.Request, route *MatchedRoute, err error) {
+	if len(c.observers) == 0 {
+		return
+	}
+	if state := requestStateFrom(request); state != nil {
+		state.err = err
+	}
+	for _, o := range c.observers {
+		o.BindingFailed(request, operationIDFor(route), err)
+	}
+}
+
+func operationIDFor(route *MatchedRoute) string {
+	if route == nil || route.Operation == nil {
+		return ""
+	}
+	return route.Operation.ID
+}
+
+// newObserved tells the observers about the requests when they complete
+func newObserved(ctx *Context, next http.Handler) http.Handler {
+	if len(ctx.observers) == 0 {
+		return next
+	}
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		stats := RequestStats{Method: r.Method, Path: r.URL.Path, Start: time.Now()}
+		recorder := &statusRecorder{ResponseWriter: rw}
+		rState := withRequestState(r)
+
+		next.ServeHTTP(recorder, rState)
+
+		state := requestStateFrom(rState)
+		stats.Duration = time.Since(stats.Start)
+		stats.Status = recorder.Status()
+		stats.Size = recorder.size
+		stats.Err = state.err
+		stats.HandlerDuration = state.handlerDuration
+		if state.route != nil {
+			stats.OperationID = operationIDFor(state.route)
+			stats.PathPattern = state.route.PathPattern
+		}
+		for _, o := range ctx.observers {
+			o.Completed(r, stats)
+		}
+	})
+}
+
+// statusRecorder remembers the status code and the size of a response
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	size   int64
+}
+
+func (s *statusRecorder) WriteHeader(code int) {
+	if s.status == 0 {
+		s.status = code
+	}
+	s.ResponseWriter.WriteHeader(code)
+}
+
+func (s *statusRecorder) Write(b []byte) (int, error) {
+	if s.status == 0 {
+		s.status = http.StatusOK
+	}
+	n, err := s.ResponseWriter.Write(b)
+	s.size += int64(n)
+	return n, err
+}
+
+// Status the status code that was written, a response without a body or status is a 200
+func (s *statusRecorder) Status() int {
+	if s.status == 0 {
+		return http.StatusOK
+	}
+	return s.status
+}
+
+func (s *statusRecorder) Flush() {
+	if s.status == 0 {
+		s.status = http.StatusOK
+	}
+	if f, ok := s.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (s *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := s.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, fmt.Errorf("%T doesn't support hijacking the connection", s.ResponseWriter)
+}
@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vikstrous/go-swagger/httpkit"
+	"github.com/vikstrous/go-swagger/internal/testing/petstore"
+)
+
+type recordingObserver struct {
+	events []string
+	stats  []RequestStats
+}
+
+func (r *recordingObserver) RouteMatched(_ *http.Request, route *MatchedRoute) {
+	r.events = append(r.events, "route:"+route.Operation.ID)
+}
+
+func (r *recordingObserver) Authenticated(_ *http.Request, operationID string, _ interface{}, err error) {
+	if err != nil {
+		r.events = append(r.events, "authFailed:"+operationID)
+		return
+	}
+	r.events = append(r.events, "auth:"+operationID)
+}
+
+func (r *recordingObserver) BindingFailed(_ *http.Request, operationID string, _ error) {
+	r.events = append(r.events, "bindingFailed:"+operationID)
+}
+
+func (r *recordingObserver) Completed(_ *http.Request, stats RequestStats) {
+	r.stats = append(r.stats, stats)
+}
+
+func TestObserver(t *testing.T) {
+	spec, api := petstore.NewAPI(t)
+	observer := new(recordingObserver)
+	handler := NewContext(spec, api, nil).WithObserver(observer).APIHandler()
+
+	recorder := httptest.NewRecorder()
+	request, _ := http.NewRequest("GET", "/api/pets", nil)
+	request.SetBasicAuth("admin", "admin")
+	request.Header.Set(httpkit.HeaderAccept, httpkit.JSONMime)
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	request, _ = http.NewRequest("GET", "/api/pets", nil)
+	request.SetBasicAuth("admin", "wrong")
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	request, _ = http.NewRequest("POST", "/api/pets", strings.NewReader("name: fido"))
+	request.SetBasicAuth("admin", "admin")
+	request.Header.Set(httpkit.HeaderContentType, "text/plain")
+	request.Header.Set(httpkit.HeaderAccept, "application/x-yaml")
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusUnsupportedMediaType, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	request, _ = http.NewRequest("GET", "/api/nopets", nil)
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	assert.Equal(t, []string{
+		"route:getAllPets", "auth:getAllPets",
+		"route:getAllPets", "authFailed:getAllPets",
+		"route:createPet", "auth:createPet", "bindingFailed:createPet",
+	}, observer.events)
+
+	if assert.Len(t, observer.stats, 4) {
+		assert.Equal(t, "getAllPets", observer.stats[0].OperationID)
+		assert.Equal(t, "/pets", observer.stats[0].PathPattern)
+		assert.Equal(t, "/api/pets", observer.stats[0].Path)
+		assert.Equal(t, http.StatusOK, observer.stats[0].Status)
+		assert.NoError(t, observer.stats[0].Err)
+		assert.True(t, observer.stats[0].HandlerDuration > 0)
+		assert.True(t, observer.stats[0].HandlerDuration <= observer.stats[0].Duration)
+
+		assert.Equal(t, http.StatusUnauthorized, observer.stats[1].Status)
+		assert.Error(t, observer.stats[1].Err)
+
+		assert.Equal(t, "createPet", observer.stats[2].OperationID)
+		assert.Equal(t, http.StatusUnsupportedMediaType, observer.stats[2].Status)
+		assert.Error(t, observer.stats[2].Err)
+
+		assert.Empty(t, observer.stats[3].OperationID)
+		assert.Equal(t, http.StatusNotFound, observer.stats[3].Status)
+		assert.Zero(t, observer.stats[3].HandlerDuration)
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	spec, api := petstore.NewAPI(t)
+	buf := bytes.NewBuffer(nil)
+	handler := NewContext(spec, api, nil).WithObserver(NewAccessLog(buf)).APIHandler()
+
+	recorder := httptest.NewRecorder()
+	request, _ := http.NewRequest("GET", "/api/pets", nil)
+	request.SetBasicAuth("admin", "admin")
+	request.Header.Set(httpkit.HeaderAccept, httpkit.JSONMime)
+	request.RemoteAddr = "127.0.0.1:5000"
+	handler.ServeHTTP(recorder, request)
+
+	var entry map[string]interface{}
+	if assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry)) {
+		assert.Equal(t, "GET", entry["method"])
+		assert.Equal(t, "/api/pets", entry["path"])
+		assert.Equal(t, "/pets", entry["pathPattern"])
+		assert.Equal(t, "getAllPets", entry["operationId"])
+		assert.EqualValues(t, 200, entry["status"])
+		assert.EqualValues(t, recorder.Body.Len(), entry["size"])
+		assert.Equal(t, "127.0.0.1:5000", entry["remoteAddr"])
+		assert.Contains(t, entry, "handlerMs")
+		assert.NotContains(t, entry, "error")
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	metrics := NewMetrics(MetricsOpts{Buckets: []float64{1, 0.1}})
+	request, _ := http.NewRequest("GET", "/api/pets", nil)
+
+	metrics.Completed(request, RequestStats{OperationID: "getAllPets", Method: "GET", Status: 200, Duration: 50 * time.Millisecond})
+	metrics.Completed(request, RequestStats{OperationID: "getAllPets", Method: "GET", Status: 200, Duration: 500 * time.Millisecond})
+	metrics.Completed(request, RequestStats{OperationID: "getAllPets", Method: "GET", Status: 401, Duration: 2 * time.Second})
+	metrics.Authenticated(request, "getAllPets", nil, assert.AnError)
+	metrics.Authenticated(request, "getAllPets", "admin", nil)
+	metrics.BindingFailed(request, "create\"Pet", assert.AnError)
+
+	recorder := httptest.NewRecorder()
+	metrics.ServeHTTP(recorder, request)
+	assert.Equal(t, "text/plain; version=0.0.4", recorder.Header().Get(httpkit.HeaderContentType))
+	assert.Equal(t, `# HELP swagger_requests_total Requests served by operation, method and status code.
+# TYPE swagger_requests_total counter
+swagger_requests_total{operation_id="getAllPets",method="GET",code="200"} 2
+swagger_requests_total{operation_id="getAllPets",method="GET",code="401"} 1
+# HELP swagger_auth_failures_total Requests that failed to authenticate.
+# TYPE swagger_auth_failures_total counter
+swagger_auth_failures_total{operation_id="getAllPets"} 1
+# HELP swagger_binding_errors_total Requests with parameters that failed to bind or validate.
+# TYPE swagger_binding_errors_total counter
+swagger_binding_errors_total{operation_id="create\"Pet"} 1
+# HELP swagger_request_duration_seconds Time spent serving requests by operation.
+# TYPE swagger_request_duration_seconds histogram
+swagger_request_duration_seconds_bucket{operation_id="getAllPets",le="0.1"} 1
+swagger_request_duration_seconds_bucket{operation_id="getAllPets",le="1"} 2
+swagger_request_duration_seconds_bucket{operation_id="getAllPets",le="+Inf"} 3
+swagger_request_duration_seconds_sum{operation_id="getAllPets"} 2.55
+swagger_request_duration_seconds_count{operation_id="getAllPets"} 3
+`, recorder.Body.String())
+}
@@ -1,11 +1,21 @@
 package middleware
 
-import "net/http"
+import (
+	"net/http"
+	"time"
+)
 
 func newOperationExecutor(ctx *Context) http.Handler {
 	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
 		// use context to lookup routes
-		route, _ := ctx.RouteInfo(r)
+		route, r, _ := ctx.RouteInfoWithRequest(r)
+		state := requestStateFrom(r)
+		if state == nil {
+			route.Handler.ServeHTTP(rw, r)
+			return
+		}
+		start := time.Now()
 		route.Handler.ServeHTTP(rw, r)
+		state.handlerDuration = time.Since(start)
 	})
 }
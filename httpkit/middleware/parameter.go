@@ -3,12 +3,14 @@ package middleware
 import (
 	"encoding"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/vikstrous/go-swagger/errors"
 	"github.com/vikstrous/go-swagger/httpkit"
@@ -27,10 +29,23 @@ func newUntypedParamBinder(param spec.Parameter, spec *spec.Swagger, formats str
 	binder.Name = param.Name
 	binder.parameter = &param
 	binder.formats = formats
-	if param.In != "body" {
-		binder.validator = validate.NewParamValidator(&param, formats)
-	} else {
+	switch {
+	case param.In == "body":
 		binder.validator = validate.NewSchemaValidator(param.Schema, spec, param.Name, formats)
+	case binder.queryStyle() != "":
+		// the content of a structured query param is described by the schema in its vendor extension,
+		// a schema that can't be read was reported when the spec was validated and fails the requests
+		schema, err := validate.QuerySchema(&param, spec)
+		if err != nil {
+			binder.schemaErr = err
+			break
+		}
+		binder.querySchema = schema
+		if schema != nil {
+			binder.validator = sentValueValidator{validate.NewSchemaValidator(schema, spec, param.Name, formats)}
+		}
+	default:
+		binder.validator = validate.NewParamValidator(&param, formats)
 	}
 
 	return binder
@@ -41,9 +56,18 @@ type untypedParamBinder struct {
 	formats   strfmt.Registry
 	Name      string
 	validator validate.EntityValidator
+	// querySchema the schema of the content of a structured query param
+	querySchema *spec.Schema
+	schemaErr   error
 }
 
+var interfaceType = reflect.TypeOf(new(interface{})).Elem()
+
 func (p *untypedParamBinder) Type() reflect.Type {
+	if p.queryStyle() != "" {
+		// the content of a structured query param has the json types of its schema
+		return interfaceType
+	}
 	return p.typeForSchema(p.parameter.Type, p.parameter.Format, p.parameter.Items)
 }
 
@@ -126,6 +150,9 @@ func (p *untypedParamBinder) Bind(request *http.Request, routeParams RouteParams
 	// fmt.Println("binding", p.name, "as", p.Type())
 	switch p.parameter.In {
 	case "query":
+		if style := p.queryStyle(); style != "" {
+			return p.bindStyledQuery(request.URL.Query(), style, target)
+		}
 		data, custom, err := p.readValue(request.URL.Query(), target)
 		if err != nil {
 			return err
@@ -419,3 +446,174 @@ func (p *untypedParamBinder) setSliceFieldValue(target reflect.Value, defaultVal
 
 	return nil
 }
+
+// sentValueValidator validates the values that were sent, an optional structured query param
+// that is missing from the request is left nil and there is nothing to validate
+type sentValueValidator struct {
+	validate.EntityValidator
+}
+
+func (s sentValueValidator) Validate(data interface{}) *validate.Result {
+	val := reflect.ValueOf(data)
+	switch val.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Map, reflect.Slice, reflect.Ptr, reflect.Interface:
+		if val.IsNil() {
+			return nil
+		}
+	}
+	return s.EntityValidator.Validate(data)
+}
+
+// queryStyle the serialisation of a query param, set with the x-query-style vendor extension
+func (p *untypedParamBinder) queryStyle() string {
+	if p.parameter.In != "query" {
+		return ""
+	}
+	style, _ := p.parameter.Extensions.GetString(httpkit.ExtQueryStyle)
+	return style
+}
+
+// bindStyledQuery binds a query param that is sent as a deepObject or as a json document.
+// The value is converted to the types in the schema of the param and then decoded into the target.
+func (p *untypedParamBinder) bindStyledQuery(values url.Values, style string, target reflect.Value) error {
+	if p.schemaErr != nil {
+		return errors.New(500, "parameter %q: %v", p.parameter.Name, p.schemaErr)
+	}
+	var data interface{}
+	switch {
+	case strings.EqualFold(style, httpkit.QueryStyleDeepObject):
+		obj, err := httpkit.ReadDeepObject(values, p.parameter.Name)
+		if err != nil {
+			return errors.NewParseError(p.Name, p.parameter.In, "", err)
+		}
+		if obj != nil {
+			value, err := p.convertDeepObject(p.parameter.Name, obj, p.querySchema)
+			if err != nil {
+				return err
+			}
+			data = value
+		}
+
+	case strings.EqualFold(style, httpkit.QueryStyleJSON):
+		v := values.Get(p.parameter.Name)
+		if v != "" {
+			if err := json.Unmarshal([]byte(v), &data); err != nil {
+				return errors.NewParseError(p.Name, p.parameter.In, v, err)
+			}
+		}
+
+	default:
+		return errors.New(500, "invalid query style %q for parameter %q", style, p.parameter.Name)
+	}
+
+	if data == nil {
+		if p.parameter.Required && p.parameter.Default == nil {
+			return errors.Required(p.Name, p.parameter.In)
+		}
+		data = p.parameter.Default
+		if data == nil {
+			target.Set(reflect.Zero(target.Type()))
+			return nil
+		}
+	}
+
+	// the value is made of plain json types, a round trip decodes it into any target type
+	b, err := json.Marshal(data)
+	if err != nil {
+		return errors.NewParseError(p.Name, p.parameter.In, "", err)
+	}
+	value := reflect.New(target.Type())
+	if err := json.Unmarshal(b, value.Interface()); err != nil {
+		return errors.InvalidType(p.Name, p.parameter.In, p.parameter.Type, string(b))
+	}
+	target.Set(reflect.Indirect(value))
+	return nil
+}
+
+// convertDeepObject converts the values of a deepObject to the types of the properties in the schema,
+// without a schema a value is a string, or a slice when the key was repeated
+func (p *untypedParamBinder) convertDeepObject(path string, obj map[string]interface{}, schema *spec.Schema) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		var prop *spec.Schema
+		if schema != nil {
+			if ps, ok := schema.Properties[k]; ok {
+				prop = &ps
+			} else if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+				prop = schema.AdditionalProperties.Schema
+			}
+		}
+		name := path + "." + k
+
+		if nested, ok := v.(map[string]interface{}); ok {
+			value, err := p.convertDeepObject(name, nested, prop)
+			if err != nil {
+				return nil, err
+			}
+			result[k] = value
+			continue
+		}
+
+		vals := v.([]string)
+		if prop != nil && prop.Type.Contains("array") {
+			var items *spec.Schema
+			if prop.Items != nil {
+				items = prop.Items.Schema
+			}
+			converted := make([]interface{}, 0, len(vals))
+			for _, val := range vals {
+				item, err := convertDeepObjectValue(name, items, val)
+				if err != nil {
+					return nil, err
+				}
+				converted = append(converted, item)
+			}
+			result[k] = converted
+			continue
+		}
+
+		if prop == nil && len(vals) > 1 {
+			converted := make([]interface{}, len(vals))
+			for i, val := range vals {
+				converted[i] = val
+			}
+			result[k] = converted
+			continue
+		}
+		value, err := convertDeepObjectValue(name, prop, vals[0])
+		if err != nil {
+			return nil, err
+		}
+		result[k] = value
+	}
+	return result, nil
+}
+
+func convertDeepObjectValue(name string, schema *spec.Schema, data string) (interface{}, error) {
+	if schema == nil {
+		return data, nil
+	}
+	switch {
+	case schema.Type.Contains("integer"):
+		i, err := strconv.ParseInt(data, 10, 64)
+		if err != nil {
+			return nil, errors.InvalidType(name, "query", "integer", data)
+		}
+		return i, nil
+	case schema.Type.Contains("number"):
+		f, err := strconv.ParseFloat(data, 64)
+		if err != nil {
+			return nil, errors.InvalidType(name, "query", "number", data)
+		}
+		return f, nil
+	case schema.Type.Contains("boolean"):
+		b, err := swag.ConvertBool(data)
+		if err != nil {
+			return nil, errors.InvalidType(name, "query", "boolean", data)
+		}
+		return b, nil
+	}
+	return data, nil
+}
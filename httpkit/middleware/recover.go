@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/vikstrous/go-swagger/errors"
+)
+
+// PanicHook is called with the value and the stack of a panic that happened while serving a request
+type PanicHook func(r *http.Request, recovered interface{}, stack []byte)
+
+// WithPanicHook adds hooks that are called when serving a request panics,
+// when there are no hooks the panic and its stack are logged
+func (c *Context) WithPanicHook(hooks ...PanicHook) *Context {
+	c.panicHooks = append(c.panicHooks, hooks...)
+	return c
+}
+
+func logPanic(r *http.Request, recovered interface{}, stack []byte) {
+	log.Printf("panic serving %s %s: %v\n%s", r.Method, r.URL.Path, recovered, stack)
+}
+
+// newRecoverer turns the panics of the next handlers into 500 responses,
+// which are served with the error handler of the operation that was matched
+func newRecoverer(ctx *Context, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		state := requestStateFrom(r)
+		if state == nil {
+			r = withRequestState(r)
+			state = requestStateFrom(r)
+		}
+		recorder, ok := rw.(*statusRecorder)
+		if !ok {
+			recorder = &statusRecorder{ResponseWriter: rw}
+		}
+
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				// the server aborts the response without logging it
+				panic(recovered)
+			}
+
+			stack := debug.Stack()
+			hooks := ctx.panicHooks
+			if len(hooks) == 0 {
+				hooks = []PanicHook{logPanic}
+			}
+			for _, hook := range hooks {
+				hook(r, recovered, stack)
+			}
+
+			if recorder.status != 0 {
+				// the response has started, it's too late to replace it with an error
+				return
+			}
+			err, ok := recovered.(errors.Error)
+			if !ok || err.Code() < http.StatusInternalServerError {
+				err = errors.New(http.StatusInternalServerError, "internal server error")
+			}
+			recorder.Header().Set("Content-Type", "application/json")
+			ctx.api.ServeErrorFor(operationIDFor(state.route))(recorder, r, err)
+		}()
+
+		next.ServeHTTP(recorder, r)
+	})
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vikstrous/go-swagger/errors"
+	"github.com/vikstrous/go-swagger/httpkit"
+	"github.com/vikstrous/go-swagger/internal/testing/petstore"
+)
+
+func TestRecoverer(t *testing.T) {
+	spec, api := petstore.NewAPI(t)
+	api.RegisterOperation("getAllPets", httpkit.OperationHandlerFunc(func(interface{}) (interface{}, error) {
+		panic("the pets are loose")
+	}))
+	var servedFor string
+	api.ServeError = func(rw http.ResponseWriter, r *http.Request, err error) {
+		if route := MatchedRouteFrom(r); route != nil {
+			servedFor = route.Operation.ID
+		}
+		errors.ServeError(rw, r, err)
+	}
+
+	var recovered interface{}
+	var stack []byte
+	context := NewContext(spec, api, nil).WithPanicHook(func(r *http.Request, v interface{}, s []byte) {
+		recovered, stack = v, s
+	})
+	handler := context.APIHandler()
+
+	recorder := httptest.NewRecorder()
+	request, _ := http.NewRequest("GET", "/api/pets", nil)
+	request.SetBasicAuth("admin", "admin")
+	request.Header.Set(httpkit.HeaderAccept, httpkit.JSONMime)
+	handler.ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Equal(t, httpkit.JSONMime, recorder.Header().Get(httpkit.HeaderContentType))
+	var body map[string]interface{}
+	if assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body)) {
+		// the value of the panic isn't leaked to the client
+		assert.Equal(t, "internal server error", body["message"])
+	}
+	assert.Equal(t, "the pets are loose", recovered)
+	assert.Contains(t, string(stack), "TestRecoverer")
+	assert.Empty(t, servedFor)
+}
+
+func TestRecoverer_ResponseStarted(t *testing.T) {
+	spec, api := petstore.NewAPI(t)
+	ctx := NewContext(spec, api, nil).WithPanicHook(func(*http.Request, interface{}, []byte) {})
+	handler := newRecoverer(ctx, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusAccepted)
+		panic(errors.New(http.StatusInternalServerError, "can't find a producer for text/plain"))
+	}))
+
+	recorder := httptest.NewRecorder()
+	request, _ := http.NewRequest("GET", "/api/pets", nil)
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusAccepted, recorder.Code)
+	assert.Empty(t, recorder.Body.String())
+
+	assert.Panics(t, func() {
+		newRecoverer(ctx, http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			panic(http.ErrAbortHandler)
+		})).ServeHTTP(httptest.NewRecorder(), request)
+	})
+}
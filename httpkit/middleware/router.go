@@ -1,15 +1,16 @@
 package middleware
 
 import (
+	"fmt"
+	"net"
 	"net/http"
-	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/vikstrous/go-swagger/errors"
 	"github.com/vikstrous/go-swagger/httpkit"
 	"github.com/vikstrous/go-swagger/spec"
 	"github.com/vikstrous/go-swagger/strfmt"
-	"github.com/gorilla/context"
 	"github.com/naoina/denco"
 )
 
@@ -41,24 +42,22 @@ func newRouter(ctx *Context, next http.Handler) http.Handler {
 	isRoot := ctx.spec.BasePath() == "" || ctx.spec.BasePath() == "/"
 
 	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
-		defer context.Clear(r)
 		// use context to lookup routes
-		if isRoot {
-			if _, ok := ctx.RouteInfo(r); ok {
-				next.ServeHTTP(rw, r)
-				return
-			}
-		} else {
-			if p := strings.TrimPrefix(r.URL.Path, ctx.spec.BasePath()); len(p) < len(r.URL.Path) {
-				r.URL.Path = p
-				if _, ok := ctx.RouteInfo(r); ok {
-					next.ServeHTTP(rw, r)
+		prefix, routable := routePrefix(ctx, isRoot, r)
+		if routable {
+			r.URL.Path = strings.TrimPrefix(r.URL.Path, prefix)
+			if route, rCtx, ok := ctx.RouteInfoWithRequest(r); ok {
+				if route.redirect != "" {
+					redirect(rw, r, prefix+route.redirect)
 					return
 				}
+				ctx.routeMatched(rCtx, route)
+				next.ServeHTTP(rw, rCtx)
+				return
 			}
 		}
 		// Not found, check if it exists in the other methods first
-		if others := ctx.AllowedMethods(r); len(others) > 0 {
+		if others := ctx.AllowedMethods(r); routable && len(others) > 0 {
 			ctx.Respond(rw, r, ctx.spec.RequiredProduces(), nil, errors.MethodNotAllowed(r.Method, others))
 			return
 		}
@@ -67,6 +66,46 @@ func newRouter(ctx *Context, next http.Handler) http.Handler {
 	})
 }
 
+// routePrefix finds the prefix to strip from the request path before routing it.
+// When the path isn't below the base path, the request is still routed when
+// it was made to the host of the spec, as if a proxy stripped the base path already.
+func routePrefix(ctx *Context, isRoot bool, r *http.Request) (string, bool) {
+	if isRoot {
+		return "", true
+	}
+	if basePath := ctx.spec.BasePath(); strings.HasPrefix(r.URL.Path, basePath) {
+		return basePath, true
+	}
+	return "", matchesHost(ctx.spec.Host(), r)
+}
+
+func matchesHost(host string, r *http.Request) bool {
+	if host == "" {
+		return false
+	}
+	actual := r.Host
+	if actual == "" {
+		actual = r.URL.Host
+	}
+	if !strings.Contains(host, ":") {
+		if h, _, err := net.SplitHostPort(actual); err == nil {
+			actual = h
+		}
+	}
+	return strings.EqualFold(host, actual)
+}
+
+func redirect(rw http.ResponseWriter, r *http.Request, path string) {
+	if r.URL.RawQuery != "" {
+		path += "?" + r.URL.RawQuery
+	}
+	code := http.StatusPermanentRedirect
+	if r.Method == "GET" || r.Method == "HEAD" {
+		code = http.StatusMovedPermanently
+	}
+	http.Redirect(rw, r, path, code)
+}
+
 // RoutableAPI represents an interface for things that can serve
 // as a provider of implementations for the swagger router
 type RoutableAPI interface {
@@ -86,29 +125,82 @@ type Router interface {
 	OtherMethods(method, path string) []string
 }
 
+// RouteLister is implemented by routers that can list the routes they know about
+type RouteLister interface {
+	Routes() []RouteDescription
+}
+
+// RouteDescription describes a route of a router, for diagnostics
+type RouteDescription struct {
+	Method      string
+	PathPattern string
+	OperationID string
+	Handler     http.Handler
+}
+
+// TrailingSlashPolicy determines how a router treats a request path that only matches
+// a path template once a trailing slash is added or removed
+type TrailingSlashPolicy int
+
+const (
+	// TrailingSlashStrict doesn't match the path
+	TrailingSlashStrict TrailingSlashPolicy = iota
+	// TrailingSlashIgnore matches the path as if the trailing slash was right
+	TrailingSlashIgnore
+	// TrailingSlashRedirect redirects the request to the path with the right trailing slash
+	TrailingSlashRedirect
+)
+
+// RouterOpts the options for building a router
+type RouterOpts struct {
+	// Matcher creates the path matcher for each http method, defaults to DencoMatcher
+	Matcher func() PathMatcher
+	// TrailingSlash the policy for paths that only differ by a trailing slash
+	TrailingSlash TrailingSlashPolicy
+	// CaseInsensitive matches the static parts of a path regardless of case
+	CaseInsensitive bool
+}
+
 type defaultRouteBuilder struct {
 	spec    *spec.Document
 	api     RoutableAPI
+	opts    RouterOpts
+	limits  RequestLimits
 	records map[string][]denco.Record
 }
 
 type defaultRouter struct {
 	spec    *spec.Document
 	api     RoutableAPI
-	routers map[string]*denco.Router
+	opts    RouterOpts
+	routers map[string]PathMatcher
+	routes  []RouteDescription
 }
 
 func newDefaultRouteBuilder(spec *spec.Document, api RoutableAPI) *defaultRouteBuilder {
 	return &defaultRouteBuilder{
 		spec:    spec,
 		api:     api,
+		limits:  specLimits(spec),
 		records: make(map[string][]denco.Record),
 	}
 }
 
-// DefaultRouter creates a default implemenation of the router
+// DefaultRouter creates a default implemenation of the router,
+// it panics when a path of the spec can't be routed
 func DefaultRouter(spec *spec.Document, api RoutableAPI) Router {
+	router, err := NewRouter(spec, api, RouterOpts{})
+	if err != nil {
+		panic(err)
+	}
+	return router
+}
+
+// NewRouter creates a router for the operations in the spec with the specified options,
+// it returns an error when the matcher rejects a path of the spec
+func NewRouter(spec *spec.Document, api RoutableAPI, opts RouterOpts) (Router, error) {
 	builder := newDefaultRouteBuilder(spec, api)
+	builder.opts = opts
 	if spec != nil {
 		for method, paths := range spec.Operations() {
 			for path, operation := range paths {
@@ -116,7 +208,11 @@ func DefaultRouter(spec *spec.Document, api RoutableAPI) Router {
 			}
 		}
 	}
-	return builder.Build()
+	router, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	return router, nil
 }
 
 type routeEntry struct {
@@ -132,6 +228,7 @@ type routeEntry struct {
 	Formats        strfmt.Registry
 	Binder         *untypedRequestBinder
 	Authenticators map[string]httpkit.Authenticator
+	Limits         RequestLimits
 }
 
 // MatchedRoute represents the route that was matched in this request
@@ -140,29 +237,61 @@ type MatchedRoute struct {
 	Params   RouteParams
 	Consumer httpkit.Consumer
 	Producer httpkit.Producer
+
+	// redirect is the path the request should be redirected to,
+	// when the route was matched by correcting the trailing slash
+	redirect string
 }
 
 func (d *defaultRouter) Lookup(method, path string) (*MatchedRoute, bool) {
-	if router, ok := d.routers[strings.ToUpper(method)]; ok {
-		if m, rp, ok := router.Lookup(path); ok && m != nil {
-			if entry, ok := m.(*routeEntry); ok {
-				var params RouteParams
-				for _, p := range rp {
-					params = append(params, RouteParam{Name: p.Name, Value: p.Value})
-				}
-				return &MatchedRoute{routeEntry: *entry, Params: params}, true
-			}
-		}
+	matcher, ok := d.routers[strings.ToUpper(method)]
+	if !ok {
+		return nil, false
+	}
+	if route, ok := d.match(matcher, path); ok {
+		return route, true
+	}
+	if d.opts.TrailingSlash == TrailingSlashStrict || path == "/" || path == "" {
+		return nil, false
+	}
+
+	other := path + "/"
+	if strings.HasSuffix(path, "/") {
+		other = strings.TrimSuffix(path, "/")
+	}
+	route, ok := d.match(matcher, other)
+	if ok && d.opts.TrailingSlash == TrailingSlashRedirect {
+		route.redirect = other
+	}
+	return route, ok
+}
+
+func (d *defaultRouter) match(matcher PathMatcher, path string) (*MatchedRoute, bool) {
+	key := path
+	if d.opts.CaseInsensitive {
+		key = strings.ToLower(path)
 	}
-	return nil, false
+	m, params, ok := matcher.Match(key)
+	if !ok || m == nil {
+		return nil, false
+	}
+	entry, ok := m.(*routeEntry)
+	if !ok {
+		return nil, false
+	}
+	if d.opts.CaseInsensitive {
+		// the params were captured from the lower cased path
+		params = pathParams(entry.PathPattern, path)
+	}
+	return &MatchedRoute{routeEntry: *entry, Params: params}, true
 }
 
 func (d *defaultRouter) OtherMethods(method, path string) []string {
 	mn := strings.ToUpper(method)
 	var methods []string
-	for k, v := range d.routers {
+	for k := range d.routers {
 		if k != mn {
-			if _, _, ok := v.Lookup(path); ok {
+			if _, ok := d.Lookup(k, path); ok {
 				methods = append(methods, k)
 				continue
 			}
@@ -171,7 +300,12 @@ func (d *defaultRouter) OtherMethods(method, path string) []string {
 	return methods
 }
 
-var pathConverter = regexp.MustCompile(`{(\w+)}`)
+// Routes lists the routes of this router sorted by path and method
+func (d *defaultRouter) Routes() []RouteDescription {
+	routes := make([]RouteDescription, len(d.routes))
+	copy(routes, d.routes)
+	return routes
+}
 
 func (d *defaultRouteBuilder) AddRoute(method, path string, operation *spec.Operation) {
 	mn := strings.ToUpper(method)
@@ -182,7 +316,9 @@ func (d *defaultRouteBuilder) AddRoute(method, path string, operation *spec.Oper
 		parameters := d.spec.ParamsFor(method, path)
 		definitions := d.spec.SecurityDefinitionsFor(operation)
 
-		record := denco.NewRecord(pathConverter.ReplaceAllString(path, ":$1"), &routeEntry{
+		record := denco.NewRecord(path, &routeEntry{
+			PathPattern:    path,
+			BasePath:       d.spec.BasePath(),
 			Operation:      operation,
 			Handler:        handler,
 			Consumes:       consumes,
@@ -193,20 +329,61 @@ func (d *defaultRouteBuilder) AddRoute(method, path string, operation *spec.Oper
 			Formats:        d.api.Formats(),
 			Binder:         newUntypedRequestBinder(parameters, d.spec.Spec(), d.api.Formats()),
 			Authenticators: d.api.AuthenticatorsFor(definitions),
+			Limits:         limitsFromExtensions(operation.Extensions).WithDefaults(d.limits),
 		})
 		d.records[mn] = append(d.records[mn], record)
 	}
 }
 
-func (d *defaultRouteBuilder) Build() *defaultRouter {
-	routers := make(map[string]*denco.Router)
+func (d *defaultRouteBuilder) Build() (*defaultRouter, error) {
+	newMatcher := d.opts.Matcher
+	if newMatcher == nil {
+		newMatcher = DencoMatcher
+	}
+
+	routers := make(map[string]PathMatcher)
+	var routes []RouteDescription
 	for method, records := range d.records {
-		router := denco.New()
-		router.Build(records)
-		routers[method] = router
+		matcher := newMatcher()
+		for _, record := range records {
+			key := record.Key
+			if d.opts.CaseInsensitive {
+				key = strings.ToLower(key)
+			}
+			entry := record.Value.(*routeEntry)
+			if err := matcher.Add(key, record.Value); err != nil {
+				return nil, fmt.Errorf("can't route %s %s: %v", method, entry.PathPattern, err)
+			}
+			routes = append(routes, RouteDescription{
+				Method:      method,
+				PathPattern: entry.PathPattern,
+				OperationID: entry.Operation.ID,
+				Handler:     entry.Handler,
+			})
+		}
+		if err := matcher.Build(); err != nil {
+			return nil, fmt.Errorf("can't route the %s operations: %v", method, err)
+		}
+		routers[method] = matcher
 	}
+	sort.Sort(routeDescriptions(routes))
+
 	return &defaultRouter{
 		spec:    d.spec,
+		api:     d.api,
+		opts:    d.opts,
 		routers: routers,
+		routes:  routes,
+	}, nil
+}
+
+type routeDescriptions []RouteDescription
+
+func (r routeDescriptions) Len() int      { return len(r) }
+func (r routeDescriptions) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r routeDescriptions) Less(i, j int) bool {
+	if r[i].PathPattern != r[j].PathPattern {
+		return r[i].PathPattern < r[j].PathPattern
 	}
+	return r[i].Method < r[j].Method
 }
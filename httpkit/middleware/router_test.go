@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"sort"
@@ -125,6 +126,153 @@ func TestRouterStruct(t *testing.T) {
 	assert.False(t, ok)
 }
 
+func TestRouterTreeMatcher(t *testing.T) {
+	spec, api := petstore.NewAPI(t)
+	router := newTestRouter(t, spec, newRoutableUntypedAPI(spec, api, new(Context)), RouterOpts{Matcher: TreeMatcher})
+
+	methods := router.OtherMethods("post", "/pets/1")
+	assert.Len(t, methods, 2)
+
+	entry, ok := router.Lookup("delete", "/pets/1")
+	if assert.True(t, ok) {
+		assert.Equal(t, RouteParams{{Name: "id", Value: "1"}}, entry.Params)
+		assert.Equal(t, "/pets/{id}", entry.PathPattern)
+	}
+
+	_, ok = router.Lookup("delete", "/pets")
+	assert.False(t, ok)
+
+	_, ok = router.Lookup("get", "/pets/")
+	assert.False(t, ok)
+}
+
+func TestRouterServeMuxMatcher(t *testing.T) {
+	spec, api := petstore.NewAPI(t)
+	router := newTestRouter(t, spec, newRoutableUntypedAPI(spec, api, new(Context)), RouterOpts{Matcher: ServeMuxMatcher})
+
+	entry, ok := router.Lookup("delete", "/pets/1")
+	if assert.True(t, ok) {
+		assert.Equal(t, RouteParams{{Name: "id", Value: "1"}}, entry.Params)
+		assert.Equal(t, "/pets/{id}", entry.PathPattern)
+	}
+
+	_, ok = router.Lookup("delete", "/pets")
+	assert.False(t, ok)
+
+	_, ok = router.Lookup("get", "/pets/")
+	assert.False(t, ok)
+}
+
+func TestRouterMatcherErrors(t *testing.T) {
+	spec, api := petstore.NewAPI(t)
+	routable := newRoutableUntypedAPI(spec, api, new(Context))
+
+	_, err := NewRouter(spec, routable, RouterOpts{Matcher: func() PathMatcher { return rejectingMatcher{} }})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "can't route")
+		assert.Contains(t, err.Error(), "rejected")
+	}
+
+	context := NewContext(spec, api, nil)
+	assert.Panics(t, func() {
+		context.WithRouterOpts(RouterOpts{Matcher: func() PathMatcher { return rejectingMatcher{} }})
+	})
+}
+
+func TestRouterPolicies(t *testing.T) {
+	spec, api := petstore.NewAPI(t)
+	routable := newRoutableUntypedAPI(spec, api, new(Context))
+
+	router := newTestRouter(t, spec, routable, RouterOpts{TrailingSlash: TrailingSlashIgnore})
+	entry, ok := router.Lookup("get", "/pets/")
+	if assert.True(t, ok) {
+		assert.Equal(t, "/pets", entry.PathPattern)
+		assert.Empty(t, entry.redirect)
+	}
+
+	router = newTestRouter(t, spec, routable, RouterOpts{TrailingSlash: TrailingSlashRedirect})
+	entry, ok = router.Lookup("get", "/pets/")
+	if assert.True(t, ok) {
+		assert.Equal(t, "/pets", entry.redirect)
+	}
+	entry, ok = router.Lookup("get", "/pets")
+	if assert.True(t, ok) {
+		assert.Empty(t, entry.redirect)
+	}
+
+	router = newTestRouter(t, spec, routable, RouterOpts{CaseInsensitive: true})
+	entry, ok = router.Lookup("delete", "/PETS/Fido")
+	if assert.True(t, ok) {
+		assert.Equal(t, RouteParams{{Name: "id", Value: "Fido"}}, entry.Params)
+	}
+	_, ok = DefaultRouter(spec, routable).Lookup("delete", "/PETS/Fido")
+	assert.False(t, ok)
+}
+
+func TestRouterRoutes(t *testing.T) {
+	spec, api := petstore.NewAPI(t)
+	context := NewContext(spec, api, nil)
+
+	routes := context.Routes()
+	if assert.Len(t, routes, 4) {
+		assert.Equal(t, "GET", routes[0].Method)
+		assert.Equal(t, "/pets", routes[0].PathPattern)
+		assert.Equal(t, "getAllPets", routes[0].OperationID)
+		assert.NotNil(t, routes[0].Handler)
+		assert.Equal(t, "POST", routes[1].Method)
+		assert.Equal(t, "DELETE", routes[2].Method)
+		assert.Equal(t, "/pets/{id}", routes[2].PathPattern)
+		assert.Equal(t, "GET", routes[3].Method)
+	}
+
+	context = NewContext(spec, api, new(stubRouter))
+	assert.Nil(t, context.Routes())
+}
+
+func TestRouterMiddleware_Policies(t *testing.T) {
+	spec, api := petstore.NewAPI(t)
+	context := NewContext(spec, api, nil).WithRouterOpts(RouterOpts{TrailingSlash: TrailingSlashRedirect})
+	mw := newRouter(context, http.HandlerFunc(terminator))
+
+	recorder := httptest.NewRecorder()
+	request, _ := http.NewRequest("GET", "/api/pets/?limit=10", nil)
+	mw.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusMovedPermanently, recorder.Code)
+	assert.Equal(t, "/api/pets?limit=10", recorder.Header().Get("Location"))
+
+	// not below the base path, but made to the host of the spec
+	recorder = httptest.NewRecorder()
+	request, _ = http.NewRequest("GET", "http://petstore.swagger.wordnik.com:8080/pets", nil)
+	mw.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	request, _ = http.NewRequest("GET", "http://localhost:8080/pets", nil)
+	mw.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+type stubRouter struct{}
+
+func (s *stubRouter) Lookup(method, path string) (*MatchedRoute, bool) { return nil, false }
+func (s *stubRouter) OtherMethods(method, path string) []string        { return nil }
+
+type rejectingMatcher struct{}
+
+func (rejectingMatcher) Add(template string, value interface{}) error {
+	return fmt.Errorf("the template %s was rejected", template)
+}
+func (rejectingMatcher) Build() error                                       { return nil }
+func (rejectingMatcher) Match(path string) (interface{}, RouteParams, bool) { return nil, nil, false }
+
+func newTestRouter(t testing.TB, spec *spec.Document, api RoutableAPI, opts RouterOpts) Router {
+	router, err := NewRouter(spec, api, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return router
+}
+
 func petAPIRouterBuilder(spec *spec.Document, api *untyped.API) *defaultRouteBuilder {
 	builder := newDefaultRouteBuilder(spec, newRoutableUntypedAPI(spec, api, new(Context)))
 	builder.AddRoute("GET", "/pets", spec.AllPaths()["/pets"].Get)
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/vikstrous/go-swagger/httpkit"
+	"github.com/vikstrous/go-swagger/spec"
 	"github.com/vikstrous/go-swagger/strfmt"
 	"github.com/stretchr/testify/assert"
 )
@@ -147,3 +148,86 @@ func TestUntypedBindingTypesForValid(t *testing.T) {
 	assert.EqualValues(t, pb, data["picture"].(strfmt.Base64))
 
 }
+
+func parametersForStyledQuery() map[string]spec.Parameter {
+	filter := spec.QueryParam("filter").Typed("string", "")
+	filter.AddExtension(httpkit.ExtQueryStyle, httpkit.QueryStyleDeepObject)
+	filter.AddExtension(httpkit.ExtQuerySchema, map[string]interface{}{"$ref": "#/definitions/Filter"})
+
+	page := spec.QueryParam("page").Typed("string", "")
+	page.AddExtension(httpkit.ExtQueryStyle, httpkit.QueryStyleJSON)
+	page.AddExtension(httpkit.ExtQuerySchema, new(spec.Schema).SetProperty("offset", *spec.Int64Property()).WithRequired("offset"))
+
+	return map[string]spec.Parameter{"Filter": *filter, "Page": *page}
+}
+
+func specForStyledQuery() *spec.Swagger {
+	limit := spec.Int64Property().WithMaximum(100, false)
+	filter := new(spec.Schema).
+		SetProperty("status", *spec.StringProperty()).
+		SetProperty("limit", *limit).
+		SetProperty("tags", *spec.ArrayProperty(spec.StringProperty()))
+	sw := new(spec.Swagger)
+	sw.AddDefinition("Filter", filter)
+	return sw
+}
+
+func TestUntypedBindingStyledQuery(t *testing.T) {
+	binder := newUntypedRequestBinder(parametersForStyledQuery(), specForStyledQuery(), strfmt.Default)
+
+	req, _ := http.NewRequest("GET", "http://localhost:8002/pets?filter[status]=sold&filter[limit]=10&filter[tags]=dog&filter[tags]=cat&page=%7B%22offset%22%3A20%7D", nil)
+	data := make(map[string]interface{})
+	if assert.NoError(t, binder.Bind(req, nil, httpkit.JSONConsumer(), &data)) {
+		assert.Equal(t, map[string]interface{}{
+			"status": "sold",
+			"limit":  float64(10),
+			"tags":   []interface{}{"dog", "cat"},
+		}, data["filter"])
+		assert.Equal(t, map[string]interface{}{"offset": float64(20)}, data["page"])
+	}
+
+	// optional params that weren't sent aren't validated
+	req, _ = http.NewRequest("GET", "http://localhost:8002/pets", nil)
+	data = make(map[string]interface{})
+	assert.NoError(t, binder.Bind(req, nil, httpkit.JSONConsumer(), &data))
+
+	// the content is validated against the schema of the param
+	for _, query := range []string{
+		"filter[limit]=ten",
+		"filter[limit]=1000&page=%7B%22offset%22%3A1%7D",
+		"page=%7B%22offset%22",
+		"page=%7B%7D",
+		"filter[status]=sold&filter[status][name]=fred",
+	} {
+		req, _ = http.NewRequest("GET", "http://localhost:8002/pets?"+query, nil)
+		data = make(map[string]interface{})
+		assert.Error(t, binder.Bind(req, nil, httpkit.JSONConsumer(), &data), query)
+	}
+
+	// a struct field gets the value decoded into its type
+	var params struct {
+		Filter struct {
+			Status string   `json:"status"`
+			Limit  int32    `json:"limit"`
+			Tags   []string `json:"tags"`
+		}
+		Page map[string]int64
+	}
+	req, _ = http.NewRequest("GET", "http://localhost:8002/pets?filter[status]=sold&filter[limit]=10&filter[tags]=dog", nil)
+	binder = newUntypedRequestBinder(map[string]spec.Parameter{"Filter": parametersForStyledQuery()["Filter"]}, specForStyledQuery(), strfmt.Default)
+	if assert.NoError(t, binder.Bind(req, nil, httpkit.JSONConsumer(), &params)) {
+		assert.Equal(t, "sold", params.Filter.Status)
+		assert.EqualValues(t, 10, params.Filter.Limit)
+		assert.Equal(t, []string{"dog"}, params.Filter.Tags)
+	}
+
+	// a schema that can't be resolved fails the request instead of the binding
+	missing := parametersForStyledQuery()["Filter"]
+	missing.AddExtension(httpkit.ExtQuerySchema, map[string]interface{}{"$ref": "#/definitions/Missing"})
+	binder = newUntypedRequestBinder(map[string]spec.Parameter{"Filter": missing}, specForStyledQuery(), strfmt.Default)
+	data = make(map[string]interface{})
+	err := binder.Bind(req, nil, httpkit.JSONConsumer(), &data)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), httpkit.ExtQuerySchema)
+	}
+}
@@ -13,15 +13,15 @@ import (
 func newValidation(ctx *Context, next http.Handler) http.Handler {
 
 	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
-		matched, _ := ctx.RouteInfo(r)
-		_, result := ctx.BindAndValidate(r, matched)
+		matched, r, _ := ctx.RouteInfoWithRequest(r)
+		_, rCtx, result := ctx.BindAndValidateWithRequest(r, matched)
 
 		if result != nil {
-			ctx.Respond(rw, r, matched.Produces, matched, result)
+			ctx.Respond(rw, rCtx, matched.Produces, matched, result)
 			return
 		}
 
-		next.ServeHTTP(rw, r)
+		next.ServeHTTP(rw, rCtx)
 	})
 }
 
@@ -31,6 +31,20 @@ type validation struct {
 	request *http.Request
 	route   *MatchedRoute
 	bound   map[string]interface{}
+
+	// limit is the error for a request that exceeds the request limits,
+	// it's returned as is instead of in the list of validation errors
+	limit error
+}
+
+func (v *validation) err() error {
+	if v.limit != nil {
+		return v.limit
+	}
+	if len(v.result) > 0 {
+		return errors.CompositeValidationError(v.result...)
+	}
+	return nil
 }
 
 type untypedBinder map[string]interface{}
@@ -72,7 +86,17 @@ func validateRequest(ctx *Context, request *http.Request, route *MatchedRoute) *
 }
 
 func (v *validation) parameters() {
+	limits := v.route.Limits.WithDefaults(v.context.limits)
+	body, err := applyLimits(v.request, limits)
+	if err != nil {
+		v.limit = err
+		return
+	}
 	if result := v.route.Binder.Bind(v.request, v.route.Params, v.route.Consumer, v.bound); result != nil {
+		if body != nil && body.exceeded {
+			v.limit = errors.BodyTooLarge(limits.MaxBodySize)
+			return
+		}
 		if result.Error() == "validation failure list" {
 			for _, e := range result.(*errors.Validation).Value.([]interface{}) {
 				v.result = append(v.result, e.(error))
@@ -85,7 +109,8 @@ func (v *validation) parameters() {
 
 func (v *validation) contentType() {
 	if httpkit.CanHaveBody(v.request.Method) {
-		ct, _, err := v.context.ContentType(v.request)
+		ct, _, rCtx, err := v.context.ContentTypeWithRequest(v.request)
+		v.request = rCtx
 		if err != nil {
 			v.result = append(v.result, err)
 		} else {
@@ -98,7 +123,9 @@ func (v *validation) contentType() {
 }
 
 func (v *validation) responseFormat() {
-	if str := v.context.ResponseFormat(v.request, v.route.Produces); str == "" {
+	str, rCtx := v.context.ResponseFormatWithRequest(v.request, v.route.Produces)
+	v.request = rCtx
+	if str == "" {
 		v.result = append(v.result, errors.InvalidResponseFormat(v.request.Header.Get(httpkit.HeaderAccept), v.route.Produces))
 	}
 }
@@ -93,3 +93,15 @@ func TestValidateContentType(t *testing.T) {
 		}
 	}
 }
+
+func TestBindAndValidateWithoutRoute(t *testing.T) {
+	spec, api := petstore.NewAPI(t)
+	context := NewContext(spec, api, nil)
+
+	request, _ := http.NewRequest("POST", "/pets", nil)
+	data, _, err := context.BindAndValidateWithRequest(request, nil)
+	assert.Nil(t, data)
+	if assert.Error(t, err) {
+		assert.EqualValues(t, http.StatusInternalServerError, err.(errors.Error).Code())
+	}
+}
@@ -0,0 +1,139 @@
+package httpkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// ExtQueryStyle the vendor extension on a query parameter that sets how its value is serialised
+const ExtQueryStyle = "x-query-style"
+
+// ExtQuerySchema the vendor extension with the schema of the value of a structured query parameter.
+// Swagger 2.0 doesn't allow a schema or the object type on a query parameter, so the parameter is
+// declared as a string and its content is described by this schema, which can be a $ref to a definition.
+const ExtQuerySchema = "x-query-schema"
+
+const (
+	// QueryStyleDeepObject an object sent as name[key]=value pairs, nested objects as name[key][nested]=value
+	QueryStyleDeepObject = "deepObject"
+	// QueryStyleJSON a value sent as a json document
+	QueryStyleJSON = "json"
+)
+
+// ReadDeepObject reads the value of a deepObject style parameter.
+// The result is a map per object, the values of a key are all the values that were sent for it.
+// It returns nil when there are no values for the parameter, and an error when a key is sent
+// both as a value and as an object, like filter[a]=1&filter[a][b]=2.
+func ReadDeepObject(values url.Values, name string) (map[string]interface{}, error) {
+	prefix := name + "["
+	var keys []string
+	for key := range values {
+		if strings.HasPrefix(key, prefix) && strings.HasSuffix(key, "]") {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	sort.Strings(keys)
+
+	result := make(map[string]interface{})
+	for _, key := range keys {
+		path := strings.Split(key[len(prefix):len(key)-1], "][")
+		current := result
+		for i, k := range path[:len(path)-1] {
+			switch next := current[k].(type) {
+			case nil:
+				obj := make(map[string]interface{})
+				current[k] = obj
+				current = obj
+			case map[string]interface{}:
+				current = next
+			default:
+				return nil, fmt.Errorf("%s is sent both as a value and as an object", deepObjectKey(name, path[:i+1]))
+			}
+		}
+		last := path[len(path)-1]
+		if _, ok := current[last]; ok {
+			return nil, fmt.Errorf("%s is sent both as a value and as an object", deepObjectKey(name, path))
+		}
+		current[last] = values[key]
+	}
+	return result, nil
+}
+
+func deepObjectKey(name string, path []string) string {
+	return name + "[" + strings.Join(path, "][") + "]"
+}
+
+// DeepObjectValues serialises a map or a struct as a deepObject style parameter,
+// the values can be set on a request as query params.
+// Struct fields are named after their json names and arrays are sent as repeated values.
+func DeepObjectValues(name string, value interface{}) (url.Values, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var data interface{}
+	if err := dec.Decode(&data); err != nil {
+		return nil, err
+	}
+
+	result := make(url.Values)
+	if data == nil {
+		return result, nil
+	}
+	obj, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%T can't be sent as a deepObject parameter, it's not an object", value)
+	}
+	if err := writeDeepObject(result, name, obj); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func writeDeepObject(values url.Values, prefix string, obj map[string]interface{}) error {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		key := prefix + "[" + k + "]"
+		switch v := obj[k].(type) {
+		case nil:
+		case map[string]interface{}:
+			if err := writeDeepObject(values, key, v); err != nil {
+				return err
+			}
+		case []interface{}:
+			for _, item := range v {
+				switch item.(type) {
+				case map[string]interface{}, []interface{}:
+					return fmt.Errorf("%s: arrays of objects or arrays can't be sent as a deepObject parameter", key)
+				}
+				values.Add(key, fmt.Sprint(item))
+			}
+		default:
+			values.Add(key, fmt.Sprint(v))
+		}
+	}
+	return nil
+}
+
+// JSONQueryValue serialises a value as a json encoded parameter
+func JSONQueryValue(value interface{}) (string, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
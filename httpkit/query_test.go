@@ -0,0 +1,74 @@
+package httpkit
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadDeepObject(t *testing.T) {
+	values, _ := url.ParseQuery("filter[status]=sold&filter[tag]=dog&filter[tag]=cat&filter[owner][name]=fred&limit=10&filters=x")
+	obj, err := ReadDeepObject(values, "filter")
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{
+			"status": []string{"sold"},
+			"tag":    []string{"dog", "cat"},
+			"owner":  map[string]interface{}{"name": []string{"fred"}},
+		}, obj)
+	}
+
+	obj, err = ReadDeepObject(values, "sort")
+	assert.NoError(t, err)
+	assert.Nil(t, obj)
+
+	// a key can't be a value and an object, whatever the order of the keys
+	for _, query := range []string{"f[a]=1&f[a][b]=2", "f[a][b]=2&f[a]=1", "f[a][b]=2&f[a][b][c]=3"} {
+		values, _ = url.ParseQuery(query)
+		_, err = ReadDeepObject(values, "f")
+		assert.Error(t, err, query)
+	}
+}
+
+func TestDeepObjectValues(t *testing.T) {
+	filter := struct {
+		Status string   `json:"status"`
+		Tags   []string `json:"tags"`
+		Limit  int64    `json:"limit"`
+		Owner  *struct {
+			Name string `json:"name"`
+		} `json:"owner"`
+	}{Status: "sold", Tags: []string{"dog", "cat"}, Limit: 12345678901}
+
+	values, err := DeepObjectValues("filter", filter)
+	if assert.NoError(t, err) {
+		assert.Equal(t, url.Values{
+			"filter[status]": {"sold"},
+			"filter[tags]":   {"dog", "cat"},
+			"filter[limit]":  {"12345678901"},
+		}, values)
+		obj, err := ReadDeepObject(values, "filter")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"status": []string{"sold"},
+			"tags":   []string{"dog", "cat"},
+			"limit":  []string{"12345678901"},
+		}, obj)
+	}
+
+	values, err = DeepObjectValues("filter", map[string]interface{}{"owner": map[string]string{"name": "fred"}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, url.Values{"filter[owner][name]": {"fred"}}, values)
+	}
+
+	_, err = DeepObjectValues("filter", []string{"dog"})
+	assert.Error(t, err)
+	_, err = DeepObjectValues("filter", map[string]interface{}{"owners": []map[string]string{{"name": "fred"}}})
+	assert.Error(t, err)
+}
+
+func TestJSONQueryValue(t *testing.T) {
+	v, err := JSONQueryValue(map[string]interface{}{"status": []string{"sold"}})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"status":["sold"]}`, v)
+}
@@ -0,0 +1,82 @@
+package httpkit
+
+import (
+	"encoding"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+)
+
+// TextConsumer creates a new text consumer, it reads the body into a string,
+// a byte slice or an encoding.TextUnmarshaler
+func TextConsumer() Consumer {
+	return ConsumerFunc(func(reader io.Reader, data interface{}) error {
+		b, err := ioutil.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+
+		switch target := data.(type) {
+		case encoding.TextUnmarshaler:
+			return target.UnmarshalText(b)
+		case *string:
+			*target = string(b)
+			return nil
+		case *[]byte:
+			*target = b
+			return nil
+		case *interface{}:
+			*target = string(b)
+			return nil
+		}
+
+		val := reflect.ValueOf(data)
+		if val.Kind() == reflect.Ptr && val.Elem().Kind() == reflect.String {
+			val.Elem().SetString(string(b))
+			return nil
+		}
+		return fmt.Errorf("%T is not a supported type for the text consumer", data)
+	})
+}
+
+// TextProducer creates a new text producer, it writes strings, byte slices, errors
+// and values that implement encoding.TextMarshaler or fmt.Stringer
+func TextProducer() Producer {
+	return ProducerFunc(func(writer io.Writer, data interface{}) error {
+		var b []byte
+		switch v := data.(type) {
+		case nil:
+			return nil
+		case string:
+			b = []byte(v)
+		case *string:
+			b = []byte(*v)
+		case []byte:
+			b = v
+		case encoding.TextMarshaler:
+			t, err := v.MarshalText()
+			if err != nil {
+				return err
+			}
+			b = t
+		case error:
+			b = []byte(v.Error())
+		case fmt.Stringer:
+			b = []byte(v.String())
+		default:
+			val := reflect.Indirect(reflect.ValueOf(data))
+			switch val.Kind() {
+			case reflect.String, reflect.Bool,
+				reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+				reflect.Float32, reflect.Float64:
+				b = []byte(fmt.Sprint(val.Interface()))
+			default:
+				return fmt.Errorf("%T is not a supported type for the text producer", data)
+			}
+		}
+		_, err := writer.Write(b)
+		return err
+	})
+}
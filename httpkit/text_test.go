@@ -0,0 +1,60 @@
+package httpkit
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var consProdText = `The quick brown fox jumped over the lazy dog.`
+
+func TestTextConsumer(t *testing.T) {
+	cons := TextConsumer()
+
+	var str string
+	err := cons.Consume(bytes.NewBufferString(consProdText), &str)
+	assert.NoError(t, err)
+	assert.Equal(t, consProdText, str)
+
+	var b []byte
+	err = cons.Consume(bytes.NewBufferString(consProdText), &b)
+	assert.NoError(t, err)
+	assert.Equal(t, consProdText, string(b))
+
+	var ip net.IP
+	err = cons.Consume(bytes.NewBufferString("127.0.0.1"), &ip)
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", ip.String())
+
+	var data struct{ Name string }
+	err = cons.Consume(bytes.NewBufferString(consProdText), &data)
+	assert.Error(t, err)
+}
+
+func TestTextProducer(t *testing.T) {
+	prod := TextProducer()
+
+	for _, data := range []interface{}{consProdText, []byte(consProdText), errors.New(consProdText)} {
+		rw := httptest.NewRecorder()
+		err := prod.Produce(rw, data)
+		assert.NoError(t, err)
+		assert.Equal(t, consProdText, rw.Body.String())
+	}
+
+	rw := httptest.NewRecorder()
+	err := prod.Produce(rw, net.ParseIP("127.0.0.1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", rw.Body.String())
+
+	rw = httptest.NewRecorder()
+	err = prod.Produce(rw, 42)
+	assert.NoError(t, err)
+	assert.Equal(t, "42", rw.Body.String())
+
+	err = prod.Produce(httptest.NewRecorder(), struct{ Name string }{"Somebody"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,194 @@
+package httpkit
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// XMLConsumer creates a new XML consumer.
+// Types with xml struct tags are decoded with encoding/xml, this is how the
+// names, namespaces, attributes and wrapped arrays from the spec are honoured.
+// An interface{} or map[string]interface{} target gets a map per element,
+// repeated elements become slices and elements with only text become strings.
+func XMLConsumer() Consumer {
+	return ConsumerFunc(func(reader io.Reader, data interface{}) error {
+		dec := xml.NewDecoder(reader)
+		switch target := data.(type) {
+		case *interface{}:
+			value, err := decodeXMLDocument(dec)
+			if err != nil {
+				return err
+			}
+			*target = value
+			return nil
+		case *map[string]interface{}:
+			value, err := decodeXMLDocument(dec)
+			if err != nil {
+				return err
+			}
+			m, ok := value.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("xml document has no child elements")
+			}
+			*target = m
+			return nil
+		}
+		return dec.Decode(data)
+	})
+}
+
+// XMLProducer creates a new XML producer.
+// Maps and slices are written as elements inside a root element,
+// the keys of a map become the element names.
+func XMLProducer() Producer {
+	return ProducerFunc(func(writer io.Writer, data interface{}) error {
+		enc := xml.NewEncoder(writer)
+		if isGenericXML(data) {
+			if err := encodeXMLRoot(enc, data); err != nil {
+				return err
+			}
+			return enc.Flush()
+		}
+		return enc.Encode(data)
+	})
+}
+
+func isGenericXML(data interface{}) bool {
+	if _, ok := data.(xml.Marshaler); ok {
+		return false
+	}
+	switch reflect.Indirect(reflect.ValueOf(data)).Kind() {
+	case reflect.Map, reflect.Interface:
+		return true
+	case reflect.Slice, reflect.Array:
+		_, isBytes := data.([]byte)
+		return !isBytes
+	}
+	return false
+}
+
+func decodeXMLDocument(dec *xml.Decoder) (interface{}, error) {
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return decodeXMLElement(dec, start)
+		}
+	}
+}
+
+func decodeXMLElement(dec *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	children := make(map[string]interface{})
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+		children[attr.Name.Local] = attr.Value
+	}
+
+	var text bytes.Buffer
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			value, err := decodeXMLElement(dec, t)
+			if err != nil {
+				return nil, err
+			}
+			name := t.Name.Local
+			switch existing := children[name].(type) {
+			case nil:
+				children[name] = value
+			case []interface{}:
+				children[name] = append(existing, value)
+			default:
+				children[name] = []interface{}{existing, value}
+			}
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			if len(children) == 0 {
+				return strings.TrimSpace(text.String()), nil
+			}
+			return children, nil
+		}
+	}
+}
+
+// encodeXMLRoot writes a generic value as the root element,
+// the items of a top level array have no name so they're written as item elements
+func encodeXMLRoot(enc *xml.Encoder, data interface{}) error {
+	val := reflect.Indirect(reflect.ValueOf(data))
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return encodeXMLElement(enc, "root", data)
+	}
+
+	start := xml.StartElement{Name: xml.Name{Local: "root"}}
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+	for i := 0; i < val.Len(); i++ {
+		if err := encodeXMLElement(enc, "item", val.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return enc.EncodeToken(start.End())
+}
+
+func encodeXMLElement(enc *xml.Encoder, name string, data interface{}) error {
+	val := reflect.ValueOf(data)
+	for val.IsValid() && (val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface) {
+		if val.IsNil() {
+			return nil
+		}
+		val = val.Elem()
+	}
+	if !val.IsValid() {
+		return nil
+	}
+
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	switch val.Kind() {
+	case reflect.Map:
+		if err := enc.EncodeToken(start); err != nil {
+			return err
+		}
+		keys := val.MapKeys()
+		sort.Sort(mapKeys(keys))
+		for _, k := range keys {
+			if err := encodeXMLElement(enc, fmt.Sprint(k.Interface()), val.MapIndex(k).Interface()); err != nil {
+				return err
+			}
+		}
+		return enc.EncodeToken(start.End())
+	case reflect.Slice, reflect.Array:
+		if _, ok := data.([]byte); ok {
+			return enc.EncodeElement(data, start)
+		}
+		for i := 0; i < val.Len(); i++ {
+			if err := encodeXMLElement(enc, name, val.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return enc.EncodeElement(val.Interface(), start)
+}
+
+type mapKeys []reflect.Value
+
+func (m mapKeys) Len() int      { return len(m) }
+func (m mapKeys) Swap(i, j int) { m[i], m[j] = m[j], m[i] }
+func (m mapKeys) Less(i, j int) bool {
+	return fmt.Sprint(m[i].Interface()) < fmt.Sprint(m[j].Interface())
+}
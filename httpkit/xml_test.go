@@ -0,0 +1,62 @@
+package httpkit
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type xmlPet struct {
+	XMLName xml.Name `xml:"http://example.com/schema pet"`
+	ID      int64    `xml:"id,attr"`
+	Name    string   `xml:"name"`
+	Tags    []string `xml:"tags>tag"`
+}
+
+var consProdXML = `<pet xmlns="http://example.com/schema" id="1"><name>Somebody</name><tags><tag>dog</tag><tag>brown</tag></tags></pet>`
+
+func TestXMLConsumer(t *testing.T) {
+	cons := XMLConsumer()
+	var data xmlPet
+	err := cons.Consume(bytes.NewBufferString(consProdXML), &data)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, data.ID)
+	assert.Equal(t, "Somebody", data.Name)
+	assert.Equal(t, []string{"dog", "brown"}, data.Tags)
+
+	var generic interface{}
+	err = cons.Consume(bytes.NewBufferString(consProdXML), &generic)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"id":   "1",
+		"name": "Somebody",
+		"tags": map[string]interface{}{"tag": []interface{}{"dog", "brown"}},
+	}, generic)
+
+	var m map[string]interface{}
+	err = cons.Consume(bytes.NewBufferString(`<root>text</root>`), &m)
+	assert.Error(t, err)
+}
+
+func TestXMLProducer(t *testing.T) {
+	prod := XMLProducer()
+	data := xmlPet{ID: 1, Name: "Somebody", Tags: []string{"dog", "brown"}}
+
+	rw := httptest.NewRecorder()
+	err := prod.Produce(rw, data)
+	assert.NoError(t, err)
+	assert.Equal(t, consProdXML, rw.Body.String())
+
+	rw = httptest.NewRecorder()
+	err = prod.Produce(rw, map[string]interface{}{"name": "Somebody", "id": 1, "tags": []string{"dog", "brown"}})
+	assert.NoError(t, err)
+	assert.Equal(t, `<root><id>1</id><name>Somebody</name><tags>dog</tags><tags>brown</tags></root>`, rw.Body.String())
+
+	rw = httptest.NewRecorder()
+	err = prod.Produce(rw, []interface{}{"dog", map[string]string{"name": "Somebody"}})
+	assert.NoError(t, err)
+	assert.Equal(t, `<root><item>dog</item><item><name>Somebody</name></item></root>`, rw.Body.String())
+}
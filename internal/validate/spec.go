@@ -1,12 +1,15 @@
 package validate
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"regexp"
 	"strings"
 
 	"github.com/vikstrous/go-swagger/errors"
+	"github.com/vikstrous/go-swagger/httpkit"
+	"github.com/vikstrous/go-swagger/jsonpointer"
 	"github.com/vikstrous/go-swagger/spec"
 	"github.com/vikstrous/go-swagger/strfmt"
 )
@@ -57,6 +60,7 @@ func (s *SpecValidator) Validate(data interface{}) (errs *Result, warnings *Resu
 
 	errs.Merge(s.validateDuplicatePropertyNames())         // error -
 	errs.Merge(s.validateParameters())                     // error -
+	errs.Merge(s.validateQueryStyles())                    // error -
 	errs.Merge(s.validateItems())                          // error -
 	errs.Merge(s.validateRequiredDefinitions())            // error -
 	errs.Merge(s.validateDefaultValueValidAgainstSchema()) // error -
@@ -88,7 +92,7 @@ func (s *SpecValidator) validateDuplicatePropertyNames() *Result {
 
 		ancs := s.validateCircularAncestry(k, sch, knownanc)
 		if len(ancs) > 0 {
-			res.AddErrors(errors.New(422, "definition %q has circular ancestry: %v", k, ancs))
+			res.AddErrors(specError(RuleCircularAncestry, definitionPointer(k)+"/allOf", "definition %q has circular ancestry: %v", k, ancs))
 			return res
 		}
 
@@ -99,7 +103,7 @@ func (s *SpecValidator) validateDuplicatePropertyNames() *Result {
 			for _, v := range dups {
 				pns = append(pns, v.Definition+"."+v.Name)
 			}
-			res.AddErrors(errors.New(422, "definition %q contains duplicate properties: %v", k, pns))
+			res.AddErrors(specError(RuleDuplicateProperty, definitionPointer(k), "definition %q contains duplicate properties: %v", k, pns))
 		}
 
 	}
@@ -182,16 +186,18 @@ func (s *SpecValidator) validateItems() *Result {
 	for method, pi := range s.spec.Operations() {
 		for path, op := range pi {
 			for _, param := range s.spec.ParamsFor(method, path) {
+				pointer := s.paramPointer(method, path, param.Name, param.In)
 				if param.TypeName() == "array" && param.ItemsTypeName() == "" {
-					res.AddErrors(errors.New(422, "param %q for %q is a collection without an element type", param.Name, op.ID))
+					res.AddErrors(specError(RuleArrayItems, pointer, "param %q for %q is a collection without an element type", param.Name, op.ID))
 					continue
 				}
 				if param.In != "body" {
 					if param.Items != nil {
 						items := param.Items
 						for items.TypeName() == "array" {
+							pointer += "/items"
 							if items.ItemsTypeName() == "" {
-								res.AddErrors(errors.New(422, "param %q for %q is a collection without an element type", param.Name, op.ID))
+								res.AddErrors(specError(RuleArrayItems, pointer, "param %q for %q is a collection without an element type", param.Name, op.ID))
 								break
 							}
 							items = items.Items
@@ -199,30 +205,31 @@ func (s *SpecValidator) validateItems() *Result {
 					}
 				} else {
 					if err := s.validateSchemaItems(*param.Schema, fmt.Sprintf("body param %q", param.Name), op.ID); err != nil {
-						res.AddErrors(err)
+						res.AddErrors(specError(RuleArrayItems, pointer+"/schema", "%s", err.Error()))
 					}
 				}
 			}
 
-			var responses []spec.Response
+			responses := make(map[string]spec.Response)
 			if op.Responses != nil {
+				opPtr := operationPointer(method, path)
 				if op.Responses.Default != nil {
-					responses = append(responses, *op.Responses.Default)
+					responses[opPtr+"/responses/default"] = *op.Responses.Default
 				}
-				for _, v := range op.Responses.StatusCodeResponses {
-					responses = append(responses, v)
+				for k, v := range op.Responses.StatusCodeResponses {
+					responses[fmt.Sprintf("%s/responses/%d", opPtr, k)] = v
 				}
 			}
 
-			for _, resp := range responses {
+			for pointer, resp := range responses {
 				for hn, hv := range resp.Headers {
 					if hv.TypeName() == "array" && hv.ItemsTypeName() == "" {
-						res.AddErrors(errors.New(422, "header %q for %q is a collection without an element type", hn, op.ID))
+						res.AddErrors(specError(RuleArrayItems, pointer+"/headers/"+jsonpointer.Escape(hn), "header %q for %q is a collection without an element type", hn, op.ID))
 					}
 				}
 				if resp.Schema != nil {
 					if err := s.validateSchemaItems(*resp.Schema, "response body", op.ID); err != nil {
-						res.AddErrors(err)
+						res.AddErrors(specError(RuleArrayItems, pointer+"/schema", "%s", err.Error()))
 					}
 				}
 			}
@@ -256,7 +263,28 @@ func (s *SpecValidator) validateUniqueSecurityScopes() *Result {
 	// Each authorization/security reference should contain only unique scopes.
 	// (Example: For an oauth2 authorization/security requirement, when listing the required scopes,
 	// each scope should only be listed once.)
-	return nil
+	res := new(Result)
+	check := func(pointer string, requirements []map[string][]string) {
+		for i, requirement := range requirements {
+			for name, scopes := range requirement {
+				seen := make(map[string]struct{}, len(scopes))
+				for _, scope := range scopes {
+					if _, ok := seen[scope]; ok {
+						res.AddErrors(specError(RuleUniqueScopes, fmt.Sprintf("%s/security/%d/%s", pointer, i, jsonpointer.Escape(name)), "security requirement %q lists scope %q more than once", name, scope))
+					}
+					seen[scope] = struct{}{}
+				}
+			}
+		}
+	}
+
+	check("", s.spec.Spec().Security)
+	for method, pi := range s.spec.Operations() {
+		for path, op := range pi {
+			check(operationPointer(method, path), op.Security)
+		}
+	}
+	return res
 }
 
 func (s *SpecValidator) validateUniqueScopesSecurityDefinitions() *Result {
@@ -264,7 +292,7 @@ func (s *SpecValidator) validateUniqueScopesSecurityDefinitions() *Result {
 	return nil
 }
 
-func (s *SpecValidator) validatePathParamPresence(path string, fromPath, fromOperation []string) *Result {
+func (s *SpecValidator) validatePathParamPresence(method, path string, fromPath, fromOperation []string) *Result {
 	// Each defined operation path parameters must correspond to a named element in the API's path pattern.
 	// (For example, you cannot have a path parameter named id for the following path /pets/{petId} but you must have a path parameter named petId.)
 	res := new(Result)
@@ -277,7 +305,7 @@ func (s *SpecValidator) validatePathParamPresence(path string, fromPath, fromOpe
 			}
 		}
 		if !matched {
-			res.Errors = append(res.Errors, errors.New(422, "path param %q has no parameter definition", l))
+			res.Errors = append(res.Errors, specError(RulePathParam, operationPointer(method, path), "path param %q has no parameter definition", l))
 		}
 	}
 
@@ -290,7 +318,7 @@ func (s *SpecValidator) validatePathParamPresence(path string, fromPath, fromOpe
 			}
 		}
 		if !matched {
-			res.AddErrors(errors.New(422, "path param %q is not present in path %q", p, path))
+			res.AddErrors(specError(RulePathParam, s.paramPointer(method, path, p, "path"), "path param %q is not present in path %q", p, path))
 		}
 	}
 
@@ -299,7 +327,29 @@ func (s *SpecValidator) validatePathParamPresence(path string, fromPath, fromOpe
 
 func (s *SpecValidator) validateReferenced() *Result {
 	// Each referenceable definition must have references.
-	return nil
+	res := new(Result)
+	// the schema validators expand refs in place, so look for references in the document as it was loaded
+	sw := new(spec.Swagger)
+	if err := json.Unmarshal(s.spec.Raw(), sw); err != nil {
+		res.AddErrors(err)
+		return res
+	}
+	check := func(section, kind, name string) {
+		pointer := "/" + section + "/" + jsonpointer.Escape(name)
+		if len(sw.ReferencesTo("#"+pointer)) == 0 {
+			res.AddErrors(specError(RuleUnused, pointer, "%s %q is not used anywhere", kind, name))
+		}
+	}
+	for k := range sw.Definitions {
+		check("definitions", "definition", k)
+	}
+	for k := range sw.Parameters {
+		check("parameters", "parameter", k)
+	}
+	for k := range sw.Responses {
+		check("responses", "response", k)
+	}
+	return res
 }
 
 func (s *SpecValidator) validateRequiredDefinitions() *Result {
@@ -328,7 +378,7 @@ func (s *SpecValidator) validateRequiredDefinitions() *Result {
 				}
 			}
 
-			res.AddErrors(errors.New(422, "%q is present in required but not defined as property in defintion %q", pn, d))
+			res.AddErrors(specError(RuleRequiredProperty, definitionPointer(d)+"/required", "%q is present in required but not defined as property in defintion %q", pn, d))
 		}
 	}
 	return res
@@ -354,7 +404,7 @@ func (s *SpecValidator) validateParameters() *Result {
 			}
 			knownPath := strings.Join(knowns, "/")
 			if orig, ok := knownPaths[knownPath]; ok {
-				res.AddErrors(errors.New(422, "path %s overlaps with %s", path, orig))
+				res.AddErrors(specError(RulePathOverlap, "/paths/"+jsonpointer.Escape(path), "path %s overlaps with %s", path, orig))
 			} else {
 				knownPaths[knownPath] = path
 			}
@@ -363,14 +413,14 @@ func (s *SpecValidator) validateParameters() *Result {
 			var firstBodyParam string
 			sw := s.spec.Spec()
 			var paramNames []string
-			for _, ppr := range op.Parameters {
+			for i, ppr := range op.Parameters {
 				pr := ppr
 				// pretty.Println("before", pr)
 				if pr.Ref.String() != "" {
 					obj, _, err := pr.Ref.GetPointer().Get(sw)
 					if err != nil {
 						log.Println(err)
-						res.AddErrors(err)
+						res.AddErrors(specError(RuleInvalidRef, fmt.Sprintf("%s/parameters/%d", operationPointer(method, path), i), "%s", err.Error()))
 						break
 					}
 					pr = obj.(spec.Parameter)
@@ -384,7 +434,7 @@ func (s *SpecValidator) validateParameters() *Result {
 
 				_, ok = pnames[pr.Name]
 				if ok {
-					res.AddErrors(errors.New(422, "duplicate parameter name %q for %q in operation %q", pr.Name, pr.In, op.ID))
+					res.AddErrors(specError(RuleDuplicateParam, fmt.Sprintf("%s/parameters/%d", operationPointer(method, path), i), "duplicate parameter name %q for %q in operation %q", pr.Name, pr.In, op.ID))
 				}
 				pnames[pr.Name] = struct{}{}
 			}
@@ -395,7 +445,7 @@ func (s *SpecValidator) validateParameters() *Result {
 				if ppr.Ref.String() != "" {
 					obj, _, err := ppr.Ref.GetPointer().Get(sw)
 					if err != nil {
-						res.AddErrors(err)
+						res.AddErrors(specError(RuleInvalidRef, operationPointer(method, path), "%s", err.Error()))
 						break
 					}
 					pr = obj.(spec.Parameter)
@@ -404,7 +454,7 @@ func (s *SpecValidator) validateParameters() *Result {
 
 				if pr.In == "body" {
 					if firstBodyParam != "" {
-						res.AddErrors(errors.New(422, "operation %q has more than 1 body param (accepted: %q, dropped: %q)", op.ID, firstBodyParam, pr.Name))
+						res.AddErrors(specError(RuleMultipleBodyParams, s.paramPointer(method, path, pr.Name, pr.In), "operation %q has more than 1 body param (accepted: %q, dropped: %q)", op.ID, firstBodyParam, pr.Name))
 					}
 					firstBodyParam = pr.Name
 				}
@@ -413,7 +463,107 @@ func (s *SpecValidator) validateParameters() *Result {
 					paramNames = append(paramNames, pr.Name)
 				}
 			}
-			res.Merge(s.validatePathParamPresence(path, fromPath, paramNames))
+			res.Merge(s.validatePathParamPresence(method, path, fromPath, paramNames))
+		}
+	}
+	return res
+}
+
+// QuerySchema reads the schema of a structured query param from the x-query-schema vendor extension
+// and resolves its refs against the spec, it returns nil when the param has no schema
+func QuerySchema(param *spec.Parameter, root *spec.Swagger) (*spec.Schema, error) {
+	raw, ok := param.Extensions[httpkit.ExtQuerySchema]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	schema := new(spec.Schema)
+	if err := json.Unmarshal(b, schema); err != nil {
+		return nil, fmt.Errorf("%s is not a schema: %v", httpkit.ExtQuerySchema, err)
+	}
+
+	var doc interface{} = root
+	if root == nil {
+		doc = schema
+	}
+	if err := spec.ExpandSchema(schema, doc, nil); err != nil {
+		return nil, err
+	}
+	// the expander leaves the refs it can't resolve in place
+	if ref := unresolvedRef(schema); ref != "" {
+		return nil, fmt.Errorf("%s: can't resolve the reference %s", httpkit.ExtQuerySchema, ref)
+	}
+	return schema, nil
+}
+
+func unresolvedRef(schema *spec.Schema) string {
+	if schema == nil {
+		return ""
+	}
+	if schema.Ref.String() != "" {
+		return schema.Ref.String()
+	}
+	nested := []*spec.Schema{schema.Not}
+	if schema.Items != nil {
+		nested = append(nested, schema.Items.Schema)
+		for i := range schema.Items.Schemas {
+			nested = append(nested, &schema.Items.Schemas[i])
+		}
+	}
+	if schema.AdditionalProperties != nil {
+		nested = append(nested, schema.AdditionalProperties.Schema)
+	}
+	for i := range schema.AllOf {
+		nested = append(nested, &schema.AllOf[i])
+	}
+	for k := range schema.Properties {
+		prop := schema.Properties[k]
+		nested = append(nested, &prop)
+	}
+	for _, sch := range nested {
+		if ref := unresolvedRef(sch); ref != "" {
+			return ref
+		}
+	}
+	return ""
+}
+
+func (s *SpecValidator) validateQueryStyles() *Result {
+	// a structured query param has a known style and the schema of its content is valid
+	res := new(Result)
+	for method, pi := range s.spec.Operations() {
+		for path, op := range pi {
+			for _, param := range s.spec.ParamsFor(method, path) {
+				style, hasStyle := param.Extensions.GetString(httpkit.ExtQueryStyle)
+				_, hasSchema := param.Extensions[httpkit.ExtQuerySchema]
+				if !hasStyle && !hasSchema {
+					continue
+				}
+				pointer := s.paramPointer(method, path, param.Name, param.In)
+				if param.In != "query" {
+					res.AddErrors(specError(RuleQueryStyle, pointer, "param %q for %q is in %s, only query params can have a query style", param.Name, op.ID, param.In))
+					continue
+				}
+				if !hasStyle {
+					res.AddErrors(specError(RuleQueryStyle, pointer, "param %q for %q has a %s but no %s", param.Name, op.ID, httpkit.ExtQuerySchema, httpkit.ExtQueryStyle))
+					continue
+				}
+				if !strings.EqualFold(style, httpkit.QueryStyleDeepObject) && !strings.EqualFold(style, httpkit.QueryStyleJSON) {
+					res.AddErrors(specError(RuleQueryStyle, pointer, "param %q for %q has an unknown query style %q", param.Name, op.ID, style))
+					continue
+				}
+				schema, err := QuerySchema(&param, s.spec.Spec())
+				if err != nil {
+					res.AddErrors(specError(RuleQueryStyle, pointer, "param %q for %q: %v", param.Name, op.ID, err))
+					continue
+				}
+				if schema != nil && strings.EqualFold(style, httpkit.QueryStyleDeepObject) && len(schema.Type) > 0 && !schema.Type.Contains("object") {
+					res.AddErrors(specError(RuleQueryStyle, pointer, "param %q for %q is a deepObject, its schema has to be an object", param.Name, op.ID))
+				}
+			}
 		}
 	}
 	return res
@@ -434,7 +584,7 @@ func (s *SpecValidator) validateReferencesValid() *Result {
 	res := new(Result)
 	exp, err := s.spec.Expanded()
 	if err != nil {
-		res.AddErrors(err)
+		res.AddErrors(specError(RuleInvalidRef, "", "%s", err.Error()))
 	}
 	s.expanded = exp
 	return res
@@ -467,14 +617,15 @@ func (s *SpecValidator) validateResponseExample(path string, r *spec.Response) *
 func (s *SpecValidator) validateExamplesValidAgainstSchema() *Result {
 	res := new(Result)
 
-	for _, pathItem := range s.spec.Operations() {
+	for method, pathItem := range s.spec.Operations() {
 		for path, op := range pathItem {
+			pointer := operationPointer(method, path) + "/responses/"
 			if op.Responses.Default != nil {
 				dr := op.Responses.Default
-				res.Merge(s.validateResponseExample(path, dr))
+				res.Merge(withRule(s.validateResponseExample(path, dr), RuleInvalidExample, pointer+"default/examples"))
 			}
-			for _, r := range op.Responses.StatusCodeResponses {
-				res.Merge(s.validateResponseExample(path, &r))
+			for code, r := range op.Responses.StatusCodeResponses {
+				res.Merge(withRule(s.validateResponseExample(path, &r), RuleInvalidExample, fmt.Sprintf("%s%d/examples", pointer, code)))
 			}
 		}
 	}
@@ -492,12 +643,13 @@ func (s *SpecValidator) validateDefaultValueValidAgainstSchema() *Result {
 		for path, op := range pathItem {
 			// parameters
 			for _, pr := range s.spec.ParamsFor(method, path) {
+				pointer := s.paramPointer(method, path, pr.Name, pr.In)
 				// expand ref is necessary
 				param := pr
 				if pr.Ref.String() != "" {
 					obj, _, err := pr.Ref.GetPointer().Get(s.spec.Spec())
 					if err != nil {
-						res.AddErrors(err)
+						res.AddErrors(specError(RuleInvalidRef, pointer, "%s", err.Error()))
 						break
 					}
 					param = obj.(spec.Parameter)
@@ -506,36 +658,34 @@ func (s *SpecValidator) validateDefaultValueValidAgainstSchema() *Result {
 				if param.Default != nil && param.Schema == nil {
 					//fmt.Println(param.Name, "in", param.In, "has a default without a schema")
 					// check param valid
-					res.Merge(NewParamValidator(&param, s.KnownFormats).Validate(param.Default))
+					res.Merge(withRule(NewParamValidator(&param, s.KnownFormats).Validate(param.Default), RuleInvalidDefault, pointer+"/default"))
 				}
 
 				if param.Items != nil {
-					res.Merge(s.validateDefaultValueItemsAgainstSchema(param.Name, param.In, &param, param.Items))
+					res.Merge(s.validateDefaultValueItemsAgainstSchema(param.Name, param.In, pointer+"/items", &param, param.Items))
 				}
 
 				if param.Schema != nil {
-					res.Merge(s.validateDefaultValueSchemaAgainstSchema(param.Name, param.In, param.Schema))
+					res.Merge(s.validateDefaultValueSchemaAgainstSchema(param.Name, param.In, pointer+"/schema", param.Schema))
 				}
 			}
 
+			responses := make(map[string]spec.Response)
+			opPtr := operationPointer(method, path)
 			if op.Responses.Default != nil {
-				dr := op.Responses.Default
-				for nm, h := range dr.Headers {
-					if h.Default != nil {
-						res.Merge(NewHeaderValidator(nm, &h, s.KnownFormats).Validate(h.Default))
-					}
-					if h.Items != nil {
-						res.Merge(s.validateDefaultValueItemsAgainstSchema(nm, "header", &h, h.Items))
-					}
-				}
+				responses[opPtr+"/responses/default"] = *op.Responses.Default
+			}
+			for code, r := range op.Responses.StatusCodeResponses {
+				responses[fmt.Sprintf("%s/responses/%d", opPtr, code)] = r
 			}
-			for _, r := range op.Responses.StatusCodeResponses {
+			for pointer, r := range responses {
 				for nm, h := range r.Headers {
+					hPtr := pointer + "/headers/" + jsonpointer.Escape(nm)
 					if h.Default != nil {
-						res.Merge(NewHeaderValidator(nm, &h, s.KnownFormats).Validate(h.Default))
+						res.Merge(withRule(NewHeaderValidator(nm, &h, s.KnownFormats).Validate(h.Default), RuleInvalidDefault, hPtr+"/default"))
 					}
 					if h.Items != nil {
-						res.Merge(s.validateDefaultValueItemsAgainstSchema(nm, "header", &h, h.Items))
+						res.Merge(s.validateDefaultValueItemsAgainstSchema(nm, "header", hPtr+"/items", &h, h.Items))
 					}
 				}
 			}
@@ -544,54 +694,54 @@ func (s *SpecValidator) validateDefaultValueValidAgainstSchema() *Result {
 	}
 
 	for nm, sch := range s.spec.Spec().Definitions {
-		res.Merge(s.validateDefaultValueSchemaAgainstSchema(fmt.Sprintf("definitions.%s", nm), "body", &sch))
+		res.Merge(s.validateDefaultValueSchemaAgainstSchema(fmt.Sprintf("definitions.%s", nm), "body", definitionPointer(nm), &sch))
 	}
 
 	return res
 }
 
-func (s *SpecValidator) validateDefaultValueSchemaAgainstSchema(path, in string, schema *spec.Schema) *Result {
+func (s *SpecValidator) validateDefaultValueSchemaAgainstSchema(path, in, pointer string, schema *spec.Schema) *Result {
 	res := new(Result)
 	if schema != nil {
 		if schema.Default != nil {
-			res.Merge(NewSchemaValidator(schema, s.spec.Spec(), path, s.KnownFormats).Validate(schema.Default))
+			res.Merge(withRule(NewSchemaValidator(schema, s.spec.Spec(), path, s.KnownFormats).Validate(schema.Default), RuleInvalidDefault, pointer+"/default"))
 		}
 		if schema.Items != nil {
 			if schema.Items.Schema != nil {
-				res.Merge(s.validateDefaultValueSchemaAgainstSchema(path+".items", in, schema.Items.Schema))
+				res.Merge(s.validateDefaultValueSchemaAgainstSchema(path+".items", in, pointer+"/items", schema.Items.Schema))
 			}
 			for i, sch := range schema.Items.Schemas {
-				res.Merge(s.validateDefaultValueSchemaAgainstSchema(fmt.Sprintf("%s.items[%d]", path, i), in, &sch))
+				res.Merge(s.validateDefaultValueSchemaAgainstSchema(fmt.Sprintf("%s.items[%d]", path, i), in, fmt.Sprintf("%s/items/%d", pointer, i), &sch))
 			}
 		}
 		if schema.AdditionalItems != nil && schema.AdditionalItems.Schema != nil {
-			res.Merge(s.validateDefaultValueSchemaAgainstSchema(fmt.Sprintf("%s.additionalItems", path), in, schema.AdditionalItems.Schema))
+			res.Merge(s.validateDefaultValueSchemaAgainstSchema(fmt.Sprintf("%s.additionalItems", path), in, pointer+"/additionalItems", schema.AdditionalItems.Schema))
 		}
 		for propName, prop := range schema.Properties {
-			res.Merge(s.validateDefaultValueSchemaAgainstSchema(path+"."+propName, in, &prop))
+			res.Merge(s.validateDefaultValueSchemaAgainstSchema(path+"."+propName, in, pointer+"/properties/"+jsonpointer.Escape(propName), &prop))
 		}
 		for propName, prop := range schema.PatternProperties {
-			res.Merge(s.validateDefaultValueSchemaAgainstSchema(path+"."+propName, in, &prop))
+			res.Merge(s.validateDefaultValueSchemaAgainstSchema(path+"."+propName, in, pointer+"/patternProperties/"+jsonpointer.Escape(propName), &prop))
 		}
 		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
-			res.Merge(s.validateDefaultValueSchemaAgainstSchema(fmt.Sprintf("%s.additionalProperties", path), in, schema.AdditionalProperties.Schema))
+			res.Merge(s.validateDefaultValueSchemaAgainstSchema(fmt.Sprintf("%s.additionalProperties", path), in, pointer+"/additionalProperties", schema.AdditionalProperties.Schema))
 		}
 		for i, aoSch := range schema.AllOf {
-			res.Merge(s.validateDefaultValueSchemaAgainstSchema(fmt.Sprintf("%s.allOf[%d]", path, i), in, &aoSch))
+			res.Merge(s.validateDefaultValueSchemaAgainstSchema(fmt.Sprintf("%s.allOf[%d]", path, i), in, fmt.Sprintf("%s/allOf/%d", pointer, i), &aoSch))
 		}
 
 	}
 	return res
 }
 
-func (s *SpecValidator) validateDefaultValueItemsAgainstSchema(path, in string, root interface{}, items *spec.Items) *Result {
+func (s *SpecValidator) validateDefaultValueItemsAgainstSchema(path, in, pointer string, root interface{}, items *spec.Items) *Result {
 	res := new(Result)
 	if items != nil {
 		if items.Default != nil {
-			res.Merge(newItemsValidator(path, in, items, root, s.KnownFormats).Validate(0, items.Default))
+			res.Merge(withRule(newItemsValidator(path, in, items, root, s.KnownFormats).Validate(0, items.Default), RuleInvalidDefault, pointer+"/default"))
 		}
 		if items.Items != nil {
-			res.Merge(s.validateDefaultValueItemsAgainstSchema(path+"[0]", in, root, items.Items))
+			res.Merge(s.validateDefaultValueItemsAgainstSchema(path+"[0]", in, pointer+"/items", root, items.Items))
 		}
 	}
 	return res
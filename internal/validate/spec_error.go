@@ -0,0 +1,107 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vikstrous/go-swagger/jsonpointer"
+	"github.com/vikstrous/go-swagger/spec"
+)
+
+// Rule codes for the checks the spec validator performs on top of the json schema validation
+const (
+	RuleSchema             = "schema"
+	RuleInvalidRef         = "invalid-ref"
+	RuleCircularAncestry   = "circular-ancestry"
+	RuleDuplicateProperty  = "duplicate-property"
+	RuleArrayItems         = "array-items"
+	RuleRequiredProperty   = "required-property"
+	RulePathOverlap        = "path-overlap"
+	RuleDuplicateParam     = "duplicate-param"
+	RuleMultipleBodyParams = "multiple-body-params"
+	RulePathParam          = "path-param"
+	RuleInvalidDefault     = "invalid-default"
+	RuleInvalidExample     = "invalid-example"
+	RuleUniqueScopes       = "unique-scopes"
+	RuleUnused             = "unused"
+	RuleQueryStyle         = "query-style"
+)
+
+// SpecError is a failure of one of the rules the spec validator checks,
+// it knows which rule failed and where in the document this happened
+type SpecError struct {
+	Rule    string
+	Pointer string
+	message string
+}
+
+func (e *SpecError) Error() string {
+	return e.message
+}
+
+// Code the error code
+func (e *SpecError) Code() int32 {
+	return 422
+}
+
+func specError(rule, pointer, message string, args ...interface{}) *SpecError {
+	return &SpecError{Rule: rule, Pointer: pointer, message: fmt.Sprintf(message, args...)}
+}
+
+// withRule tags the errors in a result with a rule and a location,
+// errors that already know where they come from are left alone
+func withRule(res *Result, rule, pointer string) *Result {
+	if res == nil {
+		return nil
+	}
+	for i, err := range res.Errors {
+		if _, ok := err.(*SpecError); ok {
+			continue
+		}
+		res.Errors[i] = &SpecError{Rule: rule, Pointer: pointer, message: err.Error()}
+	}
+	return res
+}
+
+func operationPointer(method, path string) string {
+	return "/paths/" + jsonpointer.Escape(path) + "/" + strings.ToLower(method)
+}
+
+func definitionPointer(name string) string {
+	return "/definitions/" + jsonpointer.Escape(name)
+}
+
+// paramPointer finds the pointer to the parameter with the provided name and location,
+// the parameter can be declared on the operation or on the path item
+func (s *SpecValidator) paramPointer(method, path, name, in string) string {
+	find := func(params []spec.Parameter) int {
+		for i, p := range params {
+			if p.Ref.String() != "" {
+				obj, _, err := p.Ref.GetPointer().Get(s.spec.Spec())
+				if err != nil {
+					continue
+				}
+				p = obj.(spec.Parameter)
+			}
+			if p.Name == name && p.In == in {
+				return i
+			}
+		}
+		return -1
+	}
+
+	opPtr := operationPointer(method, path)
+	if op, ok := s.spec.OperationFor(method, path); ok {
+		if i := find(op.Parameters); i >= 0 {
+			return fmt.Sprintf("%s/parameters/%d", opPtr, i)
+		}
+	}
+	if s.spec.Spec().Paths != nil {
+		if pi, ok := s.spec.Spec().Paths.Paths[path]; ok {
+			if i := find(pi.Parameters); i >= 0 {
+				return fmt.Sprintf("/paths/%s/parameters/%d", jsonpointer.Escape(path), i)
+			}
+		}
+	}
+	return opPtr
+}
@@ -0,0 +1,348 @@
+// Package lint checks a swagger spec document against a set of style rules.
+//
+// Where the validate package makes sure a document is a correct swagger 2.0 specification,
+// the rules in this package enforce conventions, like every operation having an operationId,
+// tags and a summary or definitions being used.
+//
+// Rules can be enabled, disabled or have their severity changed with a config file:
+//
+//	rules:
+//	  operation-summary: off
+//	  operation-id-camel-case: error
+//	  descriptions: info
+//
+// Operations and definitions can opt out of rules with the x-lint-disable vendor extension,
+// it takes a list of rule names or "all".
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vikstrous/go-swagger/jsonpointer"
+	"github.com/vikstrous/go-swagger/spec"
+	"github.com/vikstrous/go-swagger/swag"
+)
+
+// DisableExtension the vendor extension to suppress rules for an operation or a definition
+const DisableExtension = "x-lint-disable"
+
+// Severity the severity of an issue
+type Severity int
+
+const (
+	// Off disables the rule
+	Off Severity = iota
+	// Info an issue that is reported but doesn't need fixing
+	Info
+	// Warning an issue that should be fixed
+	Warning
+	// Error an issue that fails the lint run
+	Error
+)
+
+// enabled is used for rules that are switched on in the config without a severity
+const enabled Severity = -1
+
+var severityNames = map[Severity]string{
+	Off:     "off",
+	Info:    "info",
+	Warning: "warning",
+	Error:   "error",
+}
+
+func (s Severity) String() string {
+	return severityNames[s]
+}
+
+// ParseSeverity parses the name of a severity
+func ParseSeverity(name string) (Severity, error) {
+	for k, v := range severityNames {
+		if strings.EqualFold(v, name) {
+			return k, nil
+		}
+	}
+	return Off, fmt.Errorf("%q is not a known severity, expected one of off, info, warning or error", name)
+}
+
+// MarshalJSON writes the severity as its name
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON reads the severity from its name, yaml turns off into false
+// so booleans are accepted to switch a rule on or off too
+func (s *Severity) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if on, ok := value.(bool); ok {
+		*s = Off
+		if on {
+			*s = enabled
+		}
+		return nil
+	}
+	name, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("expected a severity name but got %v", value)
+	}
+	sev, err := ParseSeverity(name)
+	if err != nil {
+		return err
+	}
+	*s = sev
+	return nil
+}
+
+// Issue a single violation of a rule
+type Issue struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Pointer  string   `json:"pointer"`
+	Message  string   `json:"message"`
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s (%s) at %s", i.Severity, i.Message, i.Rule, i.Pointer)
+}
+
+// Issues a list of issues, sorted by their location in the document
+type Issues []Issue
+
+func (s Issues) Len() int      { return len(s) }
+func (s Issues) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s Issues) Less(i, j int) bool {
+	if s[i].Pointer == s[j].Pointer {
+		return s[i].Rule < s[j].Rule
+	}
+	return s[i].Pointer < s[j].Pointer
+}
+
+// Count returns the number of issues with the provided severity
+func (s Issues) Count(severity Severity) int {
+	var cnt int
+	for _, i := range s {
+		if i.Severity == severity {
+			cnt++
+		}
+	}
+	return cnt
+}
+
+// HasErrors returns true when some of the issues have the error severity
+func (s Issues) HasErrors() bool {
+	return s.Count(Error) > 0
+}
+
+// Rule checks a document for a single convention.
+// A rule reports its findings through the reporter, the linter
+// takes care of severities and suppressions.
+type Rule interface {
+	Name() string
+	Description() string
+	DefaultSeverity() Severity
+	Check(doc *spec.Document, report Reporter)
+}
+
+// Reporter collects the findings of a rule, the pointer is a json pointer into the document
+type Reporter func(pointer, message string, args ...interface{})
+
+// Config configures the severity for rules, rules not mentioned keep their default severity
+type Config struct {
+	Rules map[string]Severity `json:"rules,omitempty"`
+}
+
+// LoadConfig loads a lint config from a yaml or json file, the rules it mentions have to be known.
+// When no rules are provided the config is checked against the default rules.
+func LoadConfig(path string, rules ...Rule) (*Config, error) {
+	var data json.RawMessage
+	var err error
+	if strings.HasSuffix(path, ".json") {
+		data, err = swag.JSONDoc(path)
+	} else {
+		data, err = swag.YAMLDoc(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := new(Config)
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+
+	if len(rules) == 0 {
+		rules = DefaultRules()
+	}
+	known := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		known[rule.Name()] = true
+	}
+	var unknown []string
+	for name := range cfg.Rules {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("%s: unknown rules %s", path, strings.Join(unknown, ", "))
+	}
+	return cfg, nil
+}
+
+// Linter runs a set of rules against spec documents
+type Linter struct {
+	rules  []Rule
+	config *Config
+}
+
+// New creates a new linter for the config, when no rules are provided the default rules are used
+func New(config *Config, rules ...Rule) *Linter {
+	if len(rules) == 0 {
+		rules = DefaultRules()
+	}
+	if config == nil {
+		config = new(Config)
+	}
+	return &Linter{rules: rules, config: config}
+}
+
+// Rules returns the rules this linter will run
+func (l *Linter) Rules() []Rule {
+	return l.rules
+}
+
+// SeverityFor returns the configured severity for a rule
+func (l *Linter) SeverityFor(rule Rule) Severity {
+	if sev, ok := l.config.Rules[rule.Name()]; ok && sev != enabled {
+		return sev
+	}
+	return rule.DefaultSeverity()
+}
+
+// Lint runs all the enabled rules against the document
+func (l *Linter) Lint(doc *spec.Document) Issues {
+	suppressed := suppressions(doc.Spec())
+
+	var issues Issues
+	for _, rule := range l.rules {
+		severity := l.SeverityFor(rule)
+		if severity == Off {
+			continue
+		}
+		name := rule.Name()
+		rule.Check(doc, func(pointer, message string, args ...interface{}) {
+			if suppressed.matches(pointer, name) {
+				return
+			}
+			issues = append(issues, Issue{
+				Rule:     name,
+				Severity: severity,
+				Pointer:  pointer,
+				Message:  fmt.Sprintf(message, args...),
+			})
+		})
+	}
+	sort.Sort(issues)
+	return issues
+}
+
+// suppressionIndex maps json pointer prefixes to the rules that are disabled for them
+type suppressionIndex map[string][]string
+
+func (s suppressionIndex) matches(pointer, rule string) bool {
+	for prefix, rules := range s {
+		if pointer != prefix && !strings.HasPrefix(pointer, prefix+"/") {
+			continue
+		}
+		for _, r := range rules {
+			if r == rule || r == "all" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func disabledRules(ext spec.Extensions) []string {
+	v, ok := ext[DisableExtension]
+	if !ok {
+		return nil
+	}
+	switch tv := v.(type) {
+	case string:
+		return []string{tv}
+	case []interface{}:
+		var result []string
+		for _, r := range tv {
+			if str, ok := r.(string); ok {
+				result = append(result, str)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+func suppressions(sw *spec.Swagger) suppressionIndex {
+	idx := make(suppressionIndex)
+	forEachOperation(sw, func(pointer, _, _ string, op *spec.Operation) {
+		if rules := disabledRules(op.Extensions); len(rules) > 0 {
+			idx[pointer] = rules
+		}
+	})
+	for name, def := range sw.Definitions {
+		if rules := disabledRules(def.Extensions); len(rules) > 0 {
+			idx["/definitions/"+jsonpointer.Escape(name)] = rules
+		}
+	}
+	return idx
+}
+
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+func operationFor(pi *spec.PathItem, method string) *spec.Operation {
+	switch method {
+	case "get":
+		return pi.Get
+	case "put":
+		return pi.Put
+	case "post":
+		return pi.Post
+	case "delete":
+		return pi.Delete
+	case "options":
+		return pi.Options
+	case "head":
+		return pi.Head
+	case "patch":
+		return pi.Patch
+	}
+	return nil
+}
+
+// forEachOperation visits the operations of a spec in a stable order
+func forEachOperation(sw *spec.Swagger, visit func(pointer, method, path string, op *spec.Operation)) {
+	if sw.Paths == nil {
+		return
+	}
+	var paths []string
+	for k := range sw.Paths.Paths {
+		paths = append(paths, k)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		pi := sw.Paths.Paths[path]
+		for _, method := range methods {
+			if op := operationFor(&pi, method); op != nil {
+				visit("/paths/"+jsonpointer.Escape(path)+"/"+method, method, path, op)
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package lint
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vikstrous/go-swagger/spec"
+)
+
+func issueFor(issues Issues, rule, pointer string) (Issue, bool) {
+	for _, i := range issues {
+		if i.Rule == rule && i.Pointer == pointer {
+			return i, true
+		}
+	}
+	return Issue{}, false
+}
+
+func TestLint_DefaultRules(t *testing.T) {
+	doc, err := spec.Load(filepath.Join("..", "fixtures", "lint", "swagger.yml"))
+	if assert.NoError(t, err) {
+		issues := New(nil).Lint(doc)
+
+		i, ok := issueFor(issues, "operation-id-camel-case", "/paths/~1pets/get/operationId")
+		if assert.True(t, ok) {
+			assert.Equal(t, Warning, i.Severity)
+		}
+		_, ok = issueFor(issues, "no-inline-response-schema", "/paths/~1pets/get/responses/200/schema")
+		assert.True(t, ok)
+		_, ok = issueFor(issues, "no-unused-definitions", "/definitions/Orphan")
+		assert.True(t, ok)
+		_, ok = issueFor(issues, "no-unused-definitions", "/definitions/Pet")
+		assert.False(t, ok)
+		_, ok = issueFor(issues, "no-unused-definitions", "/definitions/Category")
+		assert.False(t, ok)
+		// only referenced from themselves or each other
+		for _, name := range []string{"Tree", "Left", "Right"} {
+			_, ok = issueFor(issues, "no-unused-definitions", "/definitions/"+name)
+			assert.True(t, ok, name)
+		}
+		_, ok = issueFor(issues, "descriptions", "/definitions/Orphan/properties/name")
+		assert.True(t, ok)
+
+		// suppressed through vendor extensions
+		_, ok = issueFor(issues, "operation-tags", "/paths/~1pets/post")
+		assert.False(t, ok)
+		_, ok = issueFor(issues, "descriptions", "/paths/~1pets/post")
+		assert.False(t, ok)
+		_, ok = issueFor(issues, "operation-summary", "/paths/~1pets/post")
+		assert.False(t, ok)
+		for _, i := range issues {
+			assert.NotContains(t, i.Pointer, "/paths/~1pets~1{id}")
+		}
+		assert.False(t, issues.HasErrors())
+	}
+}
+
+func TestLint_Config(t *testing.T) {
+	cfg, err := LoadConfig(filepath.Join("..", "fixtures", "lint", "config.yml"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, Off, cfg.Rules["descriptions"])
+		assert.Equal(t, Error, cfg.Rules["operation-id-camel-case"])
+
+		doc, err := spec.Load(filepath.Join("..", "fixtures", "lint", "swagger.yml"))
+		if assert.NoError(t, err) {
+			issues := New(cfg).Lint(doc)
+			assert.Equal(t, 0, issues.Count(Info))
+			i, ok := issueFor(issues, "operation-id-camel-case", "/paths/~1pets/get/operationId")
+			if assert.True(t, ok) {
+				assert.Equal(t, Error, i.Severity)
+			}
+			assert.True(t, issues.HasErrors())
+			i, ok = issueFor(issues, "operation-tags", "/paths/~1pets~1{id}/get")
+			assert.False(t, ok)
+		}
+		linter := New(cfg)
+		for _, rule := range linter.Rules() {
+			if rule.Name() == "operation-tags" {
+				assert.Equal(t, Warning, linter.SeverityFor(rule))
+			}
+		}
+	}
+}
+
+func TestLint_ConfigUnknownRules(t *testing.T) {
+	_, err := LoadConfig(filepath.Join("..", "fixtures", "lint", "unknown-rules.yml"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "unknown rules operation-summery, operationid-camelcase")
+		assert.NotContains(t, err.Error(), "descriptions")
+	}
+
+	// custom rules are known too when they're passed in
+	cfg, err := LoadConfig(filepath.Join("..", "fixtures", "lint", "unknown-rules.yml"), DescriptionsRule{}, namedRule("operationid-camelcase"), namedRule("operation-summery"))
+	if assert.NoError(t, err) {
+		assert.Len(t, cfg.Rules, 3)
+	}
+}
+
+type namedRule string
+
+func (n namedRule) Name() string                   { return string(n) }
+func (n namedRule) Description() string            { return "a rule for testing" }
+func (n namedRule) DefaultSeverity() Severity      { return Warning }
+func (n namedRule) Check(*spec.Document, Reporter) {}
+
+func TestParseSeverity(t *testing.T) {
+	for _, nm := range []string{"off", "info", "Warning", "ERROR"} {
+		_, err := ParseSeverity(nm)
+		assert.NoError(t, err)
+	}
+	_, err := ParseSeverity("fatal")
+	assert.Error(t, err)
+}
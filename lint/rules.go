@@ -0,0 +1,266 @@
+package lint
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vikstrous/go-swagger/jsonpointer"
+	"github.com/vikstrous/go-swagger/spec"
+)
+
+// DefaultRules returns the rules that are available out of the box
+func DefaultRules() []Rule {
+	return []Rule{
+		OperationIDRule{},
+		OperationTagsRule{},
+		OperationSummaryRule{},
+		CamelCaseOperationIDRule{},
+		NoInlineResponseSchemaRule{},
+		DescriptionsRule{},
+		NoUnusedDefinitionsRule{},
+	}
+}
+
+// OperationIDRule every operation must have an operationId
+type OperationIDRule struct{}
+
+// Name of the rule
+func (OperationIDRule) Name() string { return "operation-id" }
+
+// Description of the rule
+func (OperationIDRule) Description() string { return "every operation has an operationId" }
+
+// DefaultSeverity of the rule
+func (OperationIDRule) DefaultSeverity() Severity { return Error }
+
+// Check the document
+func (OperationIDRule) Check(doc *spec.Document, report Reporter) {
+	forEachOperation(doc.Spec(), func(pointer, method, path string, op *spec.Operation) {
+		if op.ID == "" {
+			report(pointer, "operation %s %s has no operationId", method, path)
+		}
+	})
+}
+
+// OperationTagsRule every operation must have at least one tag
+type OperationTagsRule struct{}
+
+// Name of the rule
+func (OperationTagsRule) Name() string { return "operation-tags" }
+
+// Description of the rule
+func (OperationTagsRule) Description() string { return "every operation has at least one tag" }
+
+// DefaultSeverity of the rule
+func (OperationTagsRule) DefaultSeverity() Severity { return Warning }
+
+// Check the document
+func (OperationTagsRule) Check(doc *spec.Document, report Reporter) {
+	forEachOperation(doc.Spec(), func(pointer, method, path string, op *spec.Operation) {
+		if len(op.Tags) == 0 {
+			report(pointer, "operation %s %s has no tags", method, path)
+		}
+	})
+}
+
+// OperationSummaryRule every operation must have a summary
+type OperationSummaryRule struct{}
+
+// Name of the rule
+func (OperationSummaryRule) Name() string { return "operation-summary" }
+
+// Description of the rule
+func (OperationSummaryRule) Description() string { return "every operation has a summary" }
+
+// DefaultSeverity of the rule
+func (OperationSummaryRule) DefaultSeverity() Severity { return Warning }
+
+// Check the document
+func (OperationSummaryRule) Check(doc *spec.Document, report Reporter) {
+	forEachOperation(doc.Spec(), func(pointer, method, path string, op *spec.Operation) {
+		if op.Summary == "" {
+			report(pointer, "operation %s %s has no summary", method, path)
+		}
+	})
+}
+
+var camelCase = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+
+// CamelCaseOperationIDRule operation ids must be camelCase
+type CamelCaseOperationIDRule struct{}
+
+// Name of the rule
+func (CamelCaseOperationIDRule) Name() string { return "operation-id-camel-case" }
+
+// Description of the rule
+func (CamelCaseOperationIDRule) Description() string { return "operationIds are camelCase" }
+
+// DefaultSeverity of the rule
+func (CamelCaseOperationIDRule) DefaultSeverity() Severity { return Warning }
+
+// Check the document
+func (CamelCaseOperationIDRule) Check(doc *spec.Document, report Reporter) {
+	forEachOperation(doc.Spec(), func(pointer, method, path string, op *spec.Operation) {
+		if op.ID != "" && !camelCase.MatchString(op.ID) {
+			report(pointer+"/operationId", "operationId %q is not camelCase", op.ID)
+		}
+	})
+}
+
+// NoInlineResponseSchemaRule response schemas for objects must reference a definition
+type NoInlineResponseSchemaRule struct{}
+
+// Name of the rule
+func (NoInlineResponseSchemaRule) Name() string { return "no-inline-response-schema" }
+
+// Description of the rule
+func (NoInlineResponseSchemaRule) Description() string {
+	return "response schemas for objects reference a definition"
+}
+
+// DefaultSeverity of the rule
+func (NoInlineResponseSchemaRule) DefaultSeverity() Severity { return Warning }
+
+func isInlineObject(schema *spec.Schema) bool {
+	if schema == nil || schema.Ref.String() != "" {
+		return false
+	}
+	if schema.Type.Contains("array") && schema.Items != nil {
+		return isInlineObject(schema.Items.Schema)
+	}
+	return len(schema.Properties) > 0 || len(schema.AllOf) > 0
+}
+
+// Check the document
+func (NoInlineResponseSchemaRule) Check(doc *spec.Document, report Reporter) {
+	sw := doc.Spec()
+	check := func(pointer string, resp *spec.Response) {
+		if resp != nil && isInlineObject(resp.Schema) {
+			report(pointer+"/schema", "response schema is defined inline, use a reference to a definition")
+		}
+	}
+
+	var names []string
+	for k := range sw.Responses {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		resp := sw.Responses[k]
+		check("/responses/"+jsonpointer.Escape(k), &resp)
+	}
+
+	forEachOperation(sw, func(pointer, _, _ string, op *spec.Operation) {
+		if op.Responses == nil {
+			return
+		}
+		check(pointer+"/responses/default", op.Responses.Default)
+		for code, resp := range op.Responses.StatusCodeResponses {
+			check(pointer+"/responses/"+strconv.Itoa(code), &resp)
+		}
+	})
+}
+
+// DescriptionsRule operations, parameters, definitions and their properties must have a description
+type DescriptionsRule struct{}
+
+// Name of the rule
+func (DescriptionsRule) Name() string { return "descriptions" }
+
+// Description of the rule
+func (DescriptionsRule) Description() string {
+	return "operations, parameters, definitions and properties have a description"
+}
+
+// DefaultSeverity of the rule
+func (DescriptionsRule) DefaultSeverity() Severity { return Info }
+
+// Check the document
+func (DescriptionsRule) Check(doc *spec.Document, report Reporter) {
+	sw := doc.Spec()
+	forEachOperation(sw, func(pointer, method, path string, op *spec.Operation) {
+		if op.Description == "" {
+			report(pointer, "operation %s %s has no description", method, path)
+		}
+		for i, param := range op.Parameters {
+			if param.Ref.String() == "" && param.Description == "" {
+				report(pointer+"/parameters/"+strconv.Itoa(i), "parameter %q has no description", param.Name)
+			}
+		}
+	})
+
+	for name, param := range sw.Parameters {
+		if param.Description == "" {
+			report("/parameters/"+jsonpointer.Escape(name), "parameter %q has no description", param.Name)
+		}
+	}
+
+	for name, def := range sw.Definitions {
+		pointer := "/definitions/" + jsonpointer.Escape(name)
+		if def.Description == "" && def.Title == "" {
+			report(pointer, "definition %q has no description", name)
+		}
+		for prop, sch := range def.Properties {
+			if sch.Ref.String() == "" && sch.Description == "" {
+				report(pointer+"/properties/"+jsonpointer.Escape(prop), "property %q of %q has no description", prop, name)
+			}
+		}
+	}
+}
+
+// NoUnusedDefinitionsRule every definition must be reachable from the paths, parameters or responses,
+// definitions that only reference each other are unused too
+type NoUnusedDefinitionsRule struct{}
+
+// Name of the rule
+func (NoUnusedDefinitionsRule) Name() string { return "no-unused-definitions" }
+
+// Description of the rule
+func (NoUnusedDefinitionsRule) Description() string { return "every definition is referenced" }
+
+// DefaultSeverity of the rule
+func (NoUnusedDefinitionsRule) DefaultSeverity() Severity { return Warning }
+
+// Check the document
+func (NoUnusedDefinitionsRule) Check(doc *spec.Document, report Reporter) {
+	sw := doc.Spec()
+
+	// the definitions that reference a definition, a definition is used directly
+	// when it's referenced from outside of the definitions
+	var pending []string
+	referrers := make(map[string][]string, len(sw.Definitions))
+	used := make(map[string]bool, len(sw.Definitions))
+	for name := range sw.Definitions {
+		escaped := jsonpointer.Escape(name)
+		for _, pointer := range sw.ReferencesTo("#/definitions/" + escaped) {
+			if !strings.HasPrefix(pointer, "/definitions/") {
+				if !used[escaped] {
+					used[escaped] = true
+					pending = append(pending, escaped)
+				}
+				continue
+			}
+			from := strings.SplitN(strings.TrimPrefix(pointer, "/definitions/"), "/", 2)[0]
+			referrers[from] = append(referrers[from], escaped)
+		}
+	}
+	for len(pending) > 0 {
+		from := pending[0]
+		pending = pending[1:]
+		for _, to := range referrers[from] {
+			if !used[to] {
+				used[to] = true
+				pending = append(pending, to)
+			}
+		}
+	}
+
+	for name := range sw.Definitions {
+		escaped := jsonpointer.Escape(name)
+		if !used[escaped] {
+			report("/definitions/"+escaped, "definition %q is never used", name)
+		}
+	}
+}
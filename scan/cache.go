@@ -0,0 +1,345 @@
+package scan
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"go/build"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/vikstrous/go-swagger/spec"
+)
+
+// the version of the cache format, a cache with another version is discarded
+const scanCacheVersion = 3
+
+const (
+	schemaContribution     = "schema"
+	parametersContribution = "parameters"
+	responsesContribution  = "responses"
+)
+
+// scanCache remembers what the files of each package added to the spec.
+// A package is scanned again when its files or the files of the packages it imports change,
+// the contributions of the other packages are reused. When nothing changed the spec of the last scan is used.
+type scanCache struct {
+	Version  int                      `json:"version"`
+	Packages map[string]*packageCache `json:"packages"`
+	Result   *cachedResult            `json:"result,omitempty"`
+
+	// file the file the cache is saved in, the cache is only kept in memory when it's empty
+	file string
+	// sources the packages of the application, by directory
+	sources map[string]*packageSource
+	// dirty the directories of the packages that have to be scanned again
+	dirty map[string]bool
+}
+
+// packageCache the contributions of the files of a package, they're only valid for the hash of the package
+type packageCache struct {
+	Hash           string                                  `json:"hash"`
+	Discriminators bool                                    `json:"discriminators,omitempty"`
+	Files          map[string]map[string]*fileContribution `json:"files,omitempty"`
+	RoutesScanned  bool                                    `json:"routesScanned,omitempty"`
+	Registrations  []*routeRegistration                    `json:"registrations,omitempty"`
+}
+
+// fileContribution what parsing a file for schemas, parameters or responses added to the spec
+type fileContribution struct {
+	Definitions map[string]spec.Schema   `json:"definitions,omitempty"`
+	Responses   map[string]spec.Response `json:"responses,omitempty"`
+	Operations  []operationParams        `json:"operations,omitempty"`
+	Discovered  []discoveredDecl         `json:"discovered,omitempty"`
+	Warnings    []string                 `json:"warnings,omitempty"`
+	// ProgramWide is true when parsing the file looked at all the packages of the program,
+	// like the implementers of a discriminated interface. These are parsed again when anything changed.
+	ProgramWide bool `json:"programWide,omitempty"`
+}
+
+// operationParams the parameters a file declares for an operation
+type operationParams struct {
+	ID         string           `json:"id"`
+	Parameters []spec.Parameter `json:"parameters,omitempty"`
+}
+
+// cachedResult the outcome of the last scan
+type cachedResult struct {
+	Spec     *spec.Swagger     `json:"spec"`
+	Origins  map[string]string `json:"origins,omitempty"`
+	Warnings []string          `json:"warnings,omitempty"`
+}
+
+// packageSource a package of the application as it is on disk
+type packageSource struct {
+	Dir        string
+	ImportPath string
+	Hash       string
+	// Discriminators is true when a file of the package mentions swagger:discriminator
+	Discriminators bool
+	// Imports the directories of the imported packages, the standard library is left out
+	Imports []string
+}
+
+// loadScanCache reads the cache for the options from the cache dir,
+// the cache is empty when there is none yet or when it can't be read.
+// Without a cache dir the cache is only kept in memory.
+func loadScanCache(opts *Opts) (*scanCache, error) {
+	cache := &scanCache{Version: scanCacheVersion, Packages: make(map[string]*packageCache)}
+	if opts.CacheDir == "" {
+		return cache, nil
+	}
+	key, err := cacheKey(opts)
+	if err != nil {
+		return nil, err
+	}
+	cache.file = filepath.Join(opts.CacheDir, "scan-"+key+".json")
+
+	b, err := ioutil.ReadFile(cache.file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cache, nil
+		}
+		return nil, err
+	}
+	var saved scanCache
+	if err := json.Unmarshal(b, &saved); err != nil || saved.Version != scanCacheVersion || saved.Packages == nil {
+		// a cache that can't be used is replaced with a new one
+		return cache, nil
+	}
+	saved.file = cache.file
+	return &saved, nil
+}
+
+// cacheKey a hash of the options that change the outcome of a scan, each set of options gets its own cache
+func cacheKey(opts *Opts) (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(struct {
+		Cwd            string
+		BasePath       string
+		Input          *spec.Swagger
+		Includes       []string
+		Excludes       []string
+		BuildTags      []string
+		ScanModels     bool
+		ValidationTags []string
+		DiscoverRoutes bool
+		TypeMappings   map[string]TypeMapping
+	}{cwd, opts.BasePath, opts.Input, opts.Includes, opts.Excludes, opts.BuildTags, opts.ScanModels, opts.ValidationTags, opts.DiscoverRoutes, opts.TypeMappings})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+func (c *scanCache) save() error {
+	if c.file == "" {
+		return nil
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.file), 0755); err != nil {
+		return err
+	}
+	// write and rename, so a scan that is interrupted doesn't leave half a cache behind
+	tmp := c.file + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.file)
+}
+
+// update compares the packages on disk with the cached ones, it returns true when something changed.
+// The packages that changed and the packages that import them, directly or not, are marked dirty.
+// When a package with a swagger:discriminator annotation changed, all the packages are dirty
+// because any type in the program could implement the interface.
+func (c *scanCache) update(sources map[string]*packageSource) bool {
+	c.sources = sources
+	c.dirty = make(map[string]bool)
+	changed, all := false, false
+	for dir, pc := range c.Packages {
+		if _, ok := sources[dir]; !ok {
+			delete(c.Packages, dir)
+			changed = true
+			all = all || pc.Discriminators
+		}
+	}
+	for dir, src := range sources {
+		pc, ok := c.Packages[dir]
+		if ok && pc.Hash == src.Hash {
+			continue
+		}
+		c.dirty[dir] = true
+		changed = true
+		all = all || src.Discriminators || (ok && pc.Discriminators)
+	}
+
+	if all {
+		for dir := range sources {
+			c.dirty[dir] = true
+		}
+	}
+	for more := true; more; {
+		more = false
+		for dir, src := range sources {
+			if c.dirty[dir] {
+				continue
+			}
+			for _, imp := range src.Imports {
+				if c.dirty[imp] {
+					c.dirty[dir] = true
+					more = true
+					break
+				}
+			}
+		}
+	}
+
+	for dir := range c.dirty {
+		src := sources[dir]
+		c.Packages[dir] = &packageCache{Hash: src.Hash, Discriminators: src.Discriminators}
+	}
+	if changed {
+		c.Result = nil
+	}
+	return changed
+}
+
+// contribution the cached contribution of a file, it's nil when the file has to be parsed
+func (c *scanCache) contribution(dir, file, kind string) *fileContribution {
+	if c == nil || c.dirty[dir] {
+		return nil
+	}
+	pc, ok := c.Packages[dir]
+	if !ok {
+		return nil
+	}
+	fc := pc.Files[file][kind]
+	if fc == nil || fc.ProgramWide {
+		return nil
+	}
+	return fc
+}
+
+func (c *scanCache) storeContribution(dir, file, kind string, fc *fileContribution) {
+	if c == nil {
+		return
+	}
+	pc, ok := c.Packages[dir]
+	if !ok {
+		// packages outside of the application, like the standard library, aren't cached
+		return
+	}
+	if pc.Files == nil {
+		pc.Files = make(map[string]map[string]*fileContribution)
+	}
+	if pc.Files[file] == nil {
+		pc.Files[file] = make(map[string]*fileContribution)
+	}
+	pc.Files[file][kind] = fc
+}
+
+// registrations the cached route registrations of a package, ok is false when the package has to be parsed
+func (c *scanCache) registrations(dir string) ([]*routeRegistration, bool) {
+	if c == nil || c.dirty[dir] {
+		return nil, false
+	}
+	pc, ok := c.Packages[dir]
+	if !ok || !pc.RoutesScanned {
+		return nil, false
+	}
+	return pc.Registrations, true
+}
+
+func (c *scanCache) storeRegistrations(dir string, registrations []*routeRegistration) {
+	if c == nil {
+		return
+	}
+	if pc, ok := c.Packages[dir]; ok {
+		pc.RoutesScanned = true
+		pc.Registrations = registrations
+	}
+}
+
+// typeCheckBodies the packages that get their function bodies type checked,
+// only the dirty packages need them to find the route registrations
+func (c *scanCache) typeCheckBodies(path string) bool {
+	for dir := range c.dirty {
+		if c.sources[dir].ImportPath == path {
+			return true
+		}
+	}
+	return false
+}
+
+// collectSources finds the packages the base path imports, directly or not, and hashes their files.
+// The packages of the standard library are left out.
+func collectSources(ctx *build.Context, basePath string) (map[string]*packageSource, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	sources := make(map[string]*packageSource)
+	var visit func(path, srcDir string) (string, error)
+	visit = func(path, srcDir string) (string, error) {
+		bp, err := ctx.Import(path, srcDir, 0)
+		if err != nil {
+			return "", err
+		}
+		if bp.Goroot {
+			return "", nil
+		}
+		if _, ok := sources[bp.Dir]; ok {
+			return bp.Dir, nil
+		}
+		src := &packageSource{Dir: bp.Dir, ImportPath: bp.ImportPath}
+		sources[bp.Dir] = src
+		if err := src.hashFiles(append(append([]string{}, bp.GoFiles...), bp.CgoFiles...)); err != nil {
+			return "", err
+		}
+		for _, imp := range bp.Imports {
+			if imp == "C" {
+				continue
+			}
+			dir, err := visit(imp, bp.Dir)
+			if err != nil {
+				return "", err
+			}
+			if dir != "" {
+				src.Imports = append(src.Imports, dir)
+			}
+		}
+		return bp.Dir, nil
+	}
+	if _, err := visit(basePath, cwd); err != nil {
+		return nil, err
+	}
+	return sources, nil
+}
+
+func (src *packageSource) hashFiles(files []string) error {
+	sort.Strings(files)
+	h := sha256.New()
+	for _, name := range files {
+		b, err := ioutil.ReadFile(filepath.Join(src.Dir, name))
+		if err != nil {
+			return err
+		}
+		h.Write([]byte(name))
+		h.Write([]byte{0})
+		h.Write(b)
+		h.Write([]byte{0})
+		src.Discriminators = src.Discriminators || bytes.Contains(b, []byte("swagger:discriminator"))
+	}
+	src.Hash = hex.EncodeToString(h.Sum(nil))
+	return nil
+}
@@ -0,0 +1,195 @@
+package scan
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vikstrous/go-swagger/spec"
+	"github.com/stretchr/testify/assert"
+)
+
+const petstoreFixture = "../fixtures/goparsing/petstore/petstore-fixture"
+
+func readScanCache(t *testing.T, dir string) (*scanCache, string) {
+	files, err := filepath.Glob(filepath.Join(dir, "scan-*.json"))
+	if !assert.NoError(t, err) || !assert.Len(t, files, 1) {
+		t.FailNow()
+	}
+	b, err := ioutil.ReadFile(files[0])
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	var cache scanCache
+	if !assert.NoError(t, json.Unmarshal(b, &cache)) {
+		t.FailNow()
+	}
+	return &cache, files[0]
+}
+
+func writeScanCache(t *testing.T, cache *scanCache, file string) {
+	b, err := json.Marshal(cache)
+	if assert.NoError(t, err) {
+		assert.NoError(t, ioutil.WriteFile(file, b, 0644))
+	}
+}
+
+// packageDir the directory of the cached package that ends with suffix
+func packageDir(cache *scanCache, suffix string) string {
+	for dir := range cache.Packages {
+		if strings.HasSuffix(filepath.ToSlash(dir), suffix) {
+			return dir
+		}
+	}
+	return ""
+}
+
+func TestScanCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scan-cache")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	reasons := make(map[string]string)
+	expected, err := Run(&Opts{BasePath: petstoreFixture, Explain: func(definition, reason string) { reasons[definition] = reason }})
+	if !assert.NoError(t, err) {
+		return
+	}
+	expectedJSON, _ := json.Marshal(expected)
+
+	// the first run fills the cache
+	cachedReasons := make(map[string]string)
+	opts := &Opts{BasePath: petstoreFixture, CacheDir: dir, Explain: func(definition, reason string) { cachedReasons[definition] = reason }}
+	doc, err := Run(opts)
+	if assert.NoError(t, err) {
+		actual, _ := json.Marshal(doc)
+		assert.JSONEq(t, string(expectedJSON), string(actual))
+		assert.Equal(t, reasons, cachedReasons)
+	}
+	cache, file := readScanCache(t, dir)
+	assert.NotNil(t, cache.Result)
+	modelsDir := packageDir(cache, "petstore/models")
+	mainDir := packageDir(cache, "petstore/petstore-fixture")
+	if !assert.NotEmpty(t, modelsDir) || !assert.NotEmpty(t, mainDir) {
+		return
+	}
+
+	// nothing changed, the spec of the last run is used
+	cache.Result.Spec.Info = new(spec.Info)
+	cache.Result.Spec.Info.Title = "from the cache"
+	writeScanCache(t, cache, file)
+	cachedReasons = make(map[string]string)
+	opts.Input = nil
+	doc, err = Run(opts)
+	if assert.NoError(t, err) && assert.NotNil(t, doc.Info) {
+		assert.Equal(t, "from the cache", doc.Info.Title)
+		assert.Equal(t, reasons, cachedReasons)
+	}
+
+	// the main package changed, the models are taken from the cache
+	cache, _ = readScanCache(t, dir)
+	cache.Packages[mainDir].Hash = "changed"
+	found := false
+	for _, contributions := range cache.Packages[modelsDir].Files {
+		if fc, ok := contributions[schemaContribution]; ok {
+			if pet, ok := fc.Definitions["pet"]; ok {
+				pet.Description = "cached pet"
+				fc.Definitions["pet"] = pet
+				found = true
+			}
+		}
+	}
+	assert.True(t, found)
+	writeScanCache(t, cache, file)
+	doc, err = Run(opts)
+	if assert.NoError(t, err) {
+		pet := doc.Definitions["pet"]
+		assert.Equal(t, "cached pet", pet.Description)
+		// apart from that the cached contributions give the same spec
+		pet.Description = expected.Definitions["pet"].Description
+		doc.Definitions["pet"] = pet
+		actual, _ := json.Marshal(doc)
+		assert.JSONEq(t, string(expectedJSON), string(actual))
+	}
+
+	// the models changed, they're scanned again together with the packages that import them
+	cache, _ = readScanCache(t, dir)
+	cache.Packages[modelsDir].Hash = "changed"
+	writeScanCache(t, cache, file)
+	doc, err = Run(opts)
+	if assert.NoError(t, err) {
+		actual, _ := json.Marshal(doc)
+		assert.JSONEq(t, string(expectedJSON), string(actual))
+	}
+}
+
+func TestScanCache_DiscoverRoutes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scan-cache")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	var expected []string
+	_, err = Run(&Opts{
+		BasePath:       "../fixtures/goparsing/registrations",
+		DiscoverRoutes: true,
+		Warn:           func(msg string) { expected = append(expected, msg) },
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for i := 0; i < 2; i++ {
+		// the second run gives the warnings of the cached scan again
+		var warnings []string
+		doc, err := Run(&Opts{
+			BasePath:       "../fixtures/goparsing/registrations",
+			DiscoverRoutes: true,
+			CacheDir:       dir,
+			Warn:           func(msg string) { warnings = append(warnings, msg) },
+		})
+		if assert.NoError(t, err) {
+			assert.Equal(t, expected, warnings)
+			assert.Equal(t, "headOrdersId", doc.Paths.Paths["/orders/{id}"].Head.ID)
+		}
+	}
+
+	// the registrations of the packages that didn't change come from the cache
+	cache, file := readScanCache(t, dir)
+	mainDir := packageDir(cache, "goparsing/registrations")
+	if assert.NotEmpty(t, mainDir) && assert.True(t, cache.Packages[mainDir].RoutesScanned) {
+		assert.NotEmpty(t, cache.Packages[mainDir].Registrations)
+		cache.Result = nil
+		writeScanCache(t, cache, file)
+		var warnings []string
+		_, err := Run(&Opts{
+			BasePath:       "../fixtures/goparsing/registrations",
+			DiscoverRoutes: true,
+			CacheDir:       dir,
+			Warn:           func(msg string) { warnings = append(warnings, msg) },
+		})
+		if assert.NoError(t, err) {
+			assert.Equal(t, expected, warnings)
+		}
+	}
+}
+
+func TestWatch(t *testing.T) {
+	stop := make(chan struct{})
+	var docs []*spec.Swagger
+	err := Watch(&Opts{BasePath: petstoreFixture}, 10*time.Millisecond, stop, func(doc *spec.Swagger, err error) {
+		assert.NoError(t, err)
+		docs = append(docs, doc)
+		close(stop)
+	})
+	if assert.NoError(t, err) && assert.Len(t, docs, 1) {
+		// nothing changed while watching, so there is only the first scan
+		assert.Len(t, docs[0].Paths.Paths, 4)
+	}
+}
@@ -3,6 +3,8 @@ package scan
 import (
 	"fmt"
 	"go/ast"
+	"sort"
+	"strings"
 
 	"golang.org/x/tools/go/loader"
 )
@@ -11,16 +13,40 @@ type packageFilter struct {
 	Name string
 }
 
+// Matches the path of a package, when the name of the filter ends in /...
+// it matches the packages below that path too
 func (pf *packageFilter) Matches(path string) bool {
+	if strings.HasSuffix(pf.Name, "/...") {
+		prefix := strings.TrimSuffix(pf.Name, "/...")
+		return path == prefix || strings.HasPrefix(path, prefix+"/")
+	}
 	return path == pf.Name
 }
 
 type packageFilters []packageFilter
 
+func newPackageFilters(names []string) packageFilters {
+	var pf packageFilters
+	for _, nm := range names {
+		if nm != "" {
+			pf = append(pf, packageFilter{Name: nm})
+		}
+	}
+	return pf
+}
+
 func (pf packageFilters) HasFilters() bool {
 	return len(pf) > 0
 }
 
+func (pf packageFilters) Names() []string {
+	var names []string
+	for _, mod := range pf {
+		names = append(names, mod.Name)
+	}
+	return names
+}
+
 func (pf packageFilters) Matches(path string) bool {
 	for _, mod := range pf {
 		if mod.Matches(path) {
@@ -37,6 +63,8 @@ type classifiedProgram struct {
 	Operations []*ast.File
 	Parameters []*ast.File
 	Responses  []*ast.File
+	// Packages the packages that pass the filters, they're checked for route registrations
+	Packages []*loader.PackageInfo
 }
 
 // programClassifier classifies the files of a program into buckets
@@ -56,7 +84,15 @@ type programClassifier struct {
 
 func (pc *programClassifier) Classify(prog *loader.Program) (*classifiedProgram, error) {
 	var cp classifiedProgram
-	for pkg, pkgInfo := range prog.AllPackages {
+	// the packages are classified in order of their path, so the spec comes out the same every time
+	pkgs := make([]*loader.PackageInfo, 0, len(prog.AllPackages))
+	for _, pkgInfo := range prog.AllPackages {
+		pkgs = append(pkgs, pkgInfo)
+	}
+	sort.Sort(packagesByPath(pkgs))
+
+	for _, pkgInfo := range pkgs {
+		pkg := pkgInfo.Pkg
 		if pc.Includes.HasFilters() {
 			if !pc.Includes.Matches(pkg.Path()) {
 				continue
@@ -67,8 +103,9 @@ func (pc *programClassifier) Classify(prog *loader.Program) (*classifiedProgram,
 			}
 		}
 
+		cp.Packages = append(cp.Packages, pkgInfo)
 		for _, file := range pkgInfo.Files {
-			var op, mt, pm, rs bool // only add a particular file once
+			var op, mt, pm, rs, md bool // only add a particular file once
 			for _, comments := range file.Comments {
 				matches := rxSwaggerAnnotation.FindStringSubmatch(comments.Text())
 				if len(matches) > 1 {
@@ -79,8 +116,12 @@ func (pc *programClassifier) Classify(prog *loader.Program) (*classifiedProgram,
 							op = true
 						}
 					case "model":
-						// models are discovered through parameters and responses
-						// no actual scanning for them is required
+						// models are discovered through parameters and responses,
+						// these files are only scanned when all the models are wanted
+						if !md {
+							cp.Models = append(cp.Models, file)
+							md = true
+						}
 					case "meta":
 						if !mt {
 							cp.Meta = append(cp.Meta, file)
@@ -96,8 +137,14 @@ func (pc *programClassifier) Classify(prog *loader.Program) (*classifiedProgram,
 							cp.Responses = append(cp.Responses, file)
 							rs = true
 						}
+					case "enum":
+						// enums are discovered through the types that use them
+					case "discriminator":
+						// the implementers are discovered through the types that use the interface
 					case "strfmt":
 						// TODO: perhaps collect these and pass along to avoid lookups later on
+					case "type":
+						// the types with a swagger:type are mapped where they're used
 					case "allOf":
 					default:
 						return nil, fmt.Errorf("classifier: unknown swagger annotation %q", matches[1])
@@ -109,3 +156,9 @@ func (pc *programClassifier) Classify(prog *loader.Program) (*classifiedProgram,
 
 	return &cp, nil
 }
+
+type packagesByPath []*loader.PackageInfo
+
+func (p packagesByPath) Len() int           { return len(p) }
+func (p packagesByPath) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p packagesByPath) Less(i, j int) bool { return p[i].Pkg.Path() < p[j].Pkg.Path() }
@@ -121,3 +121,16 @@ func TestClassifierExclude(t *testing.T) {
 	//sort.Sort(sort.StringSlice(fNames))
 	//assert.EqualValues(t, []string{"order.go", "user.go"}, fNames)
 }
+
+func TestPackageFilters(t *testing.T) {
+	filters := newPackageFilters([]string{"github.com/acme/api", "github.com/acme/models/..."})
+	assert.Equal(t, []string{"github.com/acme/api", "github.com/acme/models/..."}, filters.Names())
+
+	assert.True(t, filters.Matches("github.com/acme/api"))
+	assert.False(t, filters.Matches("github.com/acme/api/internal"))
+	assert.True(t, filters.Matches("github.com/acme/models"))
+	assert.True(t, filters.Matches("github.com/acme/models/pets"))
+	assert.False(t, filters.Matches("github.com/acme/modelsv2"))
+
+	assert.False(t, newPackageFilters([]string{""}).HasFilters())
+}
@@ -0,0 +1,160 @@
+package scan
+
+import (
+	"go/ast"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/loader"
+	"golang.org/x/tools/go/types"
+
+	"github.com/vikstrous/go-swagger/spec"
+)
+
+const defaultDiscriminator = "type"
+
+// discriminatedInterface an interface annotated with swagger:discriminator
+type discriminatedInterface struct {
+	Decl  schemaDecl
+	Iface *types.Interface
+}
+
+func discriminatorName(comments *ast.CommentGroup) (string, bool) {
+	if comments != nil {
+		for _, cmt := range comments.List {
+			for _, ln := range strings.Split(cmt.Text, "\n") {
+				matches := rxDiscriminator.FindStringSubmatch(ln)
+				if len(matches) > 1 {
+					if matches[1] == "" {
+						return defaultDiscriminator, true
+					}
+					return matches[1], true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// parseDiscriminatedInterface makes the base schema for the implementers of an interface,
+// the implementers that are annotated with swagger:model are queued as definitions too
+func (scp *schemaParser) parseDiscriminatedInterface(decl *schemaDecl, schema *spec.Schema, propName string) error {
+	schema.Typed("object", "")
+	schema.Discriminator = propName
+	if _, ok := schema.Properties[propName]; !ok {
+		schema.SetProperty(propName, *spec.StringProperty())
+	}
+	required := false
+	for _, nm := range schema.Required {
+		if nm == propName {
+			required = true
+			break
+		}
+	}
+	if !required {
+		schema.Required = append(schema.Required, propName)
+	}
+
+	pkg, err := scp.packageForFile(decl.File)
+	if err != nil {
+		return err
+	}
+	iface, ok := interfaceType(pkg, decl.GoName)
+	if !ok {
+		return nil
+	}
+	scp.programWide = true
+	for _, pkgInfo := range scp.program.AllPackages {
+		scope := pkgInfo.Pkg.Scope()
+		for _, nm := range scope.Names() {
+			tn, ok := scope.Lookup(nm).(*types.TypeName)
+			if !ok || types.IsInterface(tn.Type()) || !implements(tn.Type(), iface) {
+				continue
+			}
+			file, gd, ts, err := findSourceFile(pkgInfo, nm)
+			if err != nil {
+				continue
+			}
+			sd := newSchemaDecl(file, gd, ts)
+			if sd.hasAnnotation() {
+				sd.Origin = "implements definition " + decl.Name
+				scp.discover(*sd)
+			}
+		}
+	}
+	return nil
+}
+
+// discriminatedBases finds the discriminated interfaces the type of the declaration implements,
+// they're sorted by definition name
+func (scp *schemaParser) discriminatedBases(decl *schemaDecl) ([]schemaDecl, error) {
+	if !decl.hasAnnotation() {
+		return nil, nil
+	}
+	pkg, err := scp.packageForFile(decl.File)
+	if err != nil {
+		return nil, err
+	}
+	tn, ok := pkg.Pkg.Scope().Lookup(decl.GoName).(*types.TypeName)
+	if !ok {
+		return nil, nil
+	}
+
+	var bases []schemaDecl
+	seen := make(map[string]struct{})
+	for _, di := range scp.discriminatedInterfaces() {
+		if _, ok := seen[di.Decl.Name]; ok || !implements(tn.Type(), di.Iface) {
+			continue
+		}
+		seen[di.Decl.Name] = struct{}{}
+		bases = append(bases, di.Decl)
+	}
+	sort.Sort(schemaDeclsByName(bases))
+	return bases, nil
+}
+
+// discriminatedInterfaces collects the interfaces annotated with swagger:discriminator in the program
+func (scp *schemaParser) discriminatedInterfaces() []discriminatedInterface {
+	if scp.discriminated != nil {
+		return scp.discriminated
+	}
+	scp.discriminated = []discriminatedInterface{}
+	for _, pkgInfo := range scp.program.AllPackages {
+		scope := pkgInfo.Pkg.Scope()
+		for _, nm := range scope.Names() {
+			iface, ok := interfaceType(pkgInfo, nm)
+			if !ok {
+				continue
+			}
+			file, gd, ts, err := findSourceFile(pkgInfo, nm)
+			if err != nil {
+				continue
+			}
+			if _, ok := discriminatorName(gd.Doc); ok {
+				sd := newSchemaDecl(file, gd, ts)
+				scp.discriminated = append(scp.discriminated, discriminatedInterface{Decl: *sd, Iface: iface})
+			}
+		}
+	}
+	return scp.discriminated
+}
+
+func interfaceType(pkg *loader.PackageInfo, name string) (*types.Interface, bool) {
+	tn, ok := pkg.Pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil, false
+	}
+	iface, ok := tn.Type().Underlying().(*types.Interface)
+	return iface, ok
+}
+
+// implements is true when either the type or a pointer to it implements the interface
+func implements(tpe types.Type, iface *types.Interface) bool {
+	return types.Implements(tpe, iface) || types.Implements(types.NewPointer(tpe), iface)
+}
+
+type schemaDeclsByName []schemaDecl
+
+func (s schemaDeclsByName) Len() int           { return len(s) }
+func (s schemaDeclsByName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s schemaDeclsByName) Less(i, j int) bool { return s[i].Name < s[j].Name }
@@ -21,6 +21,8 @@ http, https, ws or wss
 Host and BasePath can be specified but those values will be defaults,
 they should get substituted when serving the swagger spec.
 
+Tags is a block of yaml with a list of tags, each with a name and optionally a description and externalDocs.
+
 Default parameters and responses are not supported at this stage, for those you can edit the template json.
 
 swagger:strfmt [name]
@@ -29,6 +31,13 @@ A swagger:strfmt annotation names a type as a string formatter. The name is mand
 what will be used as format name for this particular string format.
 String formats should only be used for very well known formats.
 
+swagger:type [type] [?format]
+
+A swagger:type annotation gives a type the swagger type and format it has in json, like a struct
+that marshals to a string. Everything that uses the type gets that type and format instead of
+a ref to a definition. The types of other packages, like time.Time, are mapped with the type mappings
+of the options. The integers become integer with an int32 or int64 format.
+
 swagger:model [?model name]
 
 A swagger:model annotation optionally gets a model name as extra data on the line.
@@ -38,6 +47,21 @@ in the definitions object of swagger.
 The struct gets analyzed and all the collected models are added to the tree.
 The refs are tracked separately so that they can be renamed later on.
 
+swagger:enum [?type name]
+
+A swagger:enum annotation on a type turns the constants declared with that type into an enum.
+The values of the constants are collected in the order they are declared and become the enum
+of every schema, parameter and header that uses the type.
+The doc comments of the constants are added to the description of those as a list of values.
+
+swagger:discriminator [?property name]
+
+A swagger:discriminator annotation on an interface turns it into a schema with a discriminator.
+The property name defaults to type, the value of that property is the name of the definition
+of the type that implements the interface.
+Fields with the interface as type get a ref to that schema, and all the types annotated with
+swagger:model that implement the interface become definitions composed with allOf of that schema.
+
 swagger:route [method] [path pattern] [operation id] [?tag1 tag2 tag3]
 
 A swagger:route annotation links a path to a method.
@@ -59,5 +83,20 @@ swagger:response [?response name]
 
 Reads a struct decorated with swagger:response and uses that information to fill up the headers and the schema for a response.
 A swagger:route can specify a response name for a status code and then the matching response will be used for that operation in the swagger definition.
+
+Deprecated, Extensions and ExternalDocs
+
+Routes, models, fields and parameters can be marked with deprecated: true. Routes get the deprecated property,
+the others get an x-deprecated extension because swagger 2.0 has no deprecated property for them.
+
+An Extensions: line starts a block of yaml with vendor extensions, the names of the extensions start with x-.
+An ExternalDocs: line starts a block of yaml with a url and a description.
+Both work for routes, models, fields and the meta, parameters only get extensions.
+The lines of the block are indented below the tag:
+
+	// Extensions:
+	//   x-rate-limit: 100
+	//   x-owner:
+	//     team: pets
 */
 package scan
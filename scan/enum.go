@@ -0,0 +1,178 @@
+package scan
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/exact"
+	"golang.org/x/tools/go/loader"
+	"golang.org/x/tools/go/types"
+)
+
+// enumValue a constant declared for a type annotated with swagger:enum
+type enumValue struct {
+	Name  string
+	Value interface{}
+	Doc   string
+}
+
+func enumAnnotated(comments *ast.CommentGroup, typeName string) bool {
+	if comments != nil {
+		for _, cmt := range comments.List {
+			for _, ln := range strings.Split(cmt.Text, "\n") {
+				matches := rxEnum.FindStringSubmatch(ln)
+				if len(matches) > 1 && (matches[1] == "" || matches[1] == typeName) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// enumValues collects the constants of the type declared by the type spec, in the order they are declared
+func enumValues(pkg *loader.PackageInfo, ts *ast.TypeSpec) ([]enumValue, error) {
+	obj, ok := pkg.Info.Defs[ts.Name]
+	if !ok || obj == nil {
+		return nil, fmt.Errorf("unable to find the type of %s in %s", ts.Name.Name, pkg.String())
+	}
+
+	var values []enumValue
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.CONST {
+				continue
+			}
+			for _, spc := range gd.Specs {
+				vs, ok := spc.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				for _, nm := range vs.Names {
+					cnst, ok := pkg.Info.Defs[nm].(*types.Const)
+					if !ok || nm.Name == "_" || !types.Identical(cnst.Type(), obj.Type()) {
+						continue
+					}
+					value, err := constantValue(cnst)
+					if err != nil {
+						return nil, err
+					}
+					doc := vs.Doc
+					if doc == nil {
+						doc = vs.Comment
+					}
+					values = append(values, enumValue{Name: nm.Name, Value: value, Doc: enumValueDoc(doc)})
+				}
+			}
+		}
+	}
+	return values, nil
+}
+
+func constantValue(cnst *types.Const) (interface{}, error) {
+	val := cnst.Val()
+	switch val.Kind() {
+	case exact.String:
+		return exact.StringVal(val), nil
+	case exact.Bool:
+		return exact.BoolVal(val), nil
+	case exact.Int:
+		if i, ok := exact.Int64Val(val); ok {
+			return i, nil
+		}
+		if u, ok := exact.Uint64Val(val); ok {
+			return u, nil
+		}
+	case exact.Float:
+		if f, ok := exact.Float64Val(val); ok {
+			return f, nil
+		}
+	}
+	return nil, fmt.Errorf("the value of %s can't be used in an enum", cnst.Name())
+}
+
+func enumValueDoc(comments *ast.CommentGroup) string {
+	if comments == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(comments.Text()), " ")
+}
+
+// enumDescription lists the values of an enum with the doc comments of their constants
+func enumDescription(values []enumValue) string {
+	var lines []string
+	for _, v := range values {
+		if v.Doc == "" {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("* %v - %s", v.Value, v.Doc))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func joinDescription(desc, enumDesc string) string {
+	if enumDesc == "" {
+		return desc
+	}
+	if desc == "" {
+		return enumDesc
+	}
+	return desc + "\n" + enumDesc
+}
+
+// enumDescriptionFor finds the description of the enum used by the type of a field,
+// it's empty when the type of the field doesn't have an enum
+func (scp *schemaParser) enumDescriptionFor(gofile *ast.File, expr ast.Expr) (string, error) {
+	var pkg *loader.PackageInfo
+	var name string
+	var err error
+
+	switch tpe := expr.(type) {
+	case *ast.StarExpr:
+		return scp.enumDescriptionFor(gofile, tpe.X)
+	case *ast.ArrayType:
+		return scp.enumDescriptionFor(gofile, tpe.Elt)
+	case *ast.Ident:
+		pkg, err = scp.packageForFile(gofile)
+		name = tpe.Name
+	case *ast.SelectorExpr:
+		pkg, err = scp.packageForSelector(gofile, tpe.X)
+		name = tpe.Sel.Name
+	default:
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	_, gd, ts, err := findSourceFile(pkg, name)
+	if err != nil || !enumAnnotated(gd.Doc, ts.Name.Name) {
+		// primitives and types that aren't enums
+		return "", nil
+	}
+	values, err := enumValues(pkg, ts)
+	if err != nil {
+		return "", err
+	}
+	return enumDescription(values), nil
+}
+
+// withEnum adds the values of the type to the enum of the property when the type is annotated with swagger:enum
+func withEnum(pkg *loader.PackageInfo, gd *ast.GenDecl, ts *ast.TypeSpec, prop swaggerTypable) error {
+	if !enumAnnotated(gd.Doc, ts.Name.Name) {
+		return nil
+	}
+	values, err := enumValues(pkg, ts)
+	if err != nil {
+		return err
+	}
+	enum := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		enum = append(enum, v.Value)
+	}
+	prop.WithEnum(enum...)
+	return nil
+}
@@ -0,0 +1,170 @@
+package scan
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/vikstrous/go-swagger/spec"
+	"github.com/vikstrous/go-swagger/swag"
+)
+
+var rxCommentPrefix = regexp.MustCompile("^\\p{Zs}*//")
+
+func newSetDeprecated(set func(bool)) *setDeprecated {
+	return &setDeprecated{set: set, rx: rxDeprecated}
+}
+
+type setDeprecated struct {
+	set func(bool)
+	rx  *regexp.Regexp
+}
+
+func (sd *setDeprecated) Matches(line string) bool {
+	return sd.rx.MatchString(line)
+}
+
+func (sd *setDeprecated) Parse(lines []string) error {
+	if len(lines) == 0 || (len(lines) == 1 && len(lines[0]) == 0) {
+		return nil
+	}
+	matches := sd.rx.FindStringSubmatch(lines[0])
+	if len(matches) > 1 && len(matches[1]) > 0 {
+		sd.set(matches[1] == "true")
+	}
+	return nil
+}
+
+// deprecateExtension marks a schema or a parameter as deprecated,
+// only operations have a deprecated property in swagger 2.0
+func deprecateExtension(ext interface {
+	AddExtension(string, interface{})
+}) func(bool) {
+	return func(deprecated bool) {
+		if deprecated {
+			ext.AddExtension("x-deprecated", true)
+		}
+	}
+}
+
+// setYAMLBlock parses the indented yaml below a tag like Extensions: or ExternalDocs:
+type setYAMLBlock struct {
+	name string
+	rx   *regexp.Regexp
+	set  func(json.RawMessage) error
+}
+
+func (sy *setYAMLBlock) Matches(line string) bool {
+	return sy.rx.MatchString(line)
+}
+
+func (sy *setYAMLBlock) Parse(lines []string) error {
+	block := yamlBlock(lines)
+	if strings.TrimSpace(block) == "" {
+		return nil
+	}
+	var data interface{}
+	if err := yaml.Unmarshal([]byte(block), &data); err != nil {
+		return fmt.Errorf("invalid %s: %v", sy.name, err)
+	}
+	b, err := swag.YAMLToJSON(data)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %v", sy.name, err)
+	}
+	return sy.set(b)
+}
+
+// yamlBlock takes the comment markers and the indentation of the block off the lines.
+// The block ends at the first line that is indented less than the first line of the block.
+func yamlBlock(lines []string) string {
+	var block []string
+	indent := -1
+	for _, line := range lines {
+		if isBlankComment(line) {
+			block = append(block, "")
+			continue
+		}
+		line = strings.Replace(rxCommentPrefix.ReplaceAllString(line, ""), "\t", "    ", -1)
+		lineIndent := commentIndent(line)
+		if indent < 0 {
+			indent = lineIndent
+		}
+		if lineIndent < indent {
+			break
+		}
+		block = append(block, line[indent:])
+	}
+	return strings.Join(block, "\n")
+}
+
+func newSetExtensions(set func(spec.Extensions)) *setYAMLBlock {
+	return &setYAMLBlock{
+		name: "extensions",
+		rx:   rxExtensions,
+		set: func(data json.RawMessage) error {
+			var ext spec.Extensions
+			if err := json.Unmarshal(data, &ext); err != nil {
+				return fmt.Errorf("invalid extensions: %v", err)
+			}
+			for k := range ext {
+				if !strings.HasPrefix(strings.ToLower(k), "x-") {
+					return fmt.Errorf("invalid extension %q: the name of an extension starts with x-", k)
+				}
+			}
+			set(ext)
+			return nil
+		},
+	}
+}
+
+// addExtensions adds the parsed extensions to the ones that are already there, like x-go-name
+func addExtensions(ext interface {
+	AddExtension(string, interface{})
+}) func(spec.Extensions) {
+	return func(parsed spec.Extensions) {
+		for k, v := range parsed {
+			ext.AddExtension(k, v)
+		}
+	}
+}
+
+func newSetExternalDocs(set func(*spec.ExternalDocumentation)) *setYAMLBlock {
+	return &setYAMLBlock{
+		name: "external docs",
+		rx:   rxExternalDocs,
+		set: func(data json.RawMessage) error {
+			var docs spec.ExternalDocumentation
+			if err := json.Unmarshal(data, &docs); err != nil {
+				return fmt.Errorf("invalid external docs: %v", err)
+			}
+			if docs.URL == "" {
+				return fmt.Errorf("invalid external docs: the url is required")
+			}
+			set(&docs)
+			return nil
+		},
+	}
+}
+
+func newSetMetaTags(set func([]spec.Tag)) *setYAMLBlock {
+	return &setYAMLBlock{
+		name: "tags",
+		rx:   rxMetaTags,
+		set: func(data json.RawMessage) error {
+			var tags []spec.Tag
+			if err := json.Unmarshal(data, &tags); err != nil {
+				return fmt.Errorf("invalid tags: %v", err)
+			}
+			for _, tag := range tags {
+				if tag.Name == "" {
+					return fmt.Errorf("invalid tags: the name of a tag is required")
+				}
+			}
+			set(tags)
+			return nil
+		},
+	}
+}
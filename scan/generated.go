@@ -0,0 +1,279 @@
+package scan
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+
+	"github.com/vikstrous/go-swagger/spec"
+	"golang.org/x/tools/go/loader"
+)
+
+// generatedParamsParser reads the parameters of an operation of a generated server from its params type.
+// The fields of the params have no annotations, their location and name come from the BindRequest method:
+//
+//	o.bindPetID(route.Params.Get("petId"), route.Formats)       // a path param
+//	o.bindStatus(swag.SplitByFormat(qs.Get("status"), "multi"))  // a query param
+//	o.bindAPIKey(r.Header.Get("api_key"), route.Formats)         // a header
+//	o.bindName(r.FormValue("name"), route.Formats)               // a form field
+//	route.Consumer.Consume(r.Body, &o.Body)                     // the body
+//
+// A param is required when its bind method checks it with a Required function,
+// the binders don't check that there is a body so bodies are optional.
+type generatedParamsParser struct {
+	program *loader.Program
+	scp     *schemaParser
+}
+
+func newGeneratedParamsParser(prog *loader.Program) *generatedParamsParser {
+	return &generatedParamsParser{program: prog, scp: newSchemaParser(prog)}
+}
+
+// Parse the parameters of the params type, it's named by its import path and name
+func (gp *generatedParamsParser) Parse(params string) ([]spec.Parameter, error) {
+	i := strings.LastIndex(params, ".")
+	if i < 0 {
+		return nil, fmt.Errorf("%s isn't the name of a params type", params)
+	}
+	pkg := gp.program.Package(params[:i])
+	if pkg == nil {
+		return nil, fmt.Errorf("can't find the package of %s", params)
+	}
+	file, _, ts, err := findSourceFile(pkg, params[i+1:])
+	if err != nil {
+		return nil, err
+	}
+	st, ok := ts.Type.(*ast.StructType)
+	if !ok {
+		return nil, fmt.Errorf("%s isn't a struct", params)
+	}
+	methods := paramsMethods(pkg, ts.Name.Name)
+	bind, ok := methods["BindRequest"]
+	if !ok {
+		return nil, fmt.Errorf("%s has no BindRequest method", params)
+	}
+
+	var result []spec.Parameter
+	var parseErr error
+	add := func(field, in, name string, fn *ast.FuncDecl) {
+		if parseErr != nil {
+			return
+		}
+		param, err := gp.parameter(file, st, field, in, name)
+		if err != nil {
+			parseErr = fmt.Errorf("%s: %v", params, err)
+			return
+		}
+		param.Required = in == "path" || checksRequired(pkg, bind, name) || (fn != nil && checksRequired(pkg, fn, name))
+		result = append(result, *param)
+	}
+	ast.Inspect(bind.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.IfStmt:
+			// if err := route.Consumer.Consume(r.Body, &o.Body); err != nil { res = append(res, errors.NewParseError("body", ...)) }
+			if assign, ok := node.Init.(*ast.AssignStmt); ok && len(assign.Rhs) == 1 {
+				if field, ok := consumedField(assign.Rhs[0]); ok {
+					name := parseErrorName(pkg, node.Body)
+					if name == "" {
+						name = strings.ToLower(field[:1]) + field[1:]
+					}
+					add(field, "body", name, nil)
+					return false
+				}
+			}
+		case *ast.CallExpr:
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok || len(node.Args) == 0 {
+				return true
+			}
+			if sel.Sel.Name == "FormFile" {
+				// a file is read into an httpkit.File without a bind method
+				if name, ok := stringValue(pkg, node.Args[0]); ok {
+					result = append(result, *spec.FileParam(name).AsRequired())
+				}
+				return false
+			}
+			if !strings.HasPrefix(sel.Sel.Name, "bind") {
+				return true
+			}
+			in, name, format, ok := boundSource(pkg, node.Args[0])
+			if !ok {
+				return true
+			}
+			field := strings.TrimPrefix(sel.Sel.Name, "bind")
+			add(field, in, name, methods[sel.Sel.Name])
+			if format != "" && len(result) > 0 {
+				result[len(result)-1].CollectionFormat = format
+			}
+			return false
+		}
+		return true
+	})
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	return result, nil
+}
+
+// parameter makes the parameter for a field of the params, its type comes from the type of the field
+func (gp *generatedParamsParser) parameter(file *ast.File, st *ast.StructType, field, in, name string) (*spec.Parameter, error) {
+	for _, fld := range st.Fields.List {
+		if len(fld.Names) == 0 || fld.Names[0].Name != field {
+			continue
+		}
+		param := new(spec.Parameter)
+		param.Name = name
+		param.In = in
+		var pty swaggerTypable = paramTypable{param}
+		if in == "body" {
+			pty = schemaTypable{pty.Schema()}
+		}
+		if err := parseProperty(gp.scp, file, fld.Type, pty); err != nil {
+			return nil, err
+		}
+		return param, nil
+	}
+	return nil, fmt.Errorf("there is no field %s for the %s param %s", field, in, name)
+}
+
+// paramsMethods the methods of the params type by name
+func paramsMethods(pkg *loader.PackageInfo, typeName string) map[string]*ast.FuncDecl {
+	methods := make(map[string]*ast.FuncDecl)
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 || fn.Body == nil {
+				continue
+			}
+			recv := fn.Recv.List[0].Type
+			if star, ok := recv.(*ast.StarExpr); ok {
+				recv = star.X
+			}
+			if ident, ok := recv.(*ast.Ident); ok && ident.Name == typeName {
+				methods[fn.Name.Name] = fn
+			}
+		}
+	}
+	return methods
+}
+
+// boundSource the location and the name of the raw value a bind method is called with,
+// the collection format is set for the values that are split
+func boundSource(pkg *loader.PackageInfo, expr ast.Expr) (in, name, format string, ok bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return "", "", "", false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || len(call.Args) == 0 {
+		return "", "", "", false
+	}
+	if sel.Sel.Name == "SplitByFormat" && len(call.Args) == 2 {
+		in, name, _, ok = boundSource(pkg, call.Args[0])
+		format, _ = stringValue(pkg, call.Args[1])
+		return in, name, format, ok
+	}
+	name, ok = stringValue(pkg, call.Args[0])
+	if !ok {
+		return "", "", "", false
+	}
+	switch sel.Sel.Name {
+	case "FormValue":
+		return "formData", name, "", true
+	case "Get":
+		if src, ok := sel.X.(*ast.SelectorExpr); ok {
+			switch src.Sel.Name {
+			case "Params":
+				return "path", name, "", true
+			case "Header":
+				return "header", name, "", true
+			}
+		}
+		return "query", name, "", true
+	}
+	return "", "", "", false
+}
+
+// consumedField the field a body is consumed into, like Body for route.Consumer.Consume(r.Body, &o.Body)
+func consumedField(expr ast.Expr) (string, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 {
+		return "", false
+	}
+	if sel, ok := call.Fun.(*ast.SelectorExpr); !ok || sel.Sel.Name != "Consume" {
+		return "", false
+	}
+	ref, ok := call.Args[1].(*ast.UnaryExpr)
+	if !ok {
+		return "", false
+	}
+	field, ok := ref.X.(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+	return field.Sel.Name, true
+}
+
+// parseErrorName the name of the param in the errors.NewParseError the block reports
+func parseErrorName(pkg *loader.PackageInfo, block *ast.BlockStmt) string {
+	var name string
+	ast.Inspect(block, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || name != "" {
+			return name == ""
+		}
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "NewParseError" && len(call.Args) > 0 {
+			name, _ = stringValue(pkg, call.Args[0])
+		}
+		return true
+	})
+	return name
+}
+
+// checksRequired is true when the function calls a Required function, like validate.RequiredString, for the param
+func checksRequired(pkg *loader.PackageInfo, fn *ast.FuncDecl, name string) bool {
+	required := false
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || required {
+			return !required
+		}
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && strings.HasPrefix(sel.Sel.Name, "Required") && len(call.Args) > 0 {
+			if arg, ok := stringValue(pkg, call.Args[0]); ok && arg == name {
+				required = true
+			}
+		}
+		return true
+	})
+	return required
+}
+
+// parseGeneratedParameters gives the operations that a generated server registers the parameters of their params types,
+// the models the parameters use become definitions
+func (a *appScanner) parseGeneratedParameters() error {
+	for _, reg := range a.registrations {
+		if reg.Params == "" {
+			continue
+		}
+		op, ok := a.operations[reg.OperationID]
+		if ok && len(op.Parameters) > 0 {
+			// the parameters are annotated
+			continue
+		}
+		gp := newGeneratedParamsParser(a.prog)
+		gp.scp.typeMappings = a.typeMappings
+		gp.scp.origin = "used by the parameters of operation " + reg.OperationID
+		params, err := gp.Parse(reg.Params)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			op = new(spec.Operation)
+			op.ID = reg.OperationID
+			a.operations[op.ID] = op
+		}
+		op.Parameters = params
+		a.discovered = append(a.discovered, a.discoveredDecls(gp.scp.postDecls)...)
+	}
+	return a.processDiscovered()
+}
@@ -63,6 +63,9 @@ func newMetaParser(swspec *spec.Swagger) *sectionedParser {
 		newSingleLineTagParser("BasePath", &setMetaSingle{swspec, rxBasePath, setSwaggerBasePath}),
 		newSingleLineTagParser("Contact", &setMetaSingle{swspec, rxContact, setInfoContact}),
 		newSingleLineTagParser("License", &setMetaSingle{swspec, rxLicense, setInfoLicense}),
+		newMultiLineTagParser("Tags", newSetMetaTags(func(tags []spec.Tag) { swspec.Tags = tags })),
+		newMultiLineTagParser("Extensions", newSetExtensions(addExtensions(swspec))),
+		newMultiLineTagParser("ExternalDocs", newSetExternalDocs(func(docs *spec.ExternalDocumentation) { swspec.ExternalDocs = docs })),
 	}
 	return sp
 }
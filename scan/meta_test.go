@@ -73,6 +73,35 @@ func verifyMeta(t testing.TB, doc *spec.Swagger) {
 	assert.EqualValues(t, []string{"http", "https"}, doc.Schemes)
 	assert.Equal(t, "localhost", doc.Host)
 	assert.Equal(t, "/v2", doc.BasePath)
+
+	if assert.Len(t, doc.Tags, 2) {
+		assert.Equal(t, "pets", doc.Tags[0].Name)
+		assert.Equal(t, "Everything about the pets", doc.Tags[0].Description)
+		if assert.NotNil(t, doc.Tags[0].ExternalDocs) {
+			assert.Equal(t, "http://example.com/pets", doc.Tags[0].ExternalDocs.URL)
+		}
+		assert.Equal(t, "users", doc.Tags[1].Name)
+	}
+	assert.Equal(t, "petstore", doc.Extensions["x-api-id"])
+	assert.Equal(t, []interface{}{"internal", "partners"}, doc.Extensions["x-audience"])
+	if assert.NotNil(t, doc.ExternalDocs) {
+		assert.Equal(t, "The guide for the API", doc.ExternalDocs.Description)
+		assert.Equal(t, "http://example.com/guide", doc.ExternalDocs.URL)
+	}
+}
+
+func TestYAMLBlockErrors(t *testing.T) {
+	sp := newMetaParser(new(spec.Swagger))
+	assert.Error(t, sp.Parse(ascg("Extensions:\n  api-id: petstore")))
+
+	sp = newMetaParser(new(spec.Swagger))
+	assert.Error(t, sp.Parse(ascg("ExternalDocs:\n  description: no url")))
+
+	sp = newMetaParser(new(spec.Swagger))
+	assert.Error(t, sp.Parse(ascg("Tags:\n  - description: no name")))
+
+	sp = newMetaParser(new(spec.Swagger))
+	assert.Error(t, sp.Parse(ascg("Extensions:\n  x-api-id: [petstore")))
 }
 
 func verifyInfo(t testing.TB, info *spec.Info) {
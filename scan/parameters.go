@@ -34,6 +34,10 @@ func (pt paramTypable) SetRef(ref spec.Ref) {
 	pt.param.Ref = ref
 }
 
+func (pt paramTypable) WithEnum(values ...interface{}) {
+	pt.param.WithEnum(values...)
+}
+
 func (pt paramTypable) Items() swaggerTypable {
 	if pt.param.In == "body" {
 		// get the schema for items on the schema property
@@ -83,6 +87,10 @@ func (pt itemsTypable) Schema() *spec.Schema {
 	return nil
 }
 
+func (pt itemsTypable) WithEnum(values ...interface{}) {
+	pt.items.WithEnum(values...)
+}
+
 func (pt itemsTypable) Items() swaggerTypable {
 	if pt.items.Items == nil {
 		pt.items.Items = new(spec.Items)
@@ -212,6 +220,7 @@ func (pp *paramStructParser) parseDecl(operations map[string]*spec.Operation, de
 			operations[opid] = operation
 			operation.ID = opid
 		}
+		pp.scp.origin = "used by the parameters of operation " + opid
 
 		// analyze struct body for fields etc
 		// each exported struct field:
@@ -231,54 +240,80 @@ func (pp *paramStructParser) parseDecl(operations map[string]*spec.Operation, de
 	return nil
 }
 
-func (pp *paramStructParser) parseEmbeddedStruct(gofile *ast.File, operation *spec.Operation, expr ast.Expr, seenPreviously map[string]spec.Parameter) error {
+func (pp *paramStructParser) parseEmbeddedStruct(gofile *ast.File, operation *spec.Operation, expr ast.Expr, seenPreviously map[string]spec.Parameter) ([]string, error) {
 	switch tpe := expr.(type) {
 	case *ast.Ident:
 		// do lookup of type
 		// take primitives into account, they should result in an error for swagger
 		pkg, err := pp.scp.packageForFile(gofile)
 		if err != nil {
-			return fmt.Errorf("embedded struct: %v", err)
+			return nil, fmt.Errorf("embedded struct: %v", err)
 		}
 		file, _, ts, err := findSourceFile(pkg, tpe.Name)
 		if err != nil {
-			return fmt.Errorf("embedded struct: %v", err)
+			return nil, fmt.Errorf("embedded struct: %v", err)
 		}
 		if st, ok := ts.Type.(*ast.StructType); ok {
-			return pp.parseStructType(file, operation, st, seenPreviously)
+			return pp.parseStructFields(file, operation, st, seenPreviously)
 		}
 	case *ast.SelectorExpr:
 		// look up package, file and then type
 		pkg, err := pp.scp.packageForSelector(gofile, tpe.X)
 		if err != nil {
-			return fmt.Errorf("embedded struct: %v", err)
+			return nil, fmt.Errorf("embedded struct: %v", err)
 		}
 		file, _, ts, err := findSourceFile(pkg, tpe.Sel.Name)
 		if err != nil {
-			return fmt.Errorf("embedded struct: %v", err)
+			return nil, fmt.Errorf("embedded struct: %v", err)
 		}
 		if st, ok := ts.Type.(*ast.StructType); ok {
-			return pp.parseStructType(file, operation, st, seenPreviously)
+			return pp.parseStructFields(file, operation, st, seenPreviously)
 		}
 	}
-	return fmt.Errorf("unable to resolve embedded struct for: %v\n", expr)
+	return nil, fmt.Errorf("unable to resolve embedded struct for: %v\n", expr)
 }
 
 func (pp *paramStructParser) parseStructType(gofile *ast.File, operation *spec.Operation, tpe *ast.StructType, seenPreviously map[string]spec.Parameter) error {
+	names, err := pp.parseStructFields(gofile, operation, tpe, seenPreviously)
+	if err != nil {
+		return err
+	}
+
+	// the parameters are added in the order their fields are declared in
+	for _, k := range names {
+		p := seenPreviously[k]
+		for i, v := range operation.Parameters {
+			if v.Name == k {
+				operation.Parameters = append(operation.Parameters[:i], operation.Parameters[i+1:]...)
+				break
+			}
+		}
+		operation.Parameters = append(operation.Parameters, p)
+	}
+	return nil
+}
+
+// parseStructFields parses the parameters of the fields of a struct into seenPreviously,
+// it returns their names in the order the fields are declared in
+func (pp *paramStructParser) parseStructFields(gofile *ast.File, operation *spec.Operation, tpe *ast.StructType, seenPreviously map[string]spec.Parameter) ([]string, error) {
+	var names []string
 	if tpe.Fields != nil {
 		pt := seenPreviously
+		fieldNames := make([][]string, len(tpe.Fields.List))
 
-		for _, fld := range tpe.Fields.List {
+		for i, fld := range tpe.Fields.List {
 			if len(fld.Names) == 0 {
 				// when the embedded struct is annotated with swagger:allOf it will be used as allOf property
 				// otherwise the fields will just be included as normal properties
-				if err := pp.parseEmbeddedStruct(gofile, operation, fld.Type, pt); err != nil {
-					return err
+				names, err := pp.parseEmbeddedStruct(gofile, operation, fld.Type, pt)
+				if err != nil {
+					return nil, err
 				}
+				fieldNames[i] = names
 			}
 		}
 
-		for _, fld := range tpe.Fields.List {
+		for i, fld := range tpe.Fields.List {
 			var nm, gnm string
 			if len(fld.Names) > 0 && fld.Names[0] != nil && fld.Names[0].IsExported() {
 				nm = fld.Names[0].Name
@@ -286,7 +321,7 @@ func (pp *paramStructParser) parseStructType(gofile *ast.File, operation *spec.O
 				if fld.Tag != nil && len(strings.TrimSpace(fld.Tag.Value)) > 0 {
 					tv, err := strconv.Unquote(fld.Tag.Value)
 					if err != nil {
-						return err
+						return nil, err
 					}
 
 					if strings.TrimSpace(tv) != "" {
@@ -317,11 +352,15 @@ func (pp *paramStructParser) parseStructType(gofile *ast.File, operation *spec.O
 					pty = schemaTypable{pty.Schema()}
 				}
 				if err := parseProperty(pp.scp, gofile, fld.Type, pty); err != nil {
-					return err
+					return nil, err
+				}
+				enumDesc, err := pp.scp.enumDescriptionFor(gofile, fld.Type)
+				if err != nil {
+					return nil, err
 				}
 
 				sp := new(sectionedParser)
-				sp.setDescription = func(lines []string) { ps.Description = joinDropLast(lines) }
+				sp.setDescription = func(lines []string) { ps.Description = joinDescription(joinDropLast(lines), enumDesc) }
 				if ps.Ref.GetURL() == nil {
 					sp.taggers = []tagParser{
 						newSingleLineTagParser("maximum", &setMaximum{paramValidations{&ps}, rxf(rxMaximumFmt, "")}),
@@ -336,6 +375,20 @@ func (pp *paramStructParser) parseStructType(gofile *ast.File, operation *spec.O
 						newSingleLineTagParser("unique", &setUnique{paramValidations{&ps}, rxf(rxUniqueFmt, "")}),
 						newSingleLineTagParser("required", &setRequiredParam{&ps}),
 						newSingleLineTagParser("in", &matchOnlyParam{&ps, rxIn}),
+						newSingleLineTagParser("deprecated", newSetDeprecated(deprecateExtension(&ps))),
+						newMultiLineTagParser("extensions", newSetExtensions(addExtensions(&ps))),
+					}
+					if in == "body" {
+						sp.taggers = append(sp.taggers,
+							newSingleLineTagParser("default", newSetDefault(schemaValueType(ps.Schema), func(v interface{}) { ps.Schema.Default = v })),
+							newSingleLineTagParser("example", newSetExample(schemaValueType(ps.Schema), func(v interface{}) { ps.Schema.Example = v })),
+						)
+					} else {
+						// parameters don't have examples, so it goes in an extension
+						sp.taggers = append(sp.taggers,
+							newSingleLineTagParser("default", newSetDefault(simpleValueType(ps.Type, ps.Format, ps.Items), func(v interface{}) { ps.Default = v })),
+							newSingleLineTagParser("example", newSetExample(simpleValueType(ps.Type, ps.Format, ps.Items), func(v interface{}) { ps.AddExtension("x-example", v) })),
+						)
 					}
 					itemsTaggers := func() []tagParser {
 						return []tagParser{
@@ -371,7 +424,10 @@ func (pp *paramStructParser) parseStructType(gofile *ast.File, operation *spec.O
 					}
 				}
 				if err := sp.Parse(fld.Doc); err != nil {
-					return err
+					return nil, err
+				}
+				if err := pp.scp.applyTagValidations(fld, paramValidationTarget(&ps)); err != nil {
+					return nil, err
 				}
 
 				if ps.Name == "" {
@@ -382,19 +438,19 @@ func (pp *paramStructParser) parseStructType(gofile *ast.File, operation *spec.O
 					ps.AddExtension("x-go-name", gnm)
 				}
 				pt[nm] = ps
+				fieldNames[i] = []string{nm}
 			}
 		}
 
-		for k, p := range pt {
-			for i, v := range operation.Parameters {
-				if v.Name == k {
-					operation.Parameters = append(operation.Parameters[:i], operation.Parameters[i+1:]...)
-					break
+		seen := make(map[string]bool)
+		for _, fn := range fieldNames {
+			for _, nm := range fn {
+				if !seen[nm] {
+					seen[nm] = true
+					names = append(names, nm)
 				}
 			}
-			operation.Parameters = append(operation.Parameters, p)
 		}
 	}
-
-	return nil
+	return names, nil
 }
@@ -26,16 +26,22 @@ func TestParamsParser(t *testing.T) {
 	cr, ok := noParamOps["yetAnotherOperation"]
 	assert.True(t, ok)
 	assert.Len(t, cr.Parameters, 6)
+	// in the order the fields are declared, with the fields of embedded structs in place
+	var names []string
+	for _, param := range cr.Parameters {
+		names = append(names, param.Name)
+	}
+	assert.Equal(t, []string{"id", "name", "age", "notes", "extra", "createdAt"}, names)
 	for _, param := range cr.Parameters {
 		switch param.Name {
 		case "id":
-			assert.Equal(t, "number", param.Type)
+			assert.Equal(t, "integer", param.Type)
 			assert.Equal(t, "int64", param.Format)
 		case "name":
 			assert.Equal(t, "string", param.Type)
 			assert.Equal(t, "", param.Format)
 		case "age":
-			assert.Equal(t, "number", param.Type)
+			assert.Equal(t, "integer", param.Type)
 			assert.Equal(t, "int32", param.Format)
 		case "notes":
 			assert.Equal(t, "string", param.Type)
@@ -67,7 +73,7 @@ func TestParamsParser(t *testing.T) {
 		case "id":
 			assert.Equal(t, "ID of this no model instance.\nids in this application start at 11 and are smaller than 1000", param.Description)
 			assert.Equal(t, "path", param.In)
-			assert.Equal(t, "number", param.Type)
+			assert.Equal(t, "integer", param.Type)
 			assert.Equal(t, "int64", param.Format)
 			assert.True(t, param.Required)
 			assert.Equal(t, "ID", param.Extensions["x-go-name"])
@@ -79,7 +85,7 @@ func TestParamsParser(t *testing.T) {
 		case "score":
 			assert.Equal(t, "The Score of this model", param.Description)
 			assert.Equal(t, "query", param.In)
-			assert.Equal(t, "number", param.Type)
+			assert.Equal(t, "integer", param.Type)
 			assert.Equal(t, "int32", param.Format)
 			assert.True(t, param.Required)
 			assert.Equal(t, "Score", param.Extensions["x-go-name"])
@@ -133,7 +139,7 @@ func TestParamsParser(t *testing.T) {
 			itprop := aprop.Items.Schema
 			assert.Len(t, itprop.Properties, 4)
 			assert.Len(t, itprop.Required, 3)
-			assertProperty(t, itprop, "number", "id", "int32", "ID")
+			assertProperty(t, itprop, "integer", "id", "int32", "ID")
 			iprop, ok := itprop.Properties["id"]
 			assert.True(t, ok)
 			assert.Equal(t, "ID of this no model instance.\nids in this application start at 11 and are smaller than 1000", iprop.Description)
@@ -148,7 +154,7 @@ func TestParamsParser(t *testing.T) {
 			assert.True(t, ok)
 			assert.Equal(t, "The Pet to add to this NoModel items bucket.\nPets can appear more than once in the bucket", iprop.Description)
 
-			assertProperty(t, itprop, "number", "quantity", "int16", "Quantity")
+			assertProperty(t, itprop, "integer", "quantity", "int32", "Quantity")
 			iprop, ok = itprop.Properties["quantity"]
 			assert.True(t, ok)
 			assert.Equal(t, "The amount of pets to add to this bucket.", iprop.Description)
@@ -165,3 +171,141 @@ func TestParamsParser(t *testing.T) {
 		}
 	}
 }
+
+func TestParamsParserEnums(t *testing.T) {
+	docFile := "../fixtures/goparsing/classification/operations/enums.go"
+	fileTree, err := goparser.ParseFile(classificationProg.Fset, docFile, nil, goparser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sp := newParameterParser(classificationProg)
+	operations := make(map[string]*spec.Operation)
+	err = sp.Parse(fileTree, operations)
+	if assert.NoError(t, err) {
+		op, ok := operations["listPets"]
+		if assert.True(t, ok) && assert.Len(t, op.Parameters, 2) {
+			for _, param := range op.Parameters {
+				switch param.Name {
+				case "status":
+					assert.Equal(t, "string", param.Type)
+					assert.Equal(t, []interface{}{"available", "pending", "sold"}, param.Enum)
+					assert.Contains(t, param.Description, "* sold - the pet has a new home")
+				case "priorities":
+					assert.Equal(t, "array", param.Type)
+					if assert.NotNil(t, param.Items) {
+						assert.Equal(t, []interface{}{int64(1), int64(2), int64(3)}, param.Items.Enum)
+					}
+					assert.Contains(t, param.Description, "* 1 - PriorityLow can wait")
+				default:
+					t.Errorf("unexpected parameter %s", param.Name)
+				}
+			}
+		}
+	}
+}
+
+func TestParamsParserDefaultsAndExamples(t *testing.T) {
+	docFile := "../fixtures/goparsing/classification/operations/examples.go"
+	fileTree, err := goparser.ParseFile(classificationProg.Fset, docFile, nil, goparser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sp := newParameterParser(classificationProg)
+	operations := make(map[string]*spec.Operation)
+	if !assert.NoError(t, sp.Parse(fileTree, operations)) {
+		return
+	}
+	op, ok := operations["updateSettings"]
+	if assert.True(t, ok) && assert.Len(t, op.Parameters, 3) {
+		for _, param := range op.Parameters {
+			switch param.Name {
+			case "limit":
+				assert.Equal(t, int64(20), param.Default)
+				assert.Equal(t, int64(50), param.Extensions["x-example"])
+				assert.Equal(t, "The number of items to return", param.Description)
+			case "ids":
+				assert.Equal(t, []interface{}{int64(1), int64(2)}, param.Default)
+			case "settings":
+				assert.Nil(t, param.Default)
+				if assert.NotNil(t, param.Schema) {
+					assert.Equal(t, "#/definitions/settings", param.Schema.Ref.String())
+					assert.Equal(t, map[string]interface{}{"name": "dark"}, param.Schema.Example)
+				}
+			default:
+				t.Errorf("unexpected parameter %s", param.Name)
+			}
+		}
+	}
+}
+
+func TestParamsParserTagValidations(t *testing.T) {
+	docFile := "../fixtures/goparsing/classification/operations/tagged.go"
+	fileTree, err := goparser.ParseFile(classificationProg.Fset, docFile, nil, goparser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var warnings []string
+	sp := newParameterParser(classificationProg)
+	sp.scp.validationTags = []string{"validate", "binding"}
+	sp.scp.warn = func(msg string) { warnings = append(warnings, msg) }
+	operations := make(map[string]*spec.Operation)
+	if !assert.NoError(t, sp.Parse(fileTree, operations)) {
+		return
+	}
+	op, ok := operations["listTagged"]
+	if assert.True(t, ok) && assert.Len(t, op.Parameters, 2) {
+		for _, param := range op.Parameters {
+			switch param.Name {
+			case "limit":
+				assert.True(t, param.Required)
+				if assert.NotNil(t, param.Minimum) && assert.NotNil(t, param.Maximum) {
+					assert.Equal(t, float64(1), *param.Minimum)
+					assert.Equal(t, float64(100), *param.Maximum)
+				}
+			case "ids":
+				assert.False(t, param.Required)
+				if assert.NotNil(t, param.MinItems) {
+					assert.EqualValues(t, 2, *param.MinItems)
+				}
+				if assert.NotNil(t, param.Items) && assert.NotNil(t, param.Items.Minimum) {
+					assert.Equal(t, float64(0), *param.Items.Minimum)
+					assert.True(t, param.Items.ExclusiveMinimum)
+				}
+			default:
+				t.Errorf("unexpected parameter %s", param.Name)
+			}
+		}
+	}
+	if assert.Len(t, warnings, 1) {
+		assert.Contains(t, warnings[0], "IDs: the binding tag sets minItems to 1 but the comments set it to 2")
+	}
+}
+
+func TestParamsParserExtensions(t *testing.T) {
+	docFile := "../fixtures/goparsing/classification/operations/extensions.go"
+	fileTree, err := goparser.ParseFile(classificationProg.Fset, docFile, nil, goparser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sp := newParameterParser(classificationProg)
+	operations := make(map[string]*spec.Operation)
+	if !assert.NoError(t, sp.Parse(fileTree, operations)) {
+		return
+	}
+	op, ok := operations["getLegacy"]
+	if assert.True(t, ok) && assert.Len(t, op.Parameters, 2) {
+		for _, param := range op.Parameters {
+			switch param.Name {
+			case "id":
+				assert.Nil(t, param.Extensions["x-deprecated"])
+				assert.True(t, param.Required)
+			case "version":
+				assert.Equal(t, "The version of the model", param.Description)
+				assert.Equal(t, true, param.Extensions["x-deprecated"])
+				assert.Equal(t, 1.2, param.Extensions["x-since"])
+			default:
+				assert.Fail(t, "unknown property: "+param.Name)
+			}
+		}
+	}
+}
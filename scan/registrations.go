@@ -0,0 +1,485 @@
+package scan
+
+import (
+	"go/ast"
+	"go/build"
+	"go/token"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/exact"
+	"golang.org/x/tools/go/loader"
+	"golang.org/x/tools/go/types"
+
+	"github.com/vikstrous/go-swagger/spec"
+	"github.com/vikstrous/go-swagger/swag"
+)
+
+var (
+	httpMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+	rxRouteParam = regexp.MustCompile("^(?::|\\*)(.+)$|^\\{([^:}.]+)(?::[^}]*|\\.\\.\\.)?\\}$")
+)
+
+// routeRegistration a handler that is registered with a router in the code.
+// Handlers registered with an untyped api only have an operation id, the others have a path.
+type routeRegistration struct {
+	Methods     []string
+	Path        string
+	OperationID string
+	Handler     string
+	Pos         token.Position
+	// Params the params type of an operation of a generated server, as its import path and name
+	Params string `json:",omitempty"`
+}
+
+func (r *routeRegistration) String() string {
+	if r.OperationID != "" {
+		return "operation " + r.OperationID
+	}
+	if len(r.Methods) == 0 {
+		return r.Path
+	}
+	return strings.Join(r.Methods, ",") + " " + r.Path
+}
+
+// registrationsParser finds the calls that register handlers with a router.
+// These are the Handle and HandleFunc functions of net/http and the methods of the same name on a ServeMux,
+// their pattern can start with a method like "GET /pets/{id}". Handle and HandleFunc methods on other routers
+// are used too, a call to Methods on their result limits the methods of the route.
+// Methods named after an http method, like GET or Get, take a path and a handler,
+// Handler methods take a method, a path and a handler. The methods of other types only count when their receiver
+// is an http.Handler or when they take a handler.
+// The handlers registered with RegisterOperation on an untyped api only have an operation id,
+// and so do the handlers a generated server puts in its map of handlers.
+type registrationsParser struct {
+	program       *loader.Program
+	registrations []*routeRegistration
+}
+
+func newRegistrationsParser(prog *loader.Program) *registrationsParser {
+	return &registrationsParser{program: prog}
+}
+
+// Parse collects the registrations in the files of a package
+func (rp *registrationsParser) Parse(pkg *loader.PackageInfo) {
+	for _, file := range pkg.Files {
+		var chained map[*ast.CallExpr]bool
+		ast.Inspect(file, func(n ast.Node) bool {
+			if assign, ok := n.(*ast.AssignStmt); ok && len(assign.Lhs) == len(assign.Rhs) {
+				for i, lhs := range assign.Lhs {
+					if reg := rp.generatedRegistration(pkg, lhs, assign.Rhs[i]); reg != nil {
+						rp.add(reg)
+					}
+				}
+				return true
+			}
+			call, ok := n.(*ast.CallExpr)
+			if !ok || chained[call] {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			// a router call with .Methods("GET") chained to it
+			if sel.Sel.Name == "Methods" {
+				if inner, ok := sel.X.(*ast.CallExpr); ok {
+					if reg := rp.registration(pkg, inner); reg != nil {
+						for _, arg := range call.Args {
+							if m, ok := stringValue(pkg, arg); ok {
+								reg.Methods = append(reg.Methods, strings.ToUpper(m))
+							}
+						}
+						rp.add(reg)
+						if chained == nil {
+							chained = make(map[*ast.CallExpr]bool)
+						}
+						chained[inner] = true
+					}
+				}
+				return true
+			}
+			if reg := rp.registration(pkg, call); reg != nil {
+				rp.add(reg)
+			}
+			return true
+		})
+	}
+}
+
+func (rp *registrationsParser) add(reg *routeRegistration) {
+	reg.Path = normalizeRoutePath(reg.Path)
+	rp.registrations = append(rp.registrations, reg)
+}
+
+func (rp *registrationsParser) registration(pkg *loader.PackageInfo, call *ast.CallExpr) *routeRegistration {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	name := sel.Sel.Name
+	reg := &routeRegistration{Pos: rp.program.Fset.Position(call.Pos())}
+
+	if fn, ok := pkg.Info.Uses[sel.Sel].(*types.Func); ok && fn.Pkg() != nil {
+		switch {
+		case fn.Pkg().Path() == "net/http" && (name == "Handle" || name == "HandleFunc"):
+			if len(call.Args) != 2 {
+				return nil
+			}
+			pattern, ok := stringValue(pkg, call.Args[0])
+			if !ok {
+				return nil
+			}
+			if parts := strings.Fields(pattern); len(parts) == 2 {
+				reg.Methods = []string{strings.ToUpper(parts[0])}
+				pattern = parts[1]
+			}
+			if !strings.HasPrefix(pattern, "/") {
+				// patterns for a host aren't routes of the api
+				return nil
+			}
+			reg.Path = pattern
+			reg.Handler = handlerName(call.Args[1])
+			return reg
+
+		case strings.HasSuffix(fn.Pkg().Path(), "httpkit/middleware/untyped") && name == "RegisterOperation":
+			if len(call.Args) != 2 {
+				return nil
+			}
+			id, ok := stringValue(pkg, call.Args[0])
+			if !ok {
+				return nil
+			}
+			reg.OperationID = id
+			reg.Handler = handlerName(call.Args[1])
+			return reg
+		}
+	}
+
+	switch {
+	case (name == "Handle" || name == "HandleFunc") && len(call.Args) == 2 && registersHandler(pkg, call, 1):
+		pth, ok := stringValue(pkg, call.Args[0])
+		if !ok || !strings.HasPrefix(pth, "/") {
+			return nil
+		}
+		reg.Path = pth
+		reg.Handler = handlerName(call.Args[1])
+		return reg
+
+	case name == "Handler" && len(call.Args) == 3 && registersHandler(pkg, call, 2):
+		method, ok := stringValue(pkg, call.Args[0])
+		if !ok || !swag.ContainsStringsCI(httpMethods, method) {
+			return nil
+		}
+		pth, ok := stringValue(pkg, call.Args[1])
+		if !ok || !strings.HasPrefix(pth, "/") {
+			return nil
+		}
+		reg.Methods = []string{strings.ToUpper(method)}
+		reg.Path = pth
+		reg.Handler = handlerName(call.Args[2])
+		return reg
+
+	case swag.ContainsStringsCI(httpMethods, name) && len(call.Args) >= 2 && registersHandler(pkg, call, len(call.Args)-1):
+		pth, ok := stringValue(pkg, call.Args[0])
+		if !ok || !strings.HasPrefix(pth, "/") {
+			return nil
+		}
+		reg.Methods = []string{strings.ToUpper(name)}
+		reg.Path = pth
+		reg.Handler = handlerName(call.Args[len(call.Args)-1])
+		return reg
+	}
+	return nil
+}
+
+// generatedRegistration the registration of an operation in the map of handlers of a generated server,
+// like o.handlers["getPetById"] = pet.NewGetPetByID(o.context, o.GetPetByIDHandler)
+func (rp *registrationsParser) generatedRegistration(pkg *loader.PackageInfo, lhs, rhs ast.Expr) *routeRegistration {
+	index, ok := lhs.(*ast.IndexExpr)
+	if !ok {
+		return nil
+	}
+	tpe := pkg.Info.TypeOf(index.X)
+	if tpe == nil {
+		return nil
+	}
+	handlers, ok := tpe.Underlying().(*types.Map)
+	if !ok || !isHTTPHandler(handlers.Elem()) {
+		return nil
+	}
+	id, ok := stringValue(pkg, index.Index)
+	if !ok {
+		return nil
+	}
+	reg := &routeRegistration{OperationID: id, Handler: handlerName(rhs), Pos: rp.program.Fset.Position(lhs.Pos())}
+	if call, ok := rhs.(*ast.CallExpr); ok && len(call.Args) > 0 {
+		reg.Handler = handlerName(call.Args[len(call.Args)-1])
+		reg.Params = generatedParams(pkg, call)
+	}
+	return reg
+}
+
+// generatedParams the params type of the operation a generated server makes with New<Operation>(ctx, handler),
+// it's the first parameter of the Handle method of the handler
+func generatedParams(pkg *loader.PackageInfo, call *ast.CallExpr) string {
+	sig, ok := pkg.Info.TypeOf(call.Fun).(*types.Signature)
+	if !ok {
+		return ""
+	}
+	for i := 0; i < sig.Params().Len(); i++ {
+		obj, _, _ := types.LookupFieldOrMethod(sig.Params().At(i).Type(), false, nil, "Handle")
+		handle, ok := obj.(*types.Func)
+		if !ok {
+			continue
+		}
+		params := handle.Type().(*types.Signature).Params()
+		if params.Len() == 0 {
+			continue
+		}
+		if named, ok := params.At(0).Type().(*types.Named); ok && named.Obj().Pkg() != nil {
+			return named.Obj().Pkg().Path() + "." + named.Obj().Name()
+		}
+	}
+	return ""
+}
+
+// registersHandler is true when the method called is one of a router, its receiver is an http.Handler,
+// or when the argument at the index is a handler, so calls like cache.Get("/key", value) aren't routes
+func registersHandler(pkg *loader.PackageInfo, call *ast.CallExpr, arg int) bool {
+	sel := call.Fun.(*ast.SelectorExpr)
+	if recv := pkg.Info.TypeOf(sel.X); recv != nil && isHTTPHandler(recv) {
+		return true
+	}
+	sig, ok := pkg.Info.TypeOf(call.Fun).(*types.Signature)
+	if !ok {
+		return false
+	}
+	params := sig.Params()
+	if params.Len() == 0 {
+		return false
+	}
+	var param types.Type
+	if arg >= params.Len()-1 {
+		param = params.At(params.Len() - 1).Type()
+		if slice, ok := param.(*types.Slice); ok && sig.Variadic() {
+			param = slice.Elem()
+		}
+	} else {
+		param = params.At(arg).Type()
+	}
+	if isHandlerType(param) {
+		return true
+	}
+	argType := pkg.Info.TypeOf(call.Args[arg])
+	return argType != nil && isHandlerType(argType)
+}
+
+// isHandlerType is true for http handlers, for the handler types of routers, like denco.HandlerFunc or gin.HandlerFunc,
+// and for the functions that take the request or the response writer of net/http
+func isHandlerType(tpe types.Type) bool {
+	if isHTTPHandler(tpe) {
+		return true
+	}
+	if named, ok := tpe.(*types.Named); ok {
+		name := named.Obj().Name()
+		if strings.HasSuffix(name, "Handler") || strings.HasSuffix(name, "HandlerFunc") {
+			return true
+		}
+	}
+	sig, ok := tpe.Underlying().(*types.Signature)
+	if !ok {
+		return false
+	}
+	for i := 0; i < sig.Params().Len(); i++ {
+		switch sig.Params().At(i).Type().String() {
+		case "*net/http.Request", "net/http.ResponseWriter":
+			return true
+		}
+	}
+	return false
+}
+
+// isHTTPHandler is true when the type has the ServeHTTP method of an http.Handler
+func isHTTPHandler(tpe types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(tpe, true, nil, "ServeHTTP")
+	_, ok := obj.(*types.Func)
+	return ok
+}
+
+// stringValue the value of a constant string expression
+func stringValue(pkg *loader.PackageInfo, expr ast.Expr) (string, bool) {
+	tv, ok := pkg.Info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != exact.String {
+		return "", false
+	}
+	return exact.StringVal(tv.Value), true
+}
+
+// handlerName the name of the function or method that handles a route,
+// conversions like http.HandlerFunc(fn) are looked through
+func handlerName(expr ast.Expr) string {
+	switch tpe := expr.(type) {
+	case *ast.Ident:
+		return tpe.Name
+	case *ast.SelectorExpr:
+		return tpe.Sel.Name
+	case *ast.ParenExpr:
+		return handlerName(tpe.X)
+	case *ast.UnaryExpr:
+		return handlerName(tpe.X)
+	case *ast.CallExpr:
+		if len(tpe.Args) == 1 {
+			return handlerName(tpe.Args[0])
+		}
+	}
+	return ""
+}
+
+// normalizeRoutePath rewrites the path params of the routers, like :id, *path and {id:[0-9]+},
+// to the {id} form swagger uses
+func normalizeRoutePath(pth string) string {
+	segments := strings.Split(pth, "/")
+	for i, seg := range segments {
+		matches := rxRouteParam.FindStringSubmatch(seg)
+		if len(matches) == 0 {
+			continue
+		}
+		if matches[1] != "" {
+			segments[i] = "{" + matches[1] + "}"
+		} else {
+			segments[i] = "{" + matches[2] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// annotatedRoute an operation in the paths of the spec
+type annotatedRoute struct {
+	Method    string
+	Path      string
+	Operation *spec.Operation
+}
+
+func annotatedRoutes(paths *spec.Paths) []annotatedRoute {
+	var routes []annotatedRoute
+	if paths == nil {
+		return routes
+	}
+	for pth, item := range paths.Paths {
+		ops := []*spec.Operation{item.Get, item.Post, item.Put, item.Patch, item.Delete, item.Head, item.Options}
+		for i, op := range ops {
+			if op != nil {
+				routes = append(routes, annotatedRoute{Method: httpMethods[i], Path: pth, Operation: op})
+			}
+		}
+	}
+	sort.Sort(annotatedRoutesByPath(routes))
+	return routes
+}
+
+type annotatedRoutesByPath []annotatedRoute
+
+func (a annotatedRoutesByPath) Len() int      { return len(a) }
+func (a annotatedRoutesByPath) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a annotatedRoutesByPath) Less(i, j int) bool {
+	if a[i].Path == a[j].Path {
+		return a[i].Method < a[j].Method
+	}
+	return a[i].Path < a[j].Path
+}
+
+// matches is true when the registration is for the route of an api with the base path
+func (r *routeRegistration) matches(route annotatedRoute, basePath string) bool {
+	if r.OperationID != "" {
+		return r.OperationID == route.Operation.ID
+	}
+	if r.pathIn(basePath) != route.Path {
+		return false
+	}
+	return len(r.Methods) == 0 || swag.ContainsStringsCI(r.Methods, route.Method)
+}
+
+// pathIn the path of the registration relative to the base path of the api
+func (r *routeRegistration) pathIn(basePath string) string {
+	return relativePath(r.Path, basePath)
+}
+
+// relativePath the path without the base path of the api, routers are often given the full path of a route
+func relativePath(pth, basePath string) string {
+	basePath = strings.TrimSuffix(basePath, "/")
+	if basePath != "" && strings.HasPrefix(pth, basePath+"/") {
+		return strings.TrimPrefix(pth, basePath)
+	}
+	return pth
+}
+
+// stubOperations makes operations for the registered routes that aren't annotated with swagger:route,
+// a registration without methods is assumed to be for GET
+func stubOperations(paths *spec.Paths, operations map[string]*spec.Operation, reg *routeRegistration, routes []annotatedRoute, basePath string) []string {
+	pth := reg.pathIn(basePath)
+	methods := reg.Methods
+	if len(methods) == 0 {
+		methods = []string{"GET"}
+	}
+	var stubbed []string
+	for _, method := range methods {
+		found := false
+		for _, route := range routes {
+			if route.Path == pth && route.Method == method {
+				found = true
+				break
+			}
+		}
+		if found || !swag.ContainsStringsCI(httpMethods, method) {
+			continue
+		}
+
+		// the id comes from the handler, unless the handler is used for more than one route
+		id := swag.ToJSONName(reg.Handler)
+		if _, exists := operations[id]; exists || id == "" {
+			id = swag.ToJSONName(strings.ToLower(method) + " " + swag.ToGoName(pth))
+		}
+		op := new(spec.Operation)
+		op.ID = id
+		op.Description = "This route is registered in the code but it has no swagger:route annotation."
+		op.Responses = new(spec.Responses)
+		op.Responses.Default = spec.NewResponse().WithDescription("unknown, the handler isn't documented")
+		operations[id] = op
+
+		if paths.Paths == nil {
+			paths.Paths = make(map[string]spec.PathItem)
+		}
+		item := paths.Paths[pth]
+		switch method {
+		case "GET":
+			item.Get = op
+		case "POST":
+			item.Post = op
+		case "PUT":
+			item.Put = op
+		case "PATCH":
+			item.Patch = op
+		case "DELETE":
+			item.Delete = op
+		case "HEAD":
+			item.Head = op
+		case "OPTIONS":
+			item.Options = op
+		}
+		paths.Paths[pth] = item
+		stubbed = append(stubbed, method)
+	}
+	return stubbed
+}
+
+// isGoroot is true for the packages of the standard library, their registrations aren't part of the api
+func isGoroot(ctx *build.Context, pkg *loader.PackageInfo) bool {
+	if ctx == nil {
+		ctx = &build.Default
+	}
+	bp, err := ctx.Import(pkg.Pkg.Path(), "", build.FindOnly)
+	return err == nil && bp.Goroot
+}
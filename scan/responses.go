@@ -45,6 +45,14 @@ func (ht responseTypable) Items() swaggerTypable {
 	return itemsTypable{ht.header.Items}
 }
 
+func (ht responseTypable) WithEnum(values ...interface{}) {
+	if ht.in == "body" {
+		ht.Schema().WithEnum(values...)
+		return
+	}
+	ht.header.Enum = append([]interface{}{}, values...)
+}
+
 func (ht responseTypable) SetRef(ref spec.Ref) {
 	// having trouble seeing the usefulness of this one here
 	ht.Schema().Ref = ref
@@ -173,6 +181,7 @@ func (rp *responseParser) parseDecl(responses map[string]spec.Response, decl res
 	// parameters dictionary that got passed into this parse method
 	response := responses[decl.Name]
 	resPtr := &response
+	rp.scp.origin = "used by response " + decl.Name
 
 	// analyze doc comment for the model
 	sp := new(sectionedParser)
@@ -281,9 +290,13 @@ func (rp *responseParser) parseStructType(gofile *ast.File, response *spec.Respo
 				if err := parseProperty(rp.scp, gofile, fld.Type, responseTypable{in, &ps, response}); err != nil {
 					return err
 				}
+				enumDesc, err := rp.scp.enumDescriptionFor(gofile, fld.Type)
+				if err != nil {
+					return err
+				}
 
 				sp := new(sectionedParser)
-				sp.setDescription = func(lines []string) { ps.Description = joinDropLast(lines) }
+				sp.setDescription = func(lines []string) { ps.Description = joinDescription(joinDropLast(lines), enumDesc) }
 				sp.taggers = []tagParser{
 					newSingleLineTagParser("maximum", &setMaximum{headerValidations{&ps}, rxf(rxMaximumFmt, "")}),
 					newSingleLineTagParser("minimum", &setMinimum{headerValidations{&ps}, rxf(rxMinimumFmt, "")}),
@@ -296,6 +309,17 @@ func (rp *responseParser) parseStructType(gofile *ast.File, response *spec.Respo
 					newSingleLineTagParser("maxItems", &setMaxItems{headerValidations{&ps}, rxf(rxMaxItemsFmt, "")}),
 					newSingleLineTagParser("unique", &setUnique{headerValidations{&ps}, rxf(rxUniqueFmt, "")}),
 				}
+				if in == "body" {
+					sp.taggers = append(sp.taggers,
+						newSingleLineTagParser("default", newSetDefault(schemaValueType(response.Schema), func(v interface{}) { response.Schema.Default = v })),
+						newSingleLineTagParser("example", newSetExample(schemaValueType(response.Schema), func(v interface{}) { response.Schema.Example = v })),
+					)
+				} else {
+					// headers don't have examples
+					sp.taggers = append(sp.taggers,
+						newSingleLineTagParser("default", newSetDefault(simpleValueType(ps.Type, ps.Format, ps.Items), func(v interface{}) { ps.Default = v })),
+					)
+				}
 				itemsTaggers := func() []tagParser {
 					return []tagParser{
 						newSingleLineTagParser("itemsMaximum", &setMaximum{itemsValidations{ps.Items}, rxf(rxMaximumFmt, rxItemsPrefix)}),
@@ -327,6 +351,9 @@ func (rp *responseParser) parseStructType(gofile *ast.File, response *spec.Respo
 				}
 
 				if in != "body" {
+					if err := rp.scp.applyTagValidations(fld, headerValidationTarget(&ps)); err != nil {
+						return err
+					}
 					seenProperties[nm] = struct{}{}
 					if response.Headers == nil {
 						response.Headers = make(map[string]spec.Header)
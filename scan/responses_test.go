@@ -29,13 +29,13 @@ func TestParseResponses(t *testing.T) {
 	for k, header := range cr.Headers {
 		switch k {
 		case "id":
-			assert.Equal(t, "number", header.Type)
+			assert.Equal(t, "integer", header.Type)
 			assert.Equal(t, "int64", header.Format)
 		case "name":
 			assert.Equal(t, "string", header.Type)
 			assert.Equal(t, "", header.Format)
 		case "age":
-			assert.Equal(t, "number", header.Type)
+			assert.Equal(t, "integer", header.Type)
 			assert.Equal(t, "int32", header.Format)
 		case "notes":
 			assert.Equal(t, "string", header.Type)
@@ -57,7 +57,7 @@ func TestParseResponses(t *testing.T) {
 		switch k {
 		case "id":
 			assert.Equal(t, "ID of this some response instance.\nids in this application start at 11 and are smaller than 1000", header.Description)
-			assert.Equal(t, "number", header.Type)
+			assert.Equal(t, "integer", header.Type)
 			assert.Equal(t, "int64", header.Format)
 			//assert.Equal(t, "ID", header.Extensions["x-go-name"])
 			assert.EqualValues(t, 1000, *header.Maximum)
@@ -67,7 +67,7 @@ func TestParseResponses(t *testing.T) {
 
 		case "score":
 			assert.Equal(t, "The Score of this model", header.Description)
-			assert.Equal(t, "number", header.Type)
+			assert.Equal(t, "integer", header.Type)
 			assert.Equal(t, "int32", header.Format)
 			//assert.Equal(t, "Score", header.Extensions["x-go-name"])
 			assert.EqualValues(t, 45, *header.Maximum)
@@ -116,7 +116,7 @@ func TestParseResponses(t *testing.T) {
 	itprop := aprop.Items.Schema
 	assert.Len(t, itprop.Properties, 4)
 	assert.Len(t, itprop.Required, 3)
-	assertProperty(t, itprop, "number", "id", "int32", "ID")
+	assertProperty(t, itprop, "integer", "id", "int32", "ID")
 	iprop, ok := itprop.Properties["id"]
 	assert.True(t, ok)
 	assert.Equal(t, "ID of this some response instance.\nids in this application start at 11 and are smaller than 1000", iprop.Description)
@@ -131,7 +131,7 @@ func TestParseResponses(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, "The Pet to add to this NoModel items bucket.\nPets can appear more than once in the bucket", iprop.Description)
 
-	assertProperty(t, itprop, "number", "quantity", "int16", "Quantity")
+	assertProperty(t, itprop, "integer", "quantity", "int32", "Quantity")
 	iprop, ok = itprop.Properties["quantity"]
 	assert.True(t, ok)
 	assert.Equal(t, "The amount of pets to add to this bucket.", iprop.Description)
@@ -148,3 +148,67 @@ func TestParseResponses(t *testing.T) {
 	assert.NotNil(t, res.Schema)
 	assert.Equal(t, "#/definitions/user", res.Schema.Ref.String())
 }
+
+func TestParseResponsesEnums(t *testing.T) {
+	docFile := "../fixtures/goparsing/classification/operations/enums.go"
+	fileTree, err := goparser.ParseFile(classificationProg.Fset, docFile, nil, goparser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rp := newResponseParser(classificationProg)
+	responses := make(map[string]spec.Response)
+	err = rp.Parse(fileTree, responses)
+	if assert.NoError(t, err) {
+		res, ok := responses["enumResponse"]
+		if assert.True(t, ok) {
+			header, ok := res.Headers["X-Pet-Status"]
+			if assert.True(t, ok) {
+				assert.Equal(t, "string", header.Type)
+				assert.Equal(t, []interface{}{"available", "pending", "sold"}, header.Enum)
+				assert.Equal(t, "The status of the pet\n* available - PetStatusAvailable the pet can be adopted\n* pending - PetStatusPending the adoption of the pet is being processed\n* sold - the pet has a new home", header.Description)
+			}
+		}
+	}
+}
+
+func TestParseResponsesDefaultsAndExamples(t *testing.T) {
+	docFile := "../fixtures/goparsing/classification/operations/examples.go"
+	fileTree, err := goparser.ParseFile(classificationProg.Fset, docFile, nil, goparser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rp := newResponseParser(classificationProg)
+	responses := make(map[string]spec.Response)
+	if assert.NoError(t, rp.Parse(fileTree, responses)) {
+		res, ok := responses["exampleResponse"]
+		if assert.True(t, ok) {
+			header, ok := res.Headers["X-Rate-Limit"]
+			if assert.True(t, ok) {
+				assert.Equal(t, int64(100), header.Default)
+				assert.Equal(t, "The rate limit", header.Description)
+			}
+			if assert.NotNil(t, res.Schema) {
+				assert.Equal(t, map[string]interface{}{"name": "light"}, res.Schema.Example)
+			}
+		}
+	}
+}
+
+func TestParseResponsesTagValidations(t *testing.T) {
+	docFile := "../fixtures/goparsing/classification/operations/tagged.go"
+	fileTree, err := goparser.ParseFile(classificationProg.Fset, docFile, nil, goparser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rp := newResponseParser(classificationProg)
+	rp.scp.validationTags = []string{"validate"}
+	responses := make(map[string]spec.Response)
+	if assert.NoError(t, rp.Parse(fileTree, responses)) {
+		header := responses["taggedResponse"].Headers["X-Request-Id"]
+		assert.Equal(t, "string", header.Type)
+		assert.Equal(t, "uuid4", header.Format)
+	}
+}
@@ -180,6 +180,9 @@ func (rp *routesParser) Parse(gofile *ast.File, target interface{}) error {
 			newSingleLineTagParser("Schemes", newSetSchemes(opSchemeSetter(op))),
 			newMultiLineTagParser("Security", newSetSecurityDefinitions(opSecurityDefsSetter(op))),
 			newMultiLineTagParser("Responses", sr),
+			newSingleLineTagParser("Deprecated", newSetDeprecated(func(deprecated bool) { op.Deprecated = deprecated })),
+			newMultiLineTagParser("Extensions", newSetExtensions(addExtensions(op))),
+			newMultiLineTagParser("ExternalDocs", newSetExternalDocs(func(docs *spec.ExternalDocumentation) { op.ExternalDocs = docs })),
 		}
 		if err := sp.Parse(remaining); err != nil {
 			return fmt.Errorf("operation (%s): %v", op.ID, err)
@@ -112,3 +112,34 @@ func assertOperation(t *testing.T, op *spec.Operation, id, summary, description
 	assert.True(t, ok)
 	assert.Equal(t, "#/responses/validationError", rsp.Ref.String())
 }
+
+func TestRoutesParserExtensions(t *testing.T) {
+	docFile := "../fixtures/goparsing/routes/extensions.go"
+	fileTree, err := goparser.ParseFile(classificationProg.Fset, docFile, nil, goparser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rp := newRoutesParser(classificationProg)
+	var ops spec.Paths
+	if !assert.NoError(t, rp.Parse(fileTree, &ops)) {
+		return
+	}
+
+	op := ops.Paths["/legacy/{id}"].Get
+	if assert.NotNil(t, op) {
+		assert.Equal(t, "getLegacy", op.ID)
+		assert.Equal(t, "Gets a legacy model.", op.Summary)
+		assert.True(t, op.Deprecated)
+		assert.Equal(t, float64(100), op.Extensions["x-rate-limit"])
+		// the required in the yaml block isn't taken for a tag
+		assert.Equal(t, []interface{}{map[string]interface{}{"name": "pets", "required": true}}, op.Extensions["x-owners"])
+		if assert.NotNil(t, op.ExternalDocs) {
+			assert.Equal(t, "The new way to get the model", op.ExternalDocs.Description)
+			assert.Equal(t, "http://example.com/models", op.ExternalDocs.URL)
+		}
+		if assert.NotNil(t, op.Responses) {
+			assert.Equal(t, "#/responses/genericError", op.Responses.Default.Ref.String())
+		}
+	}
+}
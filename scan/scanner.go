@@ -3,9 +3,12 @@ package scan
 import (
 	"fmt"
 	"go/ast"
+	"go/build"
 	goparser "go/parser"
 	"log"
+	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"golang.org/x/tools/go/loader"
@@ -41,6 +44,9 @@ var (
 	rxMeta               = regexp.MustCompile("swagger:meta")
 	rxStrFmt             = regexp.MustCompile("swagger:strfmt\\p{Zs}*(\\p{L}[\\p{L}\\p{N}\\p{Pd}\\p{Pc}]+)$")
 	rxAllOf              = regexp.MustCompile("swagger:allOf")
+	rxEnum               = regexp.MustCompile("swagger:enum\\p{Zs}*(\\p{L}[\\p{L}\\p{N}\\p{Pd}\\p{Pc}]+)?$")
+	rxSwaggerType        = regexp.MustCompile("swagger:type\\p{Zs}+(\\p{L}+)(?:\\p{Zs}+([\\p{L}\\p{N}\\p{Pd}\\p{Pc}]+))?\\p{Zs}*$")
+	rxDiscriminator      = regexp.MustCompile("swagger:discriminator\\p{Zs}*(\\p{L}[\\p{L}\\p{N}\\p{Pd}\\p{Pc}]+)?$")
 	rxModelOverride      = regexp.MustCompile("swagger:model\\p{Zs}*(\\p{L}[\\p{L}\\p{N}\\p{Pd}\\p{Pc}]+)?$")
 	rxResponseOverride   = regexp.MustCompile("swagger:response\\p{Zs}*(\\p{L}[\\p{L}\\p{N}\\p{Pd}\\p{Pc}]+)?$")
 	rxParametersOverride = regexp.MustCompile("swagger:parameters\\p{Zs}*(\\p{L}[\\p{L}\\p{N}\\p{Pd}\\p{Pc}\\p{Zs}]+)$")
@@ -63,6 +69,8 @@ var (
 	rxIn        = regexp.MustCompile("[Ii]n\\p{Zs}*:\\p{Zs}*(query|path|header|body)$")
 	rxRequired  = regexp.MustCompile("[Rr]equired\\p{Zs}*:\\p{Zs}*(true|false)$")
 	rxReadOnly  = regexp.MustCompile("[Rr]ead(?:\\p{Zs}*|[\\p{Pd}\\p{Pc}])?[Oo]nly\\p{Zs}*:\\p{Zs}*(true|false)$")
+	rxDefault   = regexp.MustCompile("^[^\\p{L}]*[Dd]efault\\p{Zs}*:\\p{Zs}*(.*)$")
+	rxExample   = regexp.MustCompile("^[^\\p{L}]*[Ee]xample\\p{Zs}*:\\p{Zs}*(.*)$")
 	rxConsumes  = regexp.MustCompile("[Cc]onsumes\\p{Zs}*:")
 	rxProduces  = regexp.MustCompile("[Pp]roduces\\p{Zs}*:")
 	rxSecurity  = regexp.MustCompile("[Ss]ecurity\\p{Zs}*:")
@@ -74,6 +82,11 @@ var (
 	rxLicense   = regexp.MustCompile("[Ll]icense\\p{Zs}*:\\p{Zs}*(.+)$")
 	rxContact   = regexp.MustCompile("[Cc]ontact\\p{Zs}*-?(?:[Ii]info\\p{Zs}*)?:\\p{Zs}*(.+)$")
 	rxTOS       = regexp.MustCompile("[Tt](:?erms)?\\p{Zs}*-?[Oo]f?\\p{Zs}*-?[Ss](?:ervice)?\\p{Zs}*:")
+
+	rxDeprecated   = regexp.MustCompile("[Dd]eprecated\\p{Zs}*:\\p{Zs}*(true|false)$")
+	rxExtensions   = regexp.MustCompile("^[^\\p{L}]*[Ee]xtensions\\p{Zs}*:\\p{Zs}*$")
+	rxExternalDocs = regexp.MustCompile("^[^\\p{L}]*[Ee]xternal\\p{Zs}*[Dd]ocs\\p{Zs}*:\\p{Zs}*$")
+	rxMetaTags     = regexp.MustCompile("^[^\\p{L}]*[Tt]ags\\p{Zs}*:\\p{Zs}*$")
 )
 
 // Many thanks go to https://github.com/yvasiyarov/swagger
@@ -109,11 +122,126 @@ func rxf(rxp, ar string) *regexp.Regexp {
 // When something in the discovered items requires a type that is contained in the includes or excludes it will still be
 // in the spec.
 func Application(bp string, input *spec.Swagger, includes, excludes packageFilters) (*spec.Swagger, error) {
-	parser, err := newAppScanner(bp, input, includes, excludes)
+	return Run(&Opts{
+		BasePath: bp,
+		Input:    input,
+		Includes: includes.Names(),
+		Excludes: excludes.Names(),
+	})
+}
+
+// Opts the options for scanning an application
+type Opts struct {
+	// BasePath the package to scan, the packages it imports are scanned too
+	BasePath string
+	// Input the spec to add the scanned items to
+	Input *spec.Swagger
+	// Includes limits the scanning for annotations to these packages,
+	// a package ending in /... includes the packages below it too
+	Includes []string
+	// Excludes skips these packages when scanning for annotations, these are ignored when there are includes
+	Excludes []string
+	// BuildTags the build tags to use when loading the packages
+	BuildTags []string
+	// ScanModels adds all the types annotated with swagger:model to the definitions,
+	// not just the ones used by routes, parameters and responses
+	ScanModels bool
+	// Explain when not nil, it gets called with the reason each definition is in the spec.
+	// It's called in order of the definition names.
+	Explain func(definition, reason string)
+	// ValidationTags the struct tags to read validations from, in addition to the comments of the fields.
+	// Tags like validate and binding use the rules of the go-playground validator,
+	// the json tag makes fields without omitempty required.
+	ValidationTags []string
+	// DiscoverRoutes looks for the handlers that are registered with a router in the code,
+	// like http.HandleFunc("/pets", h) or r.HandleFunc("/pets", h).Methods("GET").
+	// The registered routes without a swagger:route annotation get a stub operation,
+	// a warning is given for them and for the annotated routes that aren't registered.
+	DiscoverRoutes bool
+	// Warn gets the conflicts between the validations of the struct tags and the comments,
+	// and the routes that differ between the annotations and the registrations.
+	// When it's nil they're logged
+	Warn func(string)
+	// TypeMappings the swagger types for go types, keyed by the import path and the name of the type like time.Time.
+	// They take precedence over the built in mappings, use swagger:type on a declaration for the types of the application.
+	TypeMappings map[string]TypeMapping
+	// CacheDir the directory to keep what each package added to the spec in,
+	// only the packages that changed since the last scan are scanned again.
+	// When nothing changed the spec of the last scan is used without loading the program.
+	CacheDir string
+}
+
+// Run scans the application with the options and builds a swagger spec with the information from the code files.
+func Run(opts *Opts) (*spec.Swagger, error) {
+	if opts.CacheDir == "" {
+		return run(opts, nil)
+	}
+	cache, err := loadScanCache(opts)
 	if err != nil {
 		return nil, err
 	}
-	return parser.Parse()
+	return run(opts, cache)
+}
+
+func run(opts *Opts, cache *scanCache) (*spec.Swagger, error) {
+	if cache != nil {
+		sources, err := collectSources(buildContext(opts), opts.BasePath)
+		if err != nil {
+			return nil, err
+		}
+		if !cache.update(sources) && cache.Result != nil {
+			return replayResult(opts, cache.Result), nil
+		}
+	}
+
+	parser, err := newCachedAppScanner(opts, cache)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parser.Parse()
+	if err != nil {
+		return nil, err
+	}
+	if opts.Explain != nil {
+		parser.explain(opts.Explain)
+	}
+	if cache != nil {
+		cache.Result = &cachedResult{Spec: doc, Origins: parser.origins, Warnings: parser.warnings}
+		if err := cache.save(); err != nil {
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+// replayResult gives the warnings and the explanations of the cached scan again
+func replayResult(opts *Opts, result *cachedResult) *spec.Swagger {
+	for _, w := range result.Warnings {
+		if opts.Warn != nil {
+			opts.Warn(w)
+		} else {
+			log.Printf("warning: %s", w)
+		}
+	}
+	if opts.Explain != nil {
+		names := make([]string, 0, len(result.Spec.Definitions))
+		for k := range result.Spec.Definitions {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		for _, k := range names {
+			opts.Explain(k, result.Origins[k])
+		}
+	}
+	return result.Spec
+}
+
+func buildContext(opts *Opts) *build.Context {
+	bctx := build.Default
+	if len(opts.BuildTags) > 0 {
+		bctx.BuildTags = append(append([]string{}, bctx.BuildTags...), opts.BuildTags...)
+	}
+	return &bctx
 }
 
 // appScanner the global context for scanning a go application
@@ -122,25 +250,55 @@ type appScanner struct {
 	loader      *loader.Config
 	prog        *loader.Program
 	classifier  *programClassifier
-	discovered  []schemaDecl
+	discovered  []discoveredDecl
 	input       *spec.Swagger
 	definitions map[string]spec.Schema
 	responses   map[string]spec.Response
 	operations  map[string]*spec.Operation
+	scanModels  bool
+	origins     map[string]string
+
+	discoverRoutes bool
+
+	validationTags []string
+	typeMappings   map[string]TypeMapping
+	warn           func(string)
+	warnings       []string
+
+	cache *scanCache
+	files map[string]*ast.File
+	// registrations the routes the code registers, they're known when the routes are discovered
+	registrations []*routeRegistration
 
 	// MainPackage the path to find the main class in
 	MainPackage string
 }
 
 // newAppScanner creates a new api parser
-func newAppScanner(bp string, input *spec.Swagger, includes, excludes packageFilters) (*appScanner, error) {
+func newAppScanner(opts *Opts) (*appScanner, error) {
+	return newCachedAppScanner(opts, nil)
+}
+
+// newCachedAppScanner creates a new api parser that reuses what the cache has for the packages that didn't change
+func newCachedAppScanner(opts *Opts, cache *scanCache) (*appScanner, error) {
 	var ldr loader.Config
 	ldr.ParserMode = goparser.ParseComments
-	ldr.Import(bp)
+	if len(opts.BuildTags) > 0 {
+		ldr.Build = buildContext(opts)
+	}
+	// only the route registrations need the function bodies
+	switch {
+	case !opts.DiscoverRoutes:
+		ldr.TypeCheckFuncBodies = func(string) bool { return false }
+	case cache != nil:
+		ldr.TypeCheckFuncBodies = cache.typeCheckBodies
+	}
+	ldr.Import(opts.BasePath)
 	prog, err := ldr.Load()
 	if err != nil {
 		return nil, err
 	}
+	input := opts.Input
 	if input == nil {
 		input = new(spec.Swagger)
 	}
@@ -155,17 +313,30 @@ func newAppScanner(bp string, input *spec.Swagger, includes, excludes packageFil
 		input.Responses = make(map[string]spec.Response)
 	}
 
+	origins := make(map[string]string, len(input.Definitions))
+	for k := range input.Definitions {
+		origins[k] = "from the input spec"
+	}
+
 	return &appScanner{
-		MainPackage: bp,
+		MainPackage: opts.BasePath,
 		prog:        prog,
 		input:       input,
 		loader:      &ldr,
 		operations:  collectOperationsFromInput(input),
 		definitions: input.Definitions,
 		responses:   input.Responses,
+		scanModels:  opts.ScanModels,
+		origins:     origins,
+
+		discoverRoutes: opts.DiscoverRoutes,
+		validationTags: opts.ValidationTags,
+		typeMappings:   opts.TypeMappings,
+		warn:           opts.Warn,
+		cache:          cache,
 		classifier: &programClassifier{
-			Includes: includes,
-			Excludes: excludes,
+			Includes: newPackageFilters(opts.Includes),
+			Excludes: newPackageFilters(opts.Excludes),
 		},
 	}, nil
 }
@@ -226,6 +397,15 @@ func (a *appScanner) Parse() (*spec.Swagger, error) {
 	if err := a.processDiscovered(); err != nil {
 		return nil, err
 	}
+	// the models that aren't used by anything go last, so the origin of a definition is the first thing that uses it
+	if a.scanModels {
+		for _, modelFile := range cp.Models {
+			a.discoverModels(modelFile)
+		}
+		if err := a.processDiscovered(); err != nil {
+			return nil, err
+		}
+	}
 
 	// build paths dictionary
 	for _, routeFile := range cp.Operations {
@@ -241,6 +421,11 @@ func (a *appScanner) Parse() (*spec.Swagger, error) {
 		}
 	}
 
+	// compare the paths with the routes that are registered in the code, the routers can include the base path
+	if a.discoverRoutes {
+		a.checkRegistrations(cp)
+	}
+
 	if a.input.Swagger == "" {
 		a.input.Swagger = "2.0"
 	}
@@ -252,7 +437,7 @@ func (a *appScanner) processDiscovered() error {
 	// loop over discovered until all the items are in definitions
 	keepGoing := len(a.discovered) > 0
 	for keepGoing {
-		var queue []schemaDecl
+		var queue []discoveredDecl
 		for _, d := range a.discovered {
 			if _, ok := a.definitions[d.Name]; !ok {
 				queue = append(queue, d)
@@ -260,9 +445,14 @@ func (a *appScanner) processDiscovered() error {
 		}
 		a.discovered = nil
 		for _, sd := range queue {
-			if err := a.parseSchema(sd.File); err != nil {
+			if _, ok := a.definitions[sd.Name]; ok {
+				// declared in the same file as a definition that was parsed before
+				continue
+			}
+			if err := a.parseSchema(sd); err != nil {
 				return err
 			}
+			a.traceOrigins(sd)
 		}
 		keepGoing = len(a.discovered) > 0
 	}
@@ -270,12 +460,301 @@ func (a *appScanner) processDiscovered() error {
 	return nil
 }
 
-func (a *appScanner) parseSchema(file *ast.File) error {
-	sp := newSchemaParser(a.prog)
-	if err := sp.Parse(file, a.definitions); err != nil {
+// discoverModels queues the types annotated with swagger:model in the file
+func (a *appScanner) discoverModels(file *ast.File) {
+	pkg := file.Name.Name
+	for _, pkgInfo := range a.prog.AllPackages {
+		for _, fil := range pkgInfo.Files {
+			if fil == file {
+				pkg = pkgInfo.Pkg.Path()
+			}
+		}
+	}
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spc := range gd.Specs {
+			if ts, ok := spc.(*ast.TypeSpec); ok {
+				sd := newSchemaDecl(file, gd, ts)
+				if sd.hasAnnotation() {
+					sd.Origin = "model in package " + pkg
+					a.discovered = append(a.discovered, a.discoveredDecls([]schemaDecl{*sd})...)
+				}
+			}
+		}
+	}
+}
+
+// traceOrigins records why the definitions parsed for a discovered declaration are in the spec.
+// All the types in the file of the declaration become definitions, not just the declaration itself.
+func (a *appScanner) traceOrigins(sd discoveredDecl) {
+	if _, ok := a.origins[sd.Name]; !ok {
+		a.origins[sd.Name] = sd.Origin
+	}
+	for k := range a.definitions {
+		if _, ok := a.origins[k]; !ok {
+			a.origins[k] = fmt.Sprintf("declared in %s with %s", sd.File, sd.Name)
+		}
+	}
+}
+
+// discoveredDecl a declaration that has to become a definition, it's found by the file it is in
+// so the declarations discovered by cached contributions can be queued too
+type discoveredDecl struct {
+	Dir    string `json:"dir"`
+	File   string `json:"file"`
+	Name   string `json:"name"`
+	Origin string `json:"origin,omitempty"`
+}
+
+func (a *appScanner) discoveredDecls(decls []schemaDecl) []discoveredDecl {
+	result := make([]discoveredDecl, 0, len(decls))
+	for _, sd := range decls {
+		dir, base := a.filePath(sd.File)
+		result = append(result, discoveredDecl{Dir: dir, File: base, Name: sd.Name, Origin: sd.Origin})
+	}
+	return result
+}
+
+// filePath the directory and the name of a file of the program
+func (a *appScanner) filePath(file *ast.File) (string, string) {
+	pth := a.prog.Fset.File(file.Pos()).Name()
+	return filepath.Dir(pth), filepath.Base(pth)
+}
+
+// fileAt finds a file of the program by its directory and name
+func (a *appScanner) fileAt(dir, name string) (*ast.File, error) {
+	if a.files == nil {
+		a.files = make(map[string]*ast.File)
+		for _, pkgInfo := range a.prog.AllPackages {
+			for _, file := range pkgInfo.Files {
+				a.files[a.prog.Fset.File(file.Pos()).Name()] = file
+			}
+		}
+	}
+	file, ok := a.files[filepath.Join(dir, name)]
+	if !ok {
+		return nil, fmt.Errorf("%s isn't part of the program", filepath.Join(dir, name))
+	}
+	return file, nil
+}
+
+// declaredDefinitions the definitions for the types declared in the file
+func declaredDefinitions(file *ast.File, definitions map[string]spec.Schema) map[string]spec.Schema {
+	result := make(map[string]spec.Schema)
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spc := range gd.Specs {
+			if ts, ok := spc.(*ast.TypeSpec); ok {
+				sd := newSchemaDecl(file, gd, ts)
+				if schema, ok := definitions[sd.Name]; ok {
+					result[sd.Name] = schema
+				}
+			}
+		}
+	}
+	return result
+}
+
+// declaredResponses the responses for the types annotated with swagger:response in the file
+func declaredResponses(file *ast.File, responses map[string]spec.Response) map[string]spec.Response {
+	result := make(map[string]spec.Response)
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spc := range gd.Specs {
+			if ts, ok := spc.(*ast.TypeSpec); ok {
+				rd := newResponseDecl(file, gd, ts)
+				if response, ok := responses[rd.Name]; ok && rd.hasAnnotation() {
+					result[rd.Name] = response
+				}
+			}
+		}
+	}
+	return result
+}
+
+// operationParameters the parameters per operation, sorted by operation id
+func operationParameters(operations map[string]*spec.Operation) []operationParams {
+	ids := make([]string, 0, len(operations))
+	for id := range operations {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	result := make([]operationParams, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, operationParams{ID: id, Parameters: operations[id].Parameters})
+	}
+	return result
+}
+
+// mergeParameters adds the parameters to the operation,
+// they replace the parameters with the same name like they do when parsing
+func mergeParameters(operations map[string]*spec.Operation, params operationParams) {
+	operation, ok := operations[params.ID]
+	if !ok {
+		operation = new(spec.Operation)
+		operation.ID = params.ID
+		operations[params.ID] = operation
+	}
+	for _, param := range params.Parameters {
+		for i, v := range operation.Parameters {
+			if v.Name == param.Name {
+				operation.Parameters = append(operation.Parameters[:i], operation.Parameters[i+1:]...)
+				break
+			}
+		}
+		operation.Parameters = append(operation.Parameters, param)
+	}
+}
+
+func (a *appScanner) explain(fn func(string, string)) {
+	names := make([]string, 0, len(a.definitions))
+	for k := range a.definitions {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		fn(k, a.origins[k])
+	}
+}
+
+// checkRegistrations warns about the annotated routes without a registration and the registrations without an annotation,
+// the latter get a stub operation
+func (a *appScanner) checkRegistrations(cp *classifiedProgram) {
+	var registrations []*routeRegistration
+	for _, pkgInfo := range cp.Packages {
+		if len(pkgInfo.Files) == 0 {
+			continue
+		}
+		dir, _ := a.filePath(pkgInfo.Files[0])
+		if regs, ok := a.cache.registrations(dir); ok {
+			registrations = append(registrations, regs...)
+			continue
+		}
+		if isGoroot(a.loader.Build, pkgInfo) {
+			continue
+		}
+		rp := newRegistrationsParser(a.prog)
+		rp.Parse(pkgInfo)
+		a.cache.storeRegistrations(dir, rp.registrations)
+		registrations = append(registrations, rp.registrations...)
+	}
+
+	a.registrations = registrations
+
+	routes := annotatedRoutes(a.input.Paths)
+	// the ids of the stubs can't be the same as the ids of the annotated routes
+	for _, route := range routes {
+		a.operations[route.Operation.ID] = route.Operation
+	}
+	for _, reg := range registrations {
+		matched := false
+		for _, route := range routes {
+			if reg.matches(route, a.input.BasePath) {
+				matched = true
+				break
+			}
+		}
+		if reg.OperationID != "" {
+			if !matched {
+				a.warnf("%s: %s is registered for %s but there is no swagger:route annotation for it", reg.Pos, reg, reg.Handler)
+			}
+			continue
+		}
+		if matched && len(reg.Methods) == 0 {
+			// the handler serves all the methods of the annotated path
+			continue
+		}
+		for _, method := range stubOperations(a.input.Paths, a.operations, reg, routes, a.input.BasePath) {
+			a.warnf("%s: %s %s is registered for %s but there is no swagger:route annotation for it", reg.Pos, method, reg.Path, reg.Handler)
+		}
+	}
+
+	for _, route := range routes {
+		registered := false
+		for _, reg := range registrations {
+			if reg.matches(route, a.input.BasePath) {
+				registered = true
+				break
+			}
+		}
+		if !registered {
+			a.warnf("operation %s (%s %s) is annotated with swagger:route but it isn't registered with a router", route.Operation.ID, route.Method, route.Path)
+		}
+	}
+}
+
+func (a *appScanner) warnf(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	a.warnings = append(a.warnings, msg)
+	if a.warn != nil {
+		a.warn(msg)
+		return
+	}
+	log.Printf("warning: %s", msg)
+}
+
+// configure passes the options for parsing the fields of structs on to a schema parser,
+// its warnings are kept with the contribution of the file
+func (a *appScanner) configure(scp *schemaParser, fc *fileContribution) {
+	scp.validationTags = a.validationTags
+	scp.typeMappings = a.typeMappings
+	scp.warn = func(msg string) { fc.Warnings = append(fc.Warnings, msg) }
+}
+
+// contribution parses a file for schemas, parameters or responses, unless the cache has what the file adds to the spec
+func (a *appScanner) contribution(file *ast.File, kind string, parse func(*fileContribution) error) (*fileContribution, error) {
+	dir, base := a.filePath(file)
+	if fc := a.cache.contribution(dir, base, kind); fc != nil {
+		return fc, nil
+	}
+	fc := new(fileContribution)
+	if err := parse(fc); err != nil {
+		return nil, err
+	}
+	a.cache.storeContribution(dir, base, kind, fc)
+	return fc, nil
+}
+
+// apply queues the declarations a file discovered and gives its warnings
+func (a *appScanner) apply(fc *fileContribution) {
+	a.discovered = append(a.discovered, fc.Discovered...)
+	for _, w := range fc.Warnings {
+		a.warnf("%s", w)
+	}
+}
+
+func (a *appScanner) parseSchema(sd discoveredDecl) error {
+	file, err := a.fileAt(sd.Dir, sd.File)
+	if err != nil {
+		return err
+	}
+	fc, err := a.contribution(file, schemaContribution, func(fc *fileContribution) error {
+		sp := newSchemaParser(a.prog)
+		a.configure(sp, fc)
+		if err := sp.Parse(file, a.definitions); err != nil {
+			return err
+		}
+		fc.Definitions = declaredDefinitions(file, a.definitions)
+		fc.Discovered = a.discoveredDecls(sp.postDecls)
+		fc.ProgramWide = sp.programWide
+		return nil
+	})
+	if err != nil {
 		return err
 	}
-	a.discovered = append(a.discovered, sp.postDecls...)
+	for k, v := range fc.Definitions {
+		a.definitions[k] = v
+	}
+	a.apply(fc)
 	return nil
 }
 
@@ -291,22 +770,45 @@ func (a *appScanner) parseRoutes(file *ast.File) error {
 }
 
 func (a *appScanner) parseParameters(file *ast.File) error {
-	rp := newParameterParser(a.prog)
-	if err := rp.Parse(file, a.operations); err != nil {
+	fc, err := a.contribution(file, parametersContribution, func(fc *fileContribution) error {
+		operations := make(map[string]*spec.Operation)
+		rp := newParameterParser(a.prog)
+		a.configure(rp.scp, fc)
+		if err := rp.Parse(file, operations); err != nil {
+			return err
+		}
+		fc.Operations = operationParameters(operations)
+		fc.Discovered = a.discoveredDecls(append(rp.postDecls, rp.scp.postDecls...))
+		return nil
+	})
+	if err != nil {
 		return err
 	}
-	a.discovered = append(a.discovered, rp.postDecls...)
-	a.discovered = append(a.discovered, rp.scp.postDecls...)
+	for _, op := range fc.Operations {
+		mergeParameters(a.operations, op)
+	}
+	a.apply(fc)
 	return nil
 }
 
 func (a *appScanner) parseResponses(file *ast.File) error {
-	rp := newResponseParser(a.prog)
-	if err := rp.Parse(file, a.responses); err != nil {
+	fc, err := a.contribution(file, responsesContribution, func(fc *fileContribution) error {
+		rp := newResponseParser(a.prog)
+		a.configure(rp.scp, fc)
+		if err := rp.Parse(file, a.responses); err != nil {
+			return err
+		}
+		fc.Responses = declaredResponses(file, a.responses)
+		fc.Discovered = a.discoveredDecls(append(rp.postDecls, rp.scp.postDecls...))
+		return nil
+	})
+	if err != nil {
 		return err
 	}
-	a.discovered = append(a.discovered, rp.postDecls...)
-	a.discovered = append(a.discovered, rp.scp.postDecls...)
+	for k, v := range fc.Responses {
+		a.responses[k] = v
+	}
+	a.apply(fc)
 	return nil
 }
 
@@ -329,6 +831,7 @@ type swaggerTypable interface {
 	SetRef(spec.Ref)
 	Items() swaggerTypable
 	Schema() *spec.Schema
+	WithEnum(...interface{})
 }
 
 func swaggerSchemaForType(typeName string, prop swaggerTypable) error {
@@ -337,22 +840,17 @@ func swaggerSchemaForType(typeName string, prop swaggerTypable) error {
 		prop.Typed("boolean", "")
 	case "rune", "string":
 		prop.Typed("string", "")
-	case "int8":
-		prop.Typed("number", "int8")
-	case "int16":
-		prop.Typed("number", "int16")
-	case "int32":
-		prop.Typed("number", "int32")
+	case "int8", "int16", "int32":
+		prop.Typed("integer", "int32")
+	case "uint8", "byte", "uint16":
+		prop.Typed("integer", "int32")
+		setUnsigned(prop)
 	case "int", "int64":
-		prop.Typed("number", "int64")
-	case "uint8":
-		prop.Typed("number", "uint8")
-	case "uint16":
-		prop.Typed("number", "uint16")
-	case "uint32":
-		prop.Typed("number", "uint32")
-	case "uint", "uint64":
-		prop.Typed("number", "uint64")
+		prop.Typed("integer", "int64")
+	case "uint32", "uint", "uint64":
+		// swagger has no unsigned formats, an uint32 doesn't fit in an int32
+		prop.Typed("integer", "int64")
+		setUnsigned(prop)
 	case "float32":
 		prop.Typed("number", "float")
 	case "float64":
@@ -390,6 +888,13 @@ func (st *tagParser) Matches(line string) bool {
 	return st.Parser.Matches(line)
 }
 
+// IsYAML is true for the taggers of a block of yaml, they get the lines as they are in the comments
+// so the indentation of the yaml is kept
+func (st *tagParser) IsYAML() bool {
+	_, ok := st.Parser.(*setYAMLBlock)
+	return ok
+}
+
 func (st *tagParser) Parse(lines []string) error {
 	return st.Parser.Parse(lines)
 }
@@ -407,10 +912,21 @@ type sectionedParser struct {
 	workedOutTitle bool
 	taggers        []tagParser
 	currentTagger  *tagParser
+	tagIndent      int
 	title          []string
 	description    []string
 }
 
+// commentIndent the indentation of a comment line, without the comment marker
+func commentIndent(line string) int {
+	line = strings.Replace(rxCommentPrefix.ReplaceAllString(line, ""), "\t", "    ", -1)
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func isBlankComment(line string) bool {
+	return strings.TrimSpace(rxCommentPrefix.ReplaceAllString(line, "")) == ""
+}
+
 func (st *sectionedParser) cleanup(lines []string) []string {
 	// bail early when there is nothing to parse
 	if len(lines) == 0 {
@@ -507,12 +1023,19 @@ COMMENTS:
 			}
 
 			var matched bool
-			for _, tagger := range st.taggers {
-				if tagger.Matches(line) {
-					st.seenTag = true
-					st.currentTagger = &tagger
-					matched = true
-					break
+			if st.currentTagger != nil && st.currentTagger.IsYAML() && (isBlankComment(line) || commentIndent(line) > st.tagIndent) {
+				// the lines of a yaml block belong to it until the indentation goes back to the level of its tag,
+				// so the keys in the block aren't taken for other tags
+				matched = false
+			} else {
+				for _, tagger := range st.taggers {
+					if tagger.Matches(line) {
+						st.seenTag = true
+						st.currentTagger = &tagger
+						st.tagIndent = commentIndent(line)
+						matched = true
+						break
+					}
 				}
 			}
 
@@ -551,7 +1074,11 @@ COMMENTS:
 		st.setDescription(st.Description())
 	}
 	for _, mt := range st.matched {
-		if err := mt.Parse(st.cleanup(mt.Lines)); err != nil {
+		lines := mt.Lines
+		if !mt.IsYAML() {
+			lines = st.cleanup(lines)
+		}
+		if err := mt.Parse(lines); err != nil {
 			return err
 		}
 	}
@@ -33,7 +33,7 @@ func init() {
 }
 
 func TestAppScanner_NewSpec(t *testing.T) {
-	scanner, err := newAppScanner("../fixtures/goparsing/petstore/petstore-fixture", nil, nil, nil)
+	scanner, err := newAppScanner(&Opts{BasePath: "../fixtures/goparsing/petstore/petstore-fixture"})
 	assert.NoError(t, err)
 	assert.NotNil(t, scanner)
 	doc, err := scanner.Parse()
@@ -66,7 +66,7 @@ func verifyParsedPetStore(t testing.TB, doc *spec.Swagger) {
 	assert.Equal(t, "It is used to describe the animals available in the store.", mod.Description)
 	assert.Len(t, mod.Required, 2)
 
-	assertProperty(t, &mod, "number", "id", "int64", "ID")
+	assertProperty(t, &mod, "integer", "id", "int64", "ID")
 	prop, ok := mod.Properties["id"]
 	assert.True(t, ok, "should have had an 'id' property")
 	assert.Equal(t, "The id of the tag.", prop.Description)
@@ -82,7 +82,7 @@ func verifyParsedPetStore(t testing.TB, doc *spec.Swagger) {
 	assert.Equal(t, "It is used to describe the animals available in the store.", mod.Description)
 	assert.Len(t, mod.Required, 2)
 
-	assertProperty(t, &mod, "number", "id", "int64", "ID")
+	assertProperty(t, &mod, "integer", "id", "int64", "ID")
 	prop, ok = mod.Properties["id"]
 	assert.True(t, ok, "should have had an 'id' property")
 	assert.Equal(t, "The id of the pet.", prop.Description)
@@ -115,12 +115,12 @@ func verifyParsedPetStore(t testing.TB, doc *spec.Swagger) {
 	assert.Len(t, mod.Properties, 4)
 	assert.Len(t, mod.Required, 3)
 
-	assertProperty(t, &mod, "number", "id", "int64", "ID")
+	assertProperty(t, &mod, "integer", "id", "int64", "ID")
 	prop, ok = mod.Properties["id"]
 	assert.True(t, ok, "should have had an 'id' property")
 	assert.Equal(t, "the ID of the order", prop.Description)
 
-	assertProperty(t, &mod, "number", "userId", "int64", "UserID")
+	assertProperty(t, &mod, "integer", "userId", "int64", "UserID")
 	prop, ok = mod.Properties["userId"]
 	assert.True(t, ok, "should have had an 'userId' property")
 	assert.Equal(t, "the id of the user who placed the order.", prop.Description)
@@ -140,12 +140,12 @@ func verifyParsedPetStore(t testing.TB, doc *spec.Swagger) {
 	assert.Len(t, itprop.Properties, 2)
 	assert.Len(t, itprop.Required, 2)
 
-	assertProperty(t, itprop, "number", "petId", "int64", "PetID")
+	assertProperty(t, itprop, "integer", "petId", "int64", "PetID")
 	iprop, ok := itprop.Properties["petId"]
 	assert.True(t, ok, "should have had a 'petId' property")
 	assert.Equal(t, "the id of the pet to order", iprop.Description)
 
-	assertProperty(t, itprop, "number", "qty", "int32", "Quantity")
+	assertProperty(t, itprop, "integer", "qty", "int32", "Quantity")
 	iprop, ok = itprop.Properties["qty"]
 	assert.True(t, ok, "should have had a 'qty' property")
 	assert.Equal(t, "the quantity of this pet to order", iprop.Description)
@@ -156,14 +156,14 @@ func verifyParsedPetStore(t testing.TB, doc *spec.Swagger) {
 	assert.True(t, ok)
 	assert.NotNil(t, resp.Schema)
 	assert.Len(t, resp.Schema.Properties, 2)
-	assertProperty(t, resp.Schema, "number", "code", "int32", "Code")
+	assertProperty(t, resp.Schema, "integer", "code", "int32", "Code")
 	assertProperty(t, resp.Schema, "string", "message", "", "Message")
 
 	resp, ok = doc.Responses["validationError"]
 	assert.True(t, ok)
 	assert.NotNil(t, resp.Schema)
 	assert.Len(t, resp.Schema.Properties, 3)
-	assertProperty(t, resp.Schema, "number", "code", "int32", "Code")
+	assertProperty(t, resp.Schema, "integer", "code", "int32", "Code")
 	assertProperty(t, resp.Schema, "string", "message", "", "Message")
 	assertProperty(t, resp.Schema, "string", "field", "", "Field")
 
@@ -324,7 +324,7 @@ func verifyParsedPetStore(t testing.TB, doc *spec.Swagger) {
 func verifyIDParam(t testing.TB, param spec.Parameter, description string) {
 	assert.Equal(t, description, param.Description)
 	assert.Equal(t, "path", param.In)
-	assert.Equal(t, "number", param.Type)
+	assert.Equal(t, "integer", param.Type)
 	assert.Equal(t, "int64", param.Format)
 	assert.True(t, param.Required)
 	assert.Equal(t, "ID", param.Extensions["x-go-name"])
@@ -843,3 +843,113 @@ func verifySwaggerMultiArgSwaggerTag(t *testing.T, matcher *regexp.Regexp, prefi
 		}
 	}
 }
+
+func TestAppScanner_ScanModels(t *testing.T) {
+	reasons := make(map[string]string)
+	doc, err := Run(&Opts{
+		BasePath:   "../fixtures/goparsing/petstore/petstore-fixture",
+		ScanModels: true,
+		Explain:    func(definition, reason string) { reasons[definition] = reason },
+	})
+	if assert.NoError(t, err) {
+		// the user model isn't used by any route, parameter or response
+		assert.Len(t, doc.Definitions, 4)
+		_, ok := doc.Definitions["user"]
+		assert.True(t, ok)
+		assert.Len(t, reasons, 4)
+		assert.Equal(t, "model in package github.com/vikstrous/go-swagger/fixtures/goparsing/petstore/models", reasons["user"])
+		assert.Equal(t, "used by definition pet", reasons["tag"])
+	}
+}
+
+func TestAppScanner_Filters(t *testing.T) {
+	reasons := make(map[string]string)
+	doc, err := Run(&Opts{
+		BasePath: "../fixtures/goparsing/petstore/petstore-fixture",
+		Excludes: []string{"github.com/vikstrous/go-swagger/fixtures/goparsing/petstore/rest/..."},
+		Explain:  func(definition, reason string) { reasons[definition] = reason },
+	})
+	if assert.NoError(t, err) {
+		// the routes, parameters and responses are in the excluded packages
+		assert.Empty(t, doc.Paths.Paths)
+		assert.Empty(t, doc.Responses)
+		assert.Empty(t, doc.Definitions)
+		assert.Empty(t, reasons)
+	}
+
+	reasons = make(map[string]string)
+	doc, err = Run(&Opts{
+		BasePath: "../fixtures/goparsing/petstore/petstore-fixture",
+		Includes: []string{"github.com/vikstrous/go-swagger/fixtures/goparsing/petstore/rest/handlers"},
+		Explain:  func(definition, reason string) { reasons[definition] = reason },
+	})
+	if assert.NoError(t, err) {
+		assert.Len(t, doc.Definitions, 3)
+		assert.Equal(t, "used by the parameters of operation updateOrder", reasons["order"])
+		assert.Contains(t, reasons["pet"], "used by ")
+	}
+}
+
+func TestAppScanner_DiscoverRoutes(t *testing.T) {
+	var warnings []string
+	doc, err := Run(&Opts{
+		BasePath:       "../fixtures/goparsing/petstore/petstore-fixture",
+		DiscoverRoutes: true,
+		Warn:           func(msg string) { warnings = append(warnings, msg) },
+	})
+	if assert.NoError(t, err) {
+		// the denco routes of the petstore are all annotated
+		assert.Empty(t, warnings)
+		assert.Len(t, doc.Paths.Paths, 4)
+	}
+
+	warnings = nil
+	doc, err = Run(&Opts{
+		BasePath:       "../fixtures/goparsing/registrations",
+		DiscoverRoutes: true,
+		Warn:           func(msg string) { warnings = append(warnings, msg) },
+	})
+	if assert.NoError(t, err) {
+		health, ok := doc.Paths.Paths["/health"]
+		if assert.True(t, ok) && assert.NotNil(t, health.Get) {
+			assert.Equal(t, "health", health.Get.ID)
+			assert.NotNil(t, health.Get.Responses.Default)
+		}
+		orders, ok := doc.Paths.Paths["/orders/{id}"]
+		if assert.True(t, ok) {
+			assert.Equal(t, "getOrder", orders.Get.ID)
+			assert.Equal(t, "deleteOrder", orders.Delete.ID)
+			if assert.NotNil(t, orders.Head) {
+				assert.Equal(t, "headOrdersId", orders.Head.ID)
+			}
+		}
+		pets := doc.Paths.Paths["/pets"]
+		assert.Equal(t, "createPet", pets.Post.ID)
+		// the Get method of a cache isn't a route
+		_, ok = doc.Paths.Paths["/pets/1"]
+		assert.False(t, ok)
+
+		if assert.Len(t, warnings, 4) {
+			assert.Contains(t, warnings[0], "main.go:")
+			assert.Contains(t, warnings[0], "GET /health is registered for health but there is no swagger:route annotation for it")
+			assert.Contains(t, warnings[1], "HEAD /orders/{id} is registered for getOrder")
+			assert.Contains(t, warnings[2], "operation archiveOrder is registered for deleteOrder but there is no swagger:route annotation for it")
+			assert.Equal(t, "operation createPet (POST /pets) is annotated with swagger:route but it isn't registered with a router", warnings[3])
+		}
+	}
+
+	// without the option the registrations aren't looked at
+	doc, err = Run(&Opts{BasePath: "../fixtures/goparsing/registrations"})
+	if assert.NoError(t, err) {
+		_, ok := doc.Paths.Paths["/health"]
+		assert.False(t, ok)
+	}
+}
+
+func TestNormalizeRoutePath(t *testing.T) {
+	assert.Equal(t, "/pets/{id}", normalizeRoutePath("/pets/:id"))
+	assert.Equal(t, "/files/{path}", normalizeRoutePath("/files/*path"))
+	assert.Equal(t, "/orders/{id}", normalizeRoutePath("/orders/{id:[0-9]+}"))
+	assert.Equal(t, "/files/{path}", normalizeRoutePath("/files/{path...}"))
+	assert.Equal(t, "/pets/{id}/tags", normalizeRoutePath("/pets/{id}/tags"))
+}
@@ -29,6 +29,10 @@ func (st schemaTypable) Schema() *spec.Schema {
 	return st.schema
 }
 
+func (st schemaTypable) WithEnum(values ...interface{}) {
+	st.schema.WithEnum(values...)
+}
+
 func (st schemaTypable) Items() swaggerTypable {
 	if st.schema.Items == nil {
 		st.schema.Items = new(spec.SchemaOrArray)
@@ -98,6 +102,7 @@ type schemaDecl struct {
 	TypeSpec  *ast.TypeSpec
 	GoName    string
 	Name      string
+	Origin    string
 	annotated bool
 }
 
@@ -144,8 +149,16 @@ func (sd *schemaDecl) inferNames() (goName string, name string) {
 }
 
 type schemaParser struct {
-	program   *loader.Program
-	postDecls []schemaDecl
+	program       *loader.Program
+	postDecls     []schemaDecl
+	discriminated []discriminatedInterface
+	origin        string
+	// programWide is true when the parsing looked at all the packages of the program
+	programWide bool
+
+	validationTags []string
+	typeMappings   map[string]TypeMapping
+	warn           func(string)
 }
 
 func newSchemaParser(prog *loader.Program) *schemaParser {
@@ -154,6 +167,15 @@ func newSchemaParser(prog *loader.Program) *schemaParser {
 	return scp
 }
 
+// discover queues a declaration to become a definition,
+// when it doesn't have an origin yet it gets the item that is being parsed as origin
+func (scp *schemaParser) discover(sd schemaDecl) {
+	if sd.Origin == "" {
+		sd.Origin = scp.origin
+	}
+	scp.postDecls = append(scp.postDecls, sd)
+}
+
 func (scp *schemaParser) Parse(gofile *ast.File, target interface{}) error {
 	tgt := target.(map[string]spec.Schema)
 	for _, decl := range gofile.Decls {
@@ -182,14 +204,7 @@ func (scp *schemaParser) parseDecl(definitions map[string]spec.Schema, decl *sch
 	decl.inferNames()
 	schema := definitions[decl.Name]
 	schPtr := &schema
-
-	// analyze doc comment for the model
-	sp := new(sectionedParser)
-	sp.setTitle = func(lines []string) { schema.Title = joinDropLast(lines) }
-	sp.setDescription = func(lines []string) { schema.Description = joinDropLast(lines) }
-	if err := sp.Parse(decl.Decl.Doc); err != nil {
-		return err
-	}
+	scp.origin = "used by definition " + decl.Name
 
 	// analyze struct body for fields etc
 	// each exported struct field:
@@ -199,11 +214,63 @@ func (scp *schemaParser) parseDecl(definitions map[string]spec.Schema, decl *sch
 	// * when the struct field points to a model it becomes a ref: #/definitions/ModelName
 	// * the first line of the comment is the title
 	// * the following lines are the description
-	if tpe, ok := decl.TypeSpec.Type.(*ast.StructType); ok {
-		if err := scp.parseStructType(decl.File, schPtr, tpe, make(map[string]struct{})); err != nil {
+	// * when the struct implements an interface annotated with swagger:discriminator
+	//   it becomes allOf the ref to that interface and the schema of the struct
+	switch tpe := decl.TypeSpec.Type.(type) {
+	case *ast.StructType:
+		bases, err := scp.discriminatedBases(decl)
+		if err != nil {
 			return err
 		}
+		if len(bases) == 0 {
+			if err := scp.parseStructType(decl.File, schPtr, tpe, make(map[string]struct{})); err != nil {
+				return err
+			}
+			break
+		}
+
+		var own spec.Schema
+		if err := scp.parseStructType(decl.File, &own, tpe, make(map[string]struct{})); err != nil {
+			return err
+		}
+		schPtr.AllOf = nil
+		for _, base := range bases {
+			ref, err := spec.NewRef("#/definitions/" + base.Name)
+			if err != nil {
+				return err
+			}
+			var baseSchema spec.Schema
+			baseSchema.Ref = ref
+			schPtr.AllOf = append(schPtr.AllOf, baseSchema)
+			scp.discover(base)
+		}
+		schPtr.AllOf = append(schPtr.AllOf, own)
+
+	case *ast.InterfaceType:
+		// the value of the discriminator is the name of the definition of the implementer
+		if propName, ok := discriminatorName(decl.Decl.Doc); ok {
+			if err := scp.parseDiscriminatedInterface(decl, schPtr, propName); err != nil {
+				return err
+			}
+		}
+	}
+
+	// analyze doc comment for the model,
+	// this goes after the body so the default and example can be checked against the type
+	sp := new(sectionedParser)
+	sp.setTitle = func(lines []string) { schema.Title = joinDropLast(lines) }
+	sp.setDescription = func(lines []string) { schema.Description = joinDropLast(lines) }
+	sp.taggers = []tagParser{
+		newSingleLineTagParser("default", newSetDefault(schemaValueType(schPtr), func(v interface{}) { schema.Default = v })),
+		newSingleLineTagParser("example", newSetExample(schemaValueType(schPtr), func(v interface{}) { schema.Example = v })),
+		newSingleLineTagParser("deprecated", newSetDeprecated(deprecateExtension(schPtr))),
+		newMultiLineTagParser("extensions", newSetExtensions(addExtensions(schPtr))),
+		newMultiLineTagParser("externalDocs", newSetExternalDocs(func(docs *spec.ExternalDocumentation) { schema.ExternalDocs = docs })),
 	}
+	if err := sp.Parse(decl.Decl.Doc); err != nil {
+		return err
+	}
+
 	if decl.Name != decl.GoName {
 		schPtr.AddExtension("x-go-name", decl.GoName)
 	}
@@ -296,7 +363,7 @@ func (scp *schemaParser) parseAllOfMember(gofile *ast.File, schema *spec.Schema,
 			return err
 		}
 		schema.Ref = ref
-		scp.postDecls = append(scp.postDecls, *sd)
+		scp.discover(*sd)
 	} else {
 		if st, ok := ts.Type.(*ast.StructType); ok {
 			return scp.parseStructType(file, schema, st, seenPreviously)
@@ -376,9 +443,13 @@ func (scp *schemaParser) parseStructType(gofile *ast.File, bschema *spec.Schema,
 				if err := parseProperty(scp, gofile, fld.Type, schemaTypable{&ps}); err != nil {
 					return err
 				}
+				enumDesc, err := scp.enumDescriptionFor(gofile, fld.Type)
+				if err != nil {
+					return err
+				}
 
 				sp := new(sectionedParser)
-				sp.setDescription = func(lines []string) { ps.Description = joinDropLast(lines) }
+				sp.setDescription = func(lines []string) { ps.Description = joinDescription(joinDropLast(lines), enumDesc) }
 				if ps.Ref.GetURL() == nil {
 					sp.taggers = []tagParser{
 						newSingleLineTagParser("maximum", &setMaximum{schemaValidations{&ps}, rxf(rxMaximumFmt, "")}),
@@ -392,6 +463,11 @@ func (scp *schemaParser) parseStructType(gofile *ast.File, bschema *spec.Schema,
 						newSingleLineTagParser("unique", &setUnique{schemaValidations{&ps}, rxf(rxUniqueFmt, "")}),
 						newSingleLineTagParser("required", &setRequiredSchema{schema, nm}),
 						newSingleLineTagParser("readOnly", &setReadOnlySchema{&ps}),
+						newSingleLineTagParser("default", newSetDefault(schemaValueType(&ps), func(v interface{}) { ps.Default = v })),
+						newSingleLineTagParser("example", newSetExample(schemaValueType(&ps), func(v interface{}) { ps.Example = v })),
+						newSingleLineTagParser("deprecated", newSetDeprecated(deprecateExtension(&ps))),
+						newMultiLineTagParser("extensions", newSetExtensions(addExtensions(&ps))),
+						newMultiLineTagParser("externalDocs", newSetExternalDocs(func(docs *spec.ExternalDocumentation) { ps.ExternalDocs = docs })),
 					}
 
 					// check if this is a primitive, if so parse the validations from the
@@ -424,6 +500,9 @@ func (scp *schemaParser) parseStructType(gofile *ast.File, bschema *spec.Schema,
 				if err := sp.Parse(fld.Doc); err != nil {
 					return err
 				}
+				if err := scp.applyTagValidations(fld, schemaValidationTarget(&ps, schema, nm)); err != nil {
+					return err
+				}
 
 				if nm != gnm {
 					ps.AddExtension("x-go-name", gnm)
@@ -489,11 +568,27 @@ func (scp *schemaParser) packageForSelector(gofile *ast.File, expr ast.Expr) (*l
 }
 
 func (scp *schemaParser) parseIdentProperty(pkg *loader.PackageInfo, expr *ast.Ident, prop swaggerTypable) error {
+	// types that can't be annotated, like time.Time, are mapped by name
+	if tm, ok := scp.typeMapping(pkg.Pkg.Path(), expr.Name); ok {
+		tm.apply(prop)
+		return nil
+	}
+
 	// find the file this selector points to
 	file, gd, ts, err := findSourceFile(pkg, expr.Name)
 	if err != nil {
 		return swaggerSchemaForType(expr.Name, prop)
 	}
+
+	// look at doc comments for swagger:type [type] [format]
+	tm, ok, err := swaggerTypeAnnotation(gd.Doc)
+	if err != nil {
+		return fmt.Errorf("%s: %v", expr.Name, err)
+	}
+	if ok {
+		tm.apply(prop)
+		return nil
+	}
 	if at, ok := ts.Type.(*ast.ArrayType); ok {
 		// the swagger spec defines strfmt base64 as []byte.
 		// in that case we don't actually want to turn it into an array
@@ -535,14 +630,35 @@ func (scp *schemaParser) parseIdentProperty(pkg *loader.PackageInfo, expr *ast.I
 			return err
 		}
 		prop.SetRef(ref)
-		scp.postDecls = append(scp.postDecls, *sd)
+		scp.discover(*sd)
+		return nil
+
+	case *ast.InterfaceType:
+		// only interfaces with a discriminator can be described,
+		// for any other interface the value can be anything
+		if _, ok := discriminatorName(gd.Doc); !ok {
+			return nil
+		}
+		sd := newSchemaDecl(file, gd, ts)
+		ref, err := spec.NewRef("#/definitions/" + sd.Name)
+		if err != nil {
+			return err
+		}
+		prop.SetRef(ref)
+		scp.discover(*sd)
 		return nil
 
 	case *ast.Ident:
-		return scp.parseIdentProperty(pkg, tpe, prop)
+		if err := scp.parseIdentProperty(pkg, tpe, prop); err != nil {
+			return err
+		}
+		return withEnum(pkg, gd, ts, prop)
 
 	case *ast.SelectorExpr:
-		return scp.typeForSelector(file, tpe, prop)
+		if err := scp.typeForSelector(file, tpe, prop); err != nil {
+			return err
+		}
+		return withEnum(pkg, gd, ts, prop)
 
 	default:
 		return swaggerSchemaForType(expr.Name, prop)
@@ -654,11 +770,8 @@ func parseProperty(scp *schemaParser, gofile *ast.File, fld ast.Expr, prop swagg
 		}
 
 	case *ast.InterfaceType:
-		// NOTE:
-		// what to do with an interface? support it?
-		// ignoring it for now
-		// I guess something can be done with a discriminator field
-		// but is it worth the trouble?
+		// an inline interface can hold any value, so it doesn't get a type.
+		// named interfaces annotated with swagger:discriminator are resolved as identifiers
 	default:
 		return fmt.Errorf("%s is unsupported for a schema", ftpe)
 	}
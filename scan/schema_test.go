@@ -1,6 +1,11 @@
 package scan
 
 import (
+	"encoding/json"
+	goparser "go/parser"
+	"io/ioutil"
+	"math/big"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -17,7 +22,7 @@ func TestSchemaParser(t *testing.T) {
 	assert.Equal(t, "NoModel exists in a package\nbut is not annotated with the swagger model annotations\nso it should now show up in a test.", schema.Description)
 	assert.Len(t, schema.Required, 3)
 
-	assertProperty(t, &schema, "number", "id", "int64", "ID")
+	assertProperty(t, &schema, "integer", "id", "int64", "ID")
 	prop, ok := schema.Properties["id"]
 	assert.Equal(t, "ID of this no model instance.\nids in this application start at 11 and are smaller than 1000", prop.Description)
 	assert.True(t, ok, "should have had an 'id' property")
@@ -27,7 +32,7 @@ func TestSchemaParser(t *testing.T) {
 	assert.EqualValues(t, 10, *prop.Minimum)
 	assert.True(t, prop.ExclusiveMinimum, "'id' should have had an exclusive minimum")
 
-	assertProperty(t, &schema, "number", "score", "int32", "Score")
+	assertProperty(t, &schema, "integer", "score", "int32", "Score")
 	prop, ok = schema.Properties["score"]
 	assert.Equal(t, "The Score of this model", prop.Description)
 	assert.True(t, ok, "should have had a 'score' property")
@@ -72,7 +77,7 @@ func TestSchemaParser(t *testing.T) {
 	itprop = prop.Items.Schema
 	assert.Len(t, itprop.Properties, 4)
 	assert.Len(t, itprop.Required, 3)
-	assertProperty(t, itprop, "number", "id", "int32", "ID")
+	assertProperty(t, itprop, "integer", "id", "int32", "ID")
 	iprop, ok := itprop.Properties["id"]
 	assert.True(t, ok)
 	assert.Equal(t, "ID of this no model instance.\nids in this application start at 11 and are smaller than 1000", iprop.Description)
@@ -87,7 +92,7 @@ func TestSchemaParser(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, "The Pet to add to this NoModel items bucket.\nPets can appear more than once in the bucket", iprop.Description)
 
-	assertProperty(t, itprop, "number", "quantity", "int16", "Quantity")
+	assertProperty(t, itprop, "integer", "quantity", "int32", "Quantity")
 	iprop, ok = itprop.Properties["quantity"]
 	assert.True(t, ok)
 	assert.Equal(t, "The amount of pets to add to this bucket.", iprop.Description)
@@ -124,8 +129,8 @@ func TestSchemaParser(t *testing.T) {
 
 func TestEmbeddedTypes(t *testing.T) {
 	schema := noModelDefs["ComplexerOne"]
-	assertProperty(t, &schema, "number", "age", "int32", "Age")
-	assertProperty(t, &schema, "number", "id", "int64", "ID")
+	assertProperty(t, &schema, "integer", "age", "int32", "Age")
+	assertProperty(t, &schema, "integer", "id", "int64", "ID")
 	assertProperty(t, &schema, "string", "createdAt", "date-time", "CreatedAt")
 	assertProperty(t, &schema, "string", "extra", "", "Extra")
 	assertProperty(t, &schema, "string", "name", "", "Name")
@@ -137,8 +142,8 @@ func TestEmbeddedAllOf(t *testing.T) {
 
 	assert.Len(t, schema.AllOf, 3)
 	asch := schema.AllOf[0]
-	assertProperty(t, &asch, "number", "age", "int32", "Age")
-	assertProperty(t, &asch, "number", "id", "int64", "ID")
+	assertProperty(t, &asch, "integer", "age", "int32", "Age")
+	assertProperty(t, &asch, "integer", "id", "int64", "ID")
 	assertProperty(t, &asch, "string", "name", "", "Name")
 
 	asch = schema.AllOf[1]
@@ -146,47 +151,47 @@ func TestEmbeddedAllOf(t *testing.T) {
 
 	asch = schema.AllOf[2]
 	assertProperty(t, &asch, "string", "createdAt", "date-time", "CreatedAt")
-	assertProperty(t, &asch, "number", "did", "int64", "DID")
+	assertProperty(t, &asch, "integer", "did", "int64", "DID")
 	assertProperty(t, &asch, "string", "cat", "", "Cat")
 }
 
 func TestAliasedTypes(t *testing.T) {
 	schema := noModelDefs["OtherTypes"]
 	assertProperty(t, &schema, "string", "named", "", "Named")
-	assertProperty(t, &schema, "number", "numbered", "int64", "Numbered")
+	assertProperty(t, &schema, "integer", "numbered", "int64", "Numbered")
 	assertProperty(t, &schema, "string", "timed", "date-time", "Timed")
 	assertRef(t, &schema, "petted", "Petted", "#/definitions/pet")
 	assertRef(t, &schema, "somethinged", "Somethinged", "#/definitions/Something")
 	assertProperty(t, &schema, "string", "dated", "date-time", "Dated")
 
 	assertArrayProperty(t, &schema, "string", "manyNamed", "", "ManyNamed")
-	assertArrayProperty(t, &schema, "number", "manyNumbered", "int64", "ManyNumbered")
+	assertArrayProperty(t, &schema, "integer", "manyNumbered", "int64", "ManyNumbered")
 	assertArrayProperty(t, &schema, "string", "manyTimed", "date-time", "ManyTimed")
 	assertArrayRef(t, &schema, "manyPetted", "ManyPetted", "#/definitions/pet")
 	assertArrayRef(t, &schema, "manySomethinged", "ManySomethinged", "#/definitions/Something")
 	assertArrayProperty(t, &schema, "string", "manyDated", "date-time", "ManyDated")
 
 	assertArrayProperty(t, &schema, "string", "nameds", "", "Nameds")
-	assertArrayProperty(t, &schema, "number", "numbereds", "int64", "Numbereds")
+	assertArrayProperty(t, &schema, "integer", "numbereds", "int64", "Numbereds")
 	assertArrayProperty(t, &schema, "string", "timeds", "date-time", "Timeds")
 	assertArrayRef(t, &schema, "petteds", "Petteds", "#/definitions/pet")
 	assertArrayRef(t, &schema, "somethingeds", "Somethingeds", "#/definitions/Something")
 	assertArrayProperty(t, &schema, "string", "dateds", "date-time", "Dateds")
 
 	assertProperty(t, &schema, "string", "modsNamed", "", "ModsNamed")
-	assertProperty(t, &schema, "number", "modsNumbered", "int64", "ModsNumbered")
+	assertProperty(t, &schema, "integer", "modsNumbered", "int64", "ModsNumbered")
 	assertProperty(t, &schema, "string", "modsTimed", "date-time", "ModsTimed")
 	assertRef(t, &schema, "modsPetted", "ModsPetted", "#/definitions/pet")
 	assertProperty(t, &schema, "string", "modsDated", "date-time", "ModsDated")
 
 	assertArrayProperty(t, &schema, "string", "manyModsNamed", "", "ManyModsNamed")
-	assertArrayProperty(t, &schema, "number", "manyModsNumbered", "int64", "ManyModsNumbered")
+	assertArrayProperty(t, &schema, "integer", "manyModsNumbered", "int64", "ManyModsNumbered")
 	assertArrayProperty(t, &schema, "string", "manyModsTimed", "date-time", "ManyModsTimed")
 	assertArrayRef(t, &schema, "manyModsPetted", "ManyModsPetted", "#/definitions/pet")
 	assertArrayProperty(t, &schema, "string", "manyModsDated", "date-time", "ManyModsDated")
 
 	assertArrayProperty(t, &schema, "string", "modsNameds", "", "ModsNameds")
-	assertArrayProperty(t, &schema, "number", "modsNumbereds", "int64", "ModsNumbereds")
+	assertArrayProperty(t, &schema, "integer", "modsNumbereds", "int64", "ModsNumbereds")
 	assertArrayProperty(t, &schema, "string", "modsTimeds", "date-time", "ModsTimeds")
 	assertArrayRef(t, &schema, "modsPetteds", "ModsPetteds", "#/definitions/pet")
 	assertArrayProperty(t, &schema, "string", "modsDateds", "date-time", "ModsDateds")
@@ -197,16 +202,16 @@ func TestParsePrimitiveSchemaProperty(t *testing.T) {
 	assertProperty(t, &schema, "boolean", "a", "", "A")
 	assertProperty(t, &schema, "string", "b", "", "B")
 	assertProperty(t, &schema, "string", "c", "", "C")
-	assertProperty(t, &schema, "number", "d", "int64", "D")
-	assertProperty(t, &schema, "number", "e", "int8", "E")
-	assertProperty(t, &schema, "number", "f", "int16", "F")
-	assertProperty(t, &schema, "number", "g", "int32", "G")
-	assertProperty(t, &schema, "number", "h", "int64", "H")
-	assertProperty(t, &schema, "number", "i", "uint64", "I")
-	assertProperty(t, &schema, "number", "j", "uint8", "J")
-	assertProperty(t, &schema, "number", "k", "uint16", "K")
-	assertProperty(t, &schema, "number", "l", "uint32", "L")
-	assertProperty(t, &schema, "number", "m", "uint64", "M")
+	assertProperty(t, &schema, "integer", "d", "int64", "D")
+	assertProperty(t, &schema, "integer", "e", "int32", "E")
+	assertProperty(t, &schema, "integer", "f", "int32", "F")
+	assertProperty(t, &schema, "integer", "g", "int32", "G")
+	assertProperty(t, &schema, "integer", "h", "int64", "H")
+	assertProperty(t, &schema, "integer", "i", "int64", "I")
+	assertProperty(t, &schema, "integer", "j", "int32", "J")
+	assertProperty(t, &schema, "integer", "k", "int32", "K")
+	assertProperty(t, &schema, "integer", "l", "int64", "L")
+	assertProperty(t, &schema, "integer", "m", "int64", "M")
 	assertProperty(t, &schema, "number", "n", "float", "N")
 	assertProperty(t, &schema, "number", "o", "double", "O")
 }
@@ -256,7 +261,7 @@ func TestParseStructFields(t *testing.T) {
 	schema := noModelDefs["SimpleComplexModel"]
 	assertProperty(t, &schema, "object", "emb", "", "Emb")
 	eSchema := schema.Properties["emb"]
-	assertProperty(t, &eSchema, "number", "cid", "int64", "CID")
+	assertProperty(t, &eSchema, "integer", "cid", "int64", "CID")
 	assertProperty(t, &eSchema, "string", "baz", "", "Baz")
 
 	assertRef(t, &schema, "top", "Top", "#/definitions/Something")
@@ -266,12 +271,12 @@ func TestParseStructFields(t *testing.T) {
 func TestParsePointerFields(t *testing.T) {
 	schema := noModelDefs["Pointdexter"]
 
-	assertProperty(t, &schema, "number", "id", "int64", "ID")
+	assertProperty(t, &schema, "integer", "id", "int64", "ID")
 	assertProperty(t, &schema, "string", "name", "", "Name")
 	assertProperty(t, &schema, "object", "emb", "", "Emb")
 	assertProperty(t, &schema, "string", "t", "uuid5", "T")
 	eSchema := schema.Properties["emb"]
-	assertProperty(t, &eSchema, "number", "cid", "int64", "CID")
+	assertProperty(t, &eSchema, "integer", "cid", "int64", "CID")
 	assertProperty(t, &eSchema, "string", "baz", "", "Baz")
 
 	assertRef(t, &schema, "top", "Top", "#/definitions/Something")
@@ -299,23 +304,23 @@ func assertArrayRef(t testing.TB, schema *spec.Schema, jsonName, goName, fragmen
 func TestParseSliceFields(t *testing.T) {
 	schema := noModelDefs["SliceAndDice"]
 
-	assertArrayProperty(t, &schema, "number", "ids", "int64", "IDs")
+	assertArrayProperty(t, &schema, "integer", "ids", "int64", "IDs")
 	assertArrayProperty(t, &schema, "string", "names", "", "Names")
 	assertArrayProperty(t, &schema, "string", "uuids", "uuid", "UUIDs")
 	assertArrayProperty(t, &schema, "object", "embs", "", "Embs")
 	eSchema := schema.Properties["embs"].Items.Schema
-	assertArrayProperty(t, eSchema, "number", "cid", "int64", "CID")
+	assertArrayProperty(t, eSchema, "integer", "cid", "int64", "CID")
 	assertArrayProperty(t, eSchema, "string", "baz", "", "Baz")
 
 	assertArrayRef(t, &schema, "tops", "Tops", "#/definitions/Something")
 	assertArrayRef(t, &schema, "notSels", "NotSels", "#/definitions/NotSelected")
 
-	assertArrayProperty(t, &schema, "number", "ptrIds", "int64", "PtrIDs")
+	assertArrayProperty(t, &schema, "integer", "ptrIds", "int64", "PtrIDs")
 	assertArrayProperty(t, &schema, "string", "ptrNames", "", "PtrNames")
 	assertArrayProperty(t, &schema, "string", "ptrUuids", "uuid", "PtrUUIDs")
 	assertArrayProperty(t, &schema, "object", "ptrEmbs", "", "PtrEmbs")
 	eSchema = schema.Properties["ptrEmbs"].Items.Schema
-	assertArrayProperty(t, eSchema, "number", "ptrCid", "int64", "PtrCID")
+	assertArrayProperty(t, eSchema, "integer", "ptrCid", "int64", "PtrCID")
 	assertArrayProperty(t, eSchema, "string", "ptrBaz", "", "PtrBaz")
 
 	assertArrayRef(t, &schema, "ptrTops", "PtrTops", "#/definitions/Something")
@@ -343,25 +348,402 @@ func assertMapRef(t testing.TB, schema *spec.Schema, jsonName, goName, fragment
 func TestParseMapFields(t *testing.T) {
 	schema := noModelDefs["MapTastic"]
 
-	assertMapProperty(t, &schema, "number", "ids", "int64", "IDs")
+	assertMapProperty(t, &schema, "integer", "ids", "int64", "IDs")
 	assertMapProperty(t, &schema, "string", "names", "", "Names")
 	assertMapProperty(t, &schema, "string", "uuids", "uuid", "UUIDs")
 	assertMapProperty(t, &schema, "object", "embs", "", "Embs")
 	eSchema := schema.Properties["embs"].AdditionalProperties.Schema
-	assertMapProperty(t, eSchema, "number", "cid", "int64", "CID")
+	assertMapProperty(t, eSchema, "integer", "cid", "int64", "CID")
 	assertMapProperty(t, eSchema, "string", "baz", "", "Baz")
 
 	assertMapRef(t, &schema, "tops", "Tops", "#/definitions/Something")
 	assertMapRef(t, &schema, "notSels", "NotSels", "#/definitions/NotSelected")
 
-	assertMapProperty(t, &schema, "number", "ptrIds", "int64", "PtrIDs")
+	assertMapProperty(t, &schema, "integer", "ptrIds", "int64", "PtrIDs")
 	assertMapProperty(t, &schema, "string", "ptrNames", "", "PtrNames")
 	assertMapProperty(t, &schema, "string", "ptrUuids", "uuid", "PtrUUIDs")
 	assertMapProperty(t, &schema, "object", "ptrEmbs", "", "PtrEmbs")
 	eSchema = schema.Properties["ptrEmbs"].AdditionalProperties.Schema
-	assertMapProperty(t, eSchema, "number", "ptrCid", "int64", "PtrCID")
+	assertMapProperty(t, eSchema, "integer", "ptrCid", "int64", "PtrCID")
 	assertMapProperty(t, eSchema, "string", "ptrBaz", "", "PtrBaz")
 
 	assertMapRef(t, &schema, "ptrTops", "PtrTops", "#/definitions/Something")
 	assertMapRef(t, &schema, "ptrNotSels", "PtrNotSels", "#/definitions/NotSelected")
 }
+
+func TestEnumSchemaProperties(t *testing.T) {
+	schema := noModelDefs["EnumModel"]
+
+	assertProperty(t, &schema, "string", "status", "", "Status")
+	prop := schema.Properties["status"]
+	assert.Equal(t, []interface{}{"available", "pending", "sold"}, prop.Enum)
+	assert.Equal(t, "The status of the pet\n* available - PetStatusAvailable the pet can be adopted\n* pending - PetStatusPending the adoption of the pet is being processed\n* sold - the pet has a new home", prop.Description)
+
+	assertProperty(t, &schema, "integer", "priority", "int32", "Priority")
+	prop = schema.Properties["priority"]
+	assert.Equal(t, []interface{}{int64(1), int64(2), int64(3)}, prop.Enum)
+	assert.Contains(t, prop.Description, "* 3 - PriorityHigh has to be done today")
+
+	assertArrayProperty(t, &schema, "string", "statuses", "", "Statuses")
+	prop = schema.Properties["statuses"]
+	assert.Equal(t, []interface{}{"available", "pending", "sold"}, prop.Items.Schema.Enum)
+
+	assertProperty(t, &schema, "string", "color", "", "Color")
+	prop = schema.Properties["color"]
+	assert.Empty(t, prop.Enum)
+	assert.Equal(t, "Color isn't an enum", prop.Description)
+}
+
+func TestDiscriminatedInterfaces(t *testing.T) {
+	docFile := "../fixtures/goparsing/classification/models/discriminated.go"
+	fileTree, err := goparser.ParseFile(classificationProg.Fset, docFile, nil, goparser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sp := newSchemaParser(classificationProg)
+	definitions := make(map[string]spec.Schema)
+	if !assert.NoError(t, sp.Parse(fileTree, definitions)) {
+		return
+	}
+
+	// the interface is the base schema
+	schema := definitions["fish"]
+	assert.Equal(t, "kind", schema.Discriminator)
+	assert.Equal(t, []string{"kind"}, schema.Required)
+	assert.Equal(t, spec.StringProperty().Type, schema.Properties["kind"].Type)
+	assert.Equal(t, "Fish", schema.Extensions["x-go-name"])
+
+	// the annotated implementers are composed with the base schema
+	var discovered []string
+	for _, sd := range sp.postDecls {
+		discovered = append(discovered, sd.Name)
+	}
+	assert.Contains(t, discovered, "salmon")
+	assert.Contains(t, discovered, "shark")
+	assert.NotContains(t, discovered, "Nemo")
+
+	schema = definitions["salmon"]
+	if assert.Len(t, schema.AllOf, 2) {
+		assert.Equal(t, "#/definitions/fish", schema.AllOf[0].Ref.String())
+		own := schema.AllOf[1]
+		assertProperty(t, &own, "string", "name", "", "Name")
+		assertProperty(t, &own, "integer", "distance", "int64", "Distance")
+	}
+	assert.Equal(t, "A Salmon is a fish that swims upstream", schema.Description)
+
+	// pointer receivers implement the interface too
+	schema = definitions["shark"]
+	if assert.Len(t, schema.AllOf, 2) {
+		assert.Equal(t, "#/definitions/fish", schema.AllOf[0].Ref.String())
+		own := schema.AllOf[1]
+		assertProperty(t, &own, "integer", "teeth", "int32", "Teeth")
+	}
+
+	// types that aren't annotated don't become part of the union
+	schema = definitions["Nemo"]
+	assert.Empty(t, schema.AllOf)
+
+	// fields with the interface type refer to the base schema
+	schema = definitions["aquarium"]
+	assertRef(t, &schema, "biggest", "Biggest", "#/definitions/fish")
+	assertArrayRef(t, &schema, "fishes", "Fishes", "#/definitions/fish")
+	assertProperty(t, &schema, "", "swimmer", "", "Swimmer")
+	assertProperty(t, &schema, "", "decoration", "", "Decoration")
+	assert.Equal(t, "Something that swims by", schema.Properties["swimmer"].Description)
+}
+
+func TestSchemaDefaultsAndExamples(t *testing.T) {
+	docFile := "../fixtures/goparsing/classification/models/examples.go"
+	fileTree, err := goparser.ParseFile(classificationProg.Fset, docFile, nil, goparser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sp := newSchemaParser(classificationProg)
+	definitions := make(map[string]spec.Schema)
+	if !assert.NoError(t, sp.Parse(fileTree, definitions)) {
+		return
+	}
+
+	schema := definitions["settings"]
+	assert.Equal(t, "A Settings model has defaults and examples", schema.Description)
+	assert.Equal(t, map[string]interface{}{"name": "dark", "size": int64(12), "tags": []interface{}{"night"}}, schema.Example)
+
+	prop := schema.Properties["name"]
+	assert.Equal(t, "light", prop.Default)
+	assert.Equal(t, "dark ", prop.Example)
+	assert.Equal(t, "The name of the theme", prop.Description)
+
+	prop = schema.Properties["size"]
+	assert.Equal(t, int64(14), prop.Default)
+	assert.Equal(t, int64(12), prop.Example)
+
+	prop = schema.Properties["ratio"]
+	assert.Equal(t, 1.5, prop.Default)
+
+	prop = schema.Properties["enabled"]
+	assert.Equal(t, true, prop.Default)
+
+	prop = schema.Properties["retries"]
+	assert.Equal(t, "The number of retries, for example: call it twice\nwhen the first call can fail, the default: 3 is fine", prop.Description)
+	assert.Equal(t, int64(2), prop.Default)
+	assert.Nil(t, prop.Example)
+
+	prop = schema.Properties["tags"]
+	assert.Equal(t, []interface{}{"day", "light"}, prop.Default)
+	assert.Equal(t, []interface{}{"night", "dark"}, prop.Example)
+
+	prop = schema.Properties["options"]
+	assert.Equal(t, map[string]interface{}{"contrast": int64(2), "font": "mono"}, prop.Example)
+
+	prop = schema.Properties["mascot"]
+	assert.Equal(t, map[string]interface{}{"name": "fido"}, prop.Example)
+}
+
+func TestParseValue(t *testing.T) {
+	intType := valueType{Type: "integer", Format: "int64"}
+	values := []struct {
+		Type     valueType
+		Raw      string
+		Expected interface{}
+	}{
+		{valueType{Type: "string"}, "plain text", "plain text"},
+		{valueType{Type: "string"}, `"quoted "`, "quoted "},
+		{valueType{Type: "boolean"}, "false", false},
+		{intType, "-12", int64(-12)},
+		{valueType{Type: "integer", Format: "uint8"}, "12", uint64(12)},
+		{valueType{Type: "number", Format: "double"}, "1.25", 1.25},
+		{valueType{Type: "array", Items: &intType}, "1, 2", []interface{}{int64(1), int64(2)}},
+		{valueType{Type: "array", Items: &intType}, "[3]", []interface{}{int64(3)}},
+		{valueType{Type: "object"}, `{"a": [1.5]}`, map[string]interface{}{"a": []interface{}{1.5}}},
+		{valueType{}, `"anything"`, "anything"},
+	}
+	for _, v := range values {
+		value, err := parseValue(v.Type, v.Raw)
+		if assert.NoError(t, err, v.Raw) {
+			assert.Equal(t, v.Expected, value, v.Raw)
+		}
+	}
+
+	invalid := []struct {
+		Type valueType
+		Raw  string
+	}{
+		{intType, "twelve"},
+		{intType, "1.5"},
+		{valueType{Type: "boolean"}, "yes"},
+		{valueType{Type: "array", Items: &intType}, `["a"]`},
+		{valueType{Type: "array", Items: &intType}, "1, b"},
+		{valueType{Type: "object"}, "[1]"},
+		{valueType{Type: "object"}, "{"},
+		{valueType{Type: "string"}, `"unterminated`},
+	}
+	for _, v := range invalid {
+		_, err := parseValue(v.Type, v.Raw)
+		assert.Error(t, err, v.Raw)
+	}
+
+	tagger := newSetDefault(intType, func(interface{}) {})
+	err := tagger.Parse([]string{"default: twelve"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `invalid default "twelve"`)
+	}
+}
+
+func TestSchemaTagValidations(t *testing.T) {
+	docFile := "../fixtures/goparsing/classification/models/tagged.go"
+	fileTree, err := goparser.ParseFile(classificationProg.Fset, docFile, nil, goparser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// without validation tags only the comments are used
+	sp := newSchemaParser(classificationProg)
+	definitions := make(map[string]spec.Schema)
+	if assert.NoError(t, sp.Parse(fileTree, definitions)) {
+		schema := definitions["tagged"]
+		assert.Empty(t, schema.Required)
+		assert.Nil(t, schema.Properties["name"].MinLength)
+	}
+
+	var warnings []string
+	sp = newSchemaParser(classificationProg)
+	sp.validationTags = []string{"json", "validate", "binding"}
+	sp.warn = func(msg string) { warnings = append(warnings, msg) }
+	definitions = make(map[string]spec.Schema)
+	if !assert.NoError(t, sp.Parse(fileTree, definitions)) {
+		return
+	}
+	schema := definitions["tagged"]
+	assert.Equal(t, []string{"id", "email"}, schema.Required)
+
+	prop := schema.Properties["id"]
+	if assert.NotNil(t, prop.Minimum) {
+		assert.Equal(t, float64(0), *prop.Minimum)
+		assert.True(t, prop.ExclusiveMinimum)
+	}
+
+	prop = schema.Properties["name"]
+	if assert.NotNil(t, prop.MinLength) && assert.NotNil(t, prop.MaxLength) {
+		assert.EqualValues(t, 1, *prop.MinLength)
+		assert.EqualValues(t, 64, *prop.MaxLength)
+	}
+	assert.Equal(t, "^[a-zA-Z0-9]+$", prop.Pattern)
+
+	prop = schema.Properties["email"]
+	assert.Equal(t, "email", prop.Format)
+
+	prop = schema.Properties["code"]
+	if assert.NotNil(t, prop.MinLength) && assert.NotNil(t, prop.MaxLength) {
+		assert.EqualValues(t, 3, *prop.MinLength)
+		assert.EqualValues(t, 3, *prop.MaxLength)
+	}
+
+	// the comments take precedence over the tags
+	prop = schema.Properties["score"]
+	if assert.NotNil(t, prop.Minimum) && assert.NotNil(t, prop.Maximum) {
+		assert.Equal(t, float64(1), *prop.Minimum)
+		assert.False(t, prop.ExclusiveMinimum)
+		assert.Equal(t, float64(10), *prop.Maximum)
+		assert.False(t, prop.ExclusiveMaximum)
+	}
+	if assert.Len(t, warnings, 3) {
+		assert.Contains(t, warnings[0], "tagged.go")
+		assert.Contains(t, warnings[0], "Score: the validate tag sets maximum to 100 but the comments set it to 10")
+		assert.Contains(t, warnings[1], "Note: the json tag sets required to true but the comments set it to false")
+		assert.Contains(t, warnings[2], "Note: the validate tag sets required to true but the comments set it to false")
+	}
+
+	prop = schema.Properties["labels"]
+	if assert.NotNil(t, prop.MaxItems) {
+		assert.EqualValues(t, 5, *prop.MaxItems)
+	}
+	assert.True(t, prop.UniqueItems)
+	items := prop.Items.Schema
+	if assert.NotNil(t, items.MinLength) && assert.NotNil(t, items.MaxLength) {
+		assert.EqualValues(t, 2, *items.MinLength)
+		assert.EqualValues(t, 10, *items.MaxLength)
+	}
+
+	prop = schema.Properties["kind"]
+	assert.Equal(t, []interface{}{"big", "small"}, prop.Enum)
+
+	prop = schema.Properties["either"]
+	assert.Empty(t, prop.Format)
+}
+
+func TestSchemaExtensions(t *testing.T) {
+	docFile := "../fixtures/goparsing/classification/models/extensions.go"
+	fileTree, err := goparser.ParseFile(classificationProg.Fset, docFile, nil, goparser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sp := newSchemaParser(classificationProg)
+	definitions := make(map[string]spec.Schema)
+	if !assert.NoError(t, sp.Parse(fileTree, definitions)) {
+		return
+	}
+
+	schema := definitions["legacy"]
+	assert.Equal(t, "A Legacy model is kept for the old clients.", schema.Title)
+	assert.Equal(t, true, schema.Extensions["x-deprecated"])
+	assert.Equal(t, "legacy.Model", schema.Extensions["x-go-type"])
+	assert.Equal(t, map[string]interface{}{"team": "pets", "since": float64(2015)}, schema.Extensions["x-owner"])
+	assert.Equal(t, "Legacy", schema.Extensions["x-go-name"])
+	if assert.NotNil(t, schema.ExternalDocs) {
+		assert.Equal(t, "How to move to the new model", schema.ExternalDocs.Description)
+		assert.Equal(t, "http://example.com/migrate", schema.ExternalDocs.URL)
+	}
+
+	prop := schema.Properties["id"]
+	assert.Equal(t, "The id of the model", prop.Description)
+	assert.Equal(t, true, prop.Extensions["x-deprecated"])
+	assert.Equal(t, false, prop.Extensions["x-nullable"])
+
+	prop = schema.Properties["name"]
+	assert.Equal(t, "The name of the model", prop.Description)
+	assert.Nil(t, prop.Extensions["x-deprecated"])
+	if assert.NotNil(t, prop.ExternalDocs) {
+		assert.Equal(t, "http://example.com/names", prop.ExternalDocs.URL)
+	}
+}
+
+func TestSchemaTypeMappings(t *testing.T) {
+	docFile := "../fixtures/goparsing/classification/models/types.go"
+	fileTree, err := goparser.ParseFile(classificationProg.Fset, docFile, nil, goparser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sp := newSchemaParser(classificationProg)
+	sp.typeMappings = map[string]TypeMapping{"math/big.Int": {Type: "string", Format: "bigint"}}
+	definitions := make(map[string]spec.Schema)
+	if !assert.NoError(t, sp.Parse(fileTree, definitions)) {
+		return
+	}
+
+	schema := definitions["payment"]
+	assertProperty(t, &schema, "integer", "id", "int64", "ID")
+	assertProperty(t, &schema, "integer", "attempts", "int32", "Attempts")
+	assertProperty(t, &schema, "integer", "sequence", "int64", "Sequence")
+	assertProperty(t, &schema, "string", "amount", "decimal", "Amount")
+	assertArrayProperty(t, &schema, "string", "refunds", "decimal", "Refunds")
+	assertProperty(t, &schema, "string", "level", "", "Level")
+	assertProperty(t, &schema, "string", "paidAt", "date-time", "PaidAt")
+	assertProperty(t, &schema, "string", "total", "bigint", "Total")
+	assertProperty(t, &schema, "string", "rate", "decimal", "Rate")
+	amount := schema.Properties["amount"]
+	assert.Empty(t, amount.Ref.String())
+
+	// the values of unsigned types can't be negative
+	for _, name := range []string{"id", "attempts", "sequence"} {
+		prop := schema.Properties[name]
+		if assert.NotNil(t, prop.Minimum, name) {
+			assert.Equal(t, float64(0), *prop.Minimum, name)
+			assert.False(t, prop.ExclusiveMinimum, name)
+		}
+	}
+	level := schema.Properties["level"]
+	assert.Nil(t, level.Minimum)
+
+	// a big.Float marshals as a json string
+	var payment struct {
+		Rate big.Float `json:"rate"`
+	}
+	payment.Rate.SetFloat64(1.5)
+	data, err := json.Marshal(&payment)
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{"rate":"1.5"}`, string(data))
+	}
+}
+
+func TestLoadTypeMappings(t *testing.T) {
+	dir, err := ioutil.TempDir("", "type-mappings")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "types.yml")
+	doc := "types:\n  github.com/shopspring/decimal.Decimal:\n    type: string\n    format: decimal\n  github.com/jackc/pgtype.UUID:\n    strfmt: uuid\n"
+	if err := ioutil.WriteFile(file, []byte(doc), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mappings, err := LoadTypeMappings(file)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]TypeMapping{
+			"github.com/shopspring/decimal.Decimal": {Type: "string", Format: "decimal"},
+			"github.com/jackc/pgtype.UUID":          {Strfmt: "uuid"},
+		}, mappings)
+	}
+
+	if err := ioutil.WriteFile(file, []byte("types:\n  Decimal:\n    type: string\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = LoadTypeMappings(file)
+	assert.Error(t, err)
+
+	if err := ioutil.WriteFile(file, []byte("types:\n  time.Duration:\n    type: duration\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = LoadTypeMappings(file)
+	assert.Error(t, err)
+}
@@ -0,0 +1,422 @@
+package scan
+
+import (
+	"fmt"
+	"go/ast"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/vikstrous/go-swagger/spec"
+)
+
+// validationTarget points at the validations of a schema, parameter, header or items,
+// so the validations from struct tags can be applied to any of them
+type validationTarget struct {
+	Type             string
+	Format           *string
+	Maximum          **float64
+	ExclusiveMaximum *bool
+	Minimum          **float64
+	ExclusiveMinimum *bool
+	MaxLength        **int64
+	MinLength        **int64
+	MaxItems         **int64
+	MinItems         **int64
+	Pattern          *string
+	UniqueItems      *bool
+	Enum             *[]interface{}
+	// SetRequired is nil when the target can't be required
+	SetRequired func()
+	// Items is nil when the target has no items
+	Items func() *validationTarget
+}
+
+func schemaValidationTarget(schema *spec.Schema, parent *spec.Schema, name string) *validationTarget {
+	var tpe string
+	if len(schema.Type) > 0 {
+		tpe = schema.Type[0]
+	}
+	vt := &validationTarget{
+		Type:             tpe,
+		Format:           &schema.Format,
+		Maximum:          &schema.Maximum,
+		ExclusiveMaximum: &schema.ExclusiveMaximum,
+		Minimum:          &schema.Minimum,
+		ExclusiveMinimum: &schema.ExclusiveMinimum,
+		MaxLength:        &schema.MaxLength,
+		MinLength:        &schema.MinLength,
+		MaxItems:         &schema.MaxItems,
+		MinItems:         &schema.MinItems,
+		Pattern:          &schema.Pattern,
+		UniqueItems:      &schema.UniqueItems,
+		Enum:             &schema.Enum,
+	}
+	if parent != nil {
+		vt.SetRequired = func() {
+			for _, nm := range parent.Required {
+				if nm == name {
+					return
+				}
+			}
+			parent.Required = append(parent.Required, name)
+		}
+	}
+	if schema.Items != nil && schema.Items.Schema != nil {
+		vt.Items = func() *validationTarget { return schemaValidationTarget(schema.Items.Schema, nil, "") }
+	}
+	return vt
+}
+
+func paramValidationTarget(param *spec.Parameter) *validationTarget {
+	vt := &validationTarget{
+		Type:             param.Type,
+		Format:           &param.Format,
+		Maximum:          &param.Maximum,
+		ExclusiveMaximum: &param.ExclusiveMaximum,
+		Minimum:          &param.Minimum,
+		ExclusiveMinimum: &param.ExclusiveMinimum,
+		MaxLength:        &param.MaxLength,
+		MinLength:        &param.MinLength,
+		MaxItems:         &param.MaxItems,
+		MinItems:         &param.MinItems,
+		Pattern:          &param.Pattern,
+		UniqueItems:      &param.UniqueItems,
+		Enum:             &param.Enum,
+		SetRequired:      func() { param.Required = true },
+	}
+	if param.Items != nil {
+		vt.Items = func() *validationTarget { return itemsValidationTarget(param.Items) }
+	}
+	return vt
+}
+
+func headerValidationTarget(header *spec.Header) *validationTarget {
+	vt := &validationTarget{
+		Type:             header.Type,
+		Format:           &header.Format,
+		Maximum:          &header.Maximum,
+		ExclusiveMaximum: &header.ExclusiveMaximum,
+		Minimum:          &header.Minimum,
+		ExclusiveMinimum: &header.ExclusiveMinimum,
+		MaxLength:        &header.MaxLength,
+		MinLength:        &header.MinLength,
+		MaxItems:         &header.MaxItems,
+		MinItems:         &header.MinItems,
+		Pattern:          &header.Pattern,
+		UniqueItems:      &header.UniqueItems,
+		Enum:             &header.Enum,
+	}
+	if header.Items != nil {
+		vt.Items = func() *validationTarget { return itemsValidationTarget(header.Items) }
+	}
+	return vt
+}
+
+func itemsValidationTarget(items *spec.Items) *validationTarget {
+	vt := &validationTarget{
+		Type:             items.Type,
+		Format:           &items.Format,
+		Maximum:          &items.Maximum,
+		ExclusiveMaximum: &items.ExclusiveMaximum,
+		Minimum:          &items.Minimum,
+		ExclusiveMinimum: &items.ExclusiveMinimum,
+		MaxLength:        &items.MaxLength,
+		MinLength:        &items.MinLength,
+		MaxItems:         &items.MaxItems,
+		MinItems:         &items.MinItems,
+		Pattern:          &items.Pattern,
+		UniqueItems:      &items.UniqueItems,
+		Enum:             &items.Enum,
+	}
+	if items.Items != nil {
+		vt.Items = func() *validationTarget { return itemsValidationTarget(items.Items) }
+	}
+	return vt
+}
+
+// the formats for the validator rules that check a string format
+var validatorFormats = map[string]string{
+	"email":      "email",
+	"url":        "uri",
+	"uri":        "uri",
+	"uuid":       "uuid",
+	"uuid3":      "uuid3",
+	"uuid4":      "uuid4",
+	"uuid5":      "uuid5",
+	"hostname":   "hostname",
+	"ipv4":       "ipv4",
+	"ipv6":       "ipv6",
+	"isbn":       "isbn",
+	"isbn10":     "isbn10",
+	"isbn13":     "isbn13",
+	"mac":        "mac",
+	"base64":     "byte",
+	"hexcolor":   "hexcolor",
+	"rgbcolor":   "rgbcolor",
+	"ssn":        "ssn",
+	"creditcard": "creditcard",
+}
+
+// the patterns for the validator rules that check the characters of a string
+var validatorPatterns = map[string]string{
+	"alpha":       "^[a-zA-Z]+$",
+	"alphanum":    "^[a-zA-Z0-9]+$",
+	"numeric":     "^[-+]?[0-9]+(?:\\.[0-9]+)?$",
+	"hexadecimal": "^(0[xX])?[0-9a-fA-F]+$",
+}
+
+// tagValidations applies the validations from the struct tags of a field.
+// The tags named validate, binding or any other name use the rules of gopkg.in/go-playground/validator,
+// the json tag makes a field without omitempty required.
+// The comments of the field take precedence, when a tag disagrees with them it's reported as a conflict.
+type tagValidations struct {
+	field     string
+	tags      reflect.StructTag
+	conflicts []string
+	// required the value of the required comment, nil when the comments don't say
+	required *bool
+}
+
+func newTagValidations(field string, fld *ast.Field) (*tagValidations, error) {
+	tv := &tagValidations{field: field}
+	if fld.Tag != nil && len(strings.TrimSpace(fld.Tag.Value)) > 0 {
+		tag, err := strconv.Unquote(fld.Tag.Value)
+		if err != nil {
+			return nil, err
+		}
+		tv.tags = reflect.StructTag(tag)
+	}
+	if fld.Doc != nil {
+		for _, cmt := range fld.Doc.List {
+			for _, ln := range strings.Split(cmt.Text, "\n") {
+				if matches := rxRequired.FindStringSubmatch(ln); len(matches) > 1 {
+					required := matches[1] == "true"
+					tv.required = &required
+				}
+			}
+		}
+	}
+	return tv, nil
+}
+
+// Apply the validations of the struct tags with the names to the target
+func (tv *tagValidations) Apply(names []string, target *validationTarget) {
+	for _, name := range names {
+		tag := tv.tags.Get(name)
+		if tag == "" {
+			continue
+		}
+		if name == "json" {
+			parts := strings.Split(tag, ",")
+			if parts[0] == "-" {
+				continue
+			}
+			omitEmpty := false
+			for _, opt := range parts[1:] {
+				if opt == "omitempty" {
+					omitEmpty = true
+				}
+			}
+			if !omitEmpty {
+				tv.setRequired(name, target)
+			}
+			continue
+		}
+		tv.applyRules(name, tag, target)
+	}
+}
+
+func (tv *tagValidations) applyRules(name, tag string, target *validationTarget) {
+	for _, rule := range strings.Split(tag, ",") {
+		if target == nil {
+			return
+		}
+		rule = strings.TrimSpace(rule)
+		if rule == "-" {
+			return
+		}
+		if rule == "" || strings.Contains(rule, "|") {
+			// alternatives can't be described with the validations of a schema
+			continue
+		}
+		key, arg := rule, ""
+		if i := strings.Index(rule, "="); i >= 0 {
+			key, arg = rule[:i], rule[i+1:]
+		}
+
+		switch key {
+		case "dive":
+			// the rules after dive apply to the items
+			if target.Items == nil {
+				return
+			}
+			target = target.Items()
+		case "required":
+			tv.setRequired(name, target)
+		case "min", "gte":
+			tv.lowerBound(name, arg, target, false)
+		case "gt":
+			tv.lowerBound(name, arg, target, true)
+		case "max", "lte":
+			tv.upperBound(name, arg, target, false)
+		case "lt":
+			tv.upperBound(name, arg, target, true)
+		case "len":
+			tv.lowerBound(name, arg, target, false)
+			tv.upperBound(name, arg, target, false)
+		case "unique":
+			if target.Type == "array" && !*target.UniqueItems {
+				*target.UniqueItems = true
+			}
+		case "oneof":
+			tv.enum(name, arg, target)
+		default:
+			if format, ok := validatorFormats[key]; ok && target.Type == "string" {
+				tv.setString(name, "format", format, target.Format)
+			}
+			if pattern, ok := validatorPatterns[key]; ok && target.Type == "string" {
+				tv.setString(name, "pattern", pattern, target.Pattern)
+			}
+		}
+	}
+}
+
+func (tv *tagValidations) conflict(name, validation string, tagValue, commentValue interface{}) {
+	tv.conflicts = append(tv.conflicts, fmt.Sprintf("%s: the %s tag sets %s to %v but the comments set it to %v", tv.field, name, validation, tagValue, commentValue))
+}
+
+func (tv *tagValidations) setRequired(name string, target *validationTarget) {
+	if target.SetRequired == nil {
+		return
+	}
+	if tv.required != nil {
+		if !*tv.required {
+			tv.conflict(name, "required", true, false)
+		}
+		return
+	}
+	target.SetRequired()
+}
+
+func (tv *tagValidations) setString(name, validation, value string, tgt *string) {
+	if *tgt == "" {
+		*tgt = value
+		return
+	}
+	if *tgt != value {
+		tv.conflict(name, validation, value, *tgt)
+	}
+}
+
+func (tv *tagValidations) setFloat(name, validation string, value float64, exclusive bool, tgt **float64, excl *bool) {
+	if *tgt == nil {
+		*tgt = &value
+		*excl = exclusive
+		return
+	}
+	if **tgt != value || *excl != exclusive {
+		tv.conflict(name, validation, value, **tgt)
+	}
+}
+
+func (tv *tagValidations) setInt(name, validation string, value int64, tgt **int64) {
+	if *tgt == nil {
+		*tgt = &value
+		return
+	}
+	if **tgt != value {
+		tv.conflict(name, validation, value, **tgt)
+	}
+}
+
+// lowerBound is a minimum for numbers and a minimum length for strings and arrays
+func (tv *tagValidations) lowerBound(name, arg string, target *validationTarget, exclusive bool) {
+	switch target.Type {
+	case "number", "integer":
+		if val, err := strconv.ParseFloat(arg, 64); err == nil {
+			tv.setFloat(name, "minimum", val, exclusive, target.Minimum, target.ExclusiveMinimum)
+		}
+	case "string", "array":
+		val, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return
+		}
+		if exclusive {
+			val++
+		}
+		if target.Type == "string" {
+			tv.setInt(name, "minLength", val, target.MinLength)
+		} else {
+			tv.setInt(name, "minItems", val, target.MinItems)
+		}
+	}
+}
+
+// upperBound is a maximum for numbers and a maximum length for strings and arrays
+func (tv *tagValidations) upperBound(name, arg string, target *validationTarget, exclusive bool) {
+	switch target.Type {
+	case "number", "integer":
+		if val, err := strconv.ParseFloat(arg, 64); err == nil {
+			tv.setFloat(name, "maximum", val, exclusive, target.Maximum, target.ExclusiveMaximum)
+		}
+	case "string", "array":
+		val, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return
+		}
+		if exclusive {
+			val--
+		}
+		if target.Type == "string" {
+			tv.setInt(name, "maxLength", val, target.MaxLength)
+		} else {
+			tv.setInt(name, "maxItems", val, target.MaxItems)
+		}
+	}
+}
+
+func (tv *tagValidations) enum(name, arg string, target *validationTarget) {
+	tpe := valueType{Type: target.Type, Format: *target.Format}
+	var values []interface{}
+	for _, raw := range strings.Fields(arg) {
+		value, err := parseValue(tpe, raw)
+		if err != nil {
+			return
+		}
+		values = append(values, value)
+	}
+	if len(*target.Enum) == 0 {
+		*target.Enum = values
+		return
+	}
+	if !reflect.DeepEqual(*target.Enum, values) {
+		tv.conflict(name, "enum", values, *target.Enum)
+	}
+}
+
+// applyTagValidations applies the validations from the struct tags of the field when the parser is configured to read them
+func (scp *schemaParser) applyTagValidations(fld *ast.Field, target *validationTarget) error {
+	if len(scp.validationTags) == 0 {
+		return nil
+	}
+	name := fmt.Sprintf("%s %s", scp.program.Fset.Position(fld.Pos()), fld.Names[0].Name)
+	tv, err := newTagValidations(name, fld)
+	if err != nil {
+		return err
+	}
+	tv.Apply(scp.validationTags, target)
+	for _, conflict := range tv.conflicts {
+		scp.warnf("%s", conflict)
+	}
+	return nil
+}
+
+func (scp *schemaParser) warnf(format string, args ...interface{}) {
+	if scp.warn != nil {
+		scp.warn(fmt.Sprintf(format, args...))
+		return
+	}
+	log.Printf("warning: "+format, args...)
+}
@@ -0,0 +1,123 @@
+package scan
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"strings"
+
+	"github.com/vikstrous/go-swagger/swag"
+)
+
+// TypeMapping the swagger type and format a go type becomes.
+// A strfmt name makes it a string with that format, a mapping without a type allows any value.
+type TypeMapping struct {
+	Type   string `json:"type,omitempty"`
+	Format string `json:"format,omitempty"`
+	Strfmt string `json:"strfmt,omitempty"`
+}
+
+// defaultTypeMappings the types of the standard library and of well known packages that can't be annotated,
+// they're keyed by the import path and the name of the type
+var defaultTypeMappings = map[string]TypeMapping{
+	"time.Time":                      {Strfmt: "date-time"},
+	"math/big.Int":                   {Type: "integer"},
+	"math/big.Float":                 {Type: "string", Format: "decimal"},
+	"encoding/json.RawMessage":       {},
+	"github.com/google/uuid.UUID":    {Strfmt: "uuid"},
+	"github.com/pborman/uuid.UUID":   {Strfmt: "uuid"},
+	"github.com/satori/go.uuid.UUID": {Strfmt: "uuid"},
+	"github.com/gofrs/uuid.UUID":     {Strfmt: "uuid"},
+}
+
+func (tm TypeMapping) validate() error {
+	switch tm.Type {
+	case "", "string", "number", "integer", "boolean", "array", "object":
+	default:
+		return fmt.Errorf("%q is not a swagger type", tm.Type)
+	}
+	if tm.Strfmt != "" && tm.Type != "" && tm.Type != "string" {
+		return fmt.Errorf("a strfmt is a string, it can't have type %q", tm.Type)
+	}
+	return nil
+}
+
+func (tm TypeMapping) apply(prop swaggerTypable) {
+	switch {
+	case tm.Strfmt != "":
+		prop.Typed("string", tm.Strfmt)
+	case tm.Type != "":
+		prop.Typed(tm.Type, tm.Format)
+	}
+}
+
+// LoadTypeMappings reads the mappings from go types to swagger types from a yaml or json file.
+// The types are keyed by their import path and name:
+//
+//	types:
+//	  github.com/shopspring/decimal.Decimal:
+//	    type: string
+//	    format: decimal
+//	  github.com/jackc/pgtype.UUID:
+//	    strfmt: uuid
+func LoadTypeMappings(path string) (map[string]TypeMapping, error) {
+	var data json.RawMessage
+	var err error
+	if strings.HasSuffix(path, ".json") {
+		data, err = swag.JSONDoc(path)
+	} else {
+		data, err = swag.YAMLDoc(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg struct {
+		Types map[string]TypeMapping `json:"types"`
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	for name, tm := range cfg.Types {
+		if !strings.Contains(name, ".") {
+			return nil, fmt.Errorf("type mapping %s: the type needs the import path of its package, like time.Time", name)
+		}
+		if err := tm.validate(); err != nil {
+			return nil, fmt.Errorf("type mapping %s: %v", name, err)
+		}
+	}
+	return cfg.Types, nil
+}
+
+// typeMapping finds the mapping for a type of a package, the mappings of the options take precedence over the defaults.
+// Vendored packages use the mappings of the package they're a copy of.
+func (scp *schemaParser) typeMapping(pkgPath, name string) (TypeMapping, bool) {
+	if idx := strings.LastIndex(pkgPath, "/vendor/"); idx >= 0 {
+		pkgPath = pkgPath[idx+len("/vendor/"):]
+	}
+	key := pkgPath + "." + name
+	if tm, ok := scp.typeMappings[key]; ok {
+		return tm, true
+	}
+	tm, ok := defaultTypeMappings[key]
+	return tm, ok
+}
+
+// swaggerTypeAnnotation reads swagger:type [type] [format] from the doc comments of a type declaration
+func swaggerTypeAnnotation(comments *ast.CommentGroup) (TypeMapping, bool, error) {
+	if comments != nil {
+		for _, cmt := range comments.List {
+			for _, ln := range strings.Split(cmt.Text, "\n") {
+				matches := rxSwaggerType.FindStringSubmatch(ln)
+				if len(matches) > 2 {
+					tm := TypeMapping{Type: matches[1], Format: matches[2]}
+					if err := tm.validate(); err != nil {
+						return tm, true, fmt.Errorf("swagger:type: %v", err)
+					}
+					return tm, true, nil
+				}
+			}
+		}
+	}
+	return TypeMapping{}, false, nil
+}
@@ -1,6 +1,8 @@
 package scan
 
 import (
+	"encoding/json"
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
@@ -23,6 +25,28 @@ type validationBuilder interface {
 	SetUnique(bool)
 }
 
+// typableValidations the validations of the value that a typable sets the type of
+func typableValidations(prop swaggerTypable) validationBuilder {
+	switch tp := prop.(type) {
+	case paramTypable:
+		return paramValidations{tp.param}
+	case itemsTypable:
+		return itemsValidations{tp.items}
+	case schemaTypable:
+		return schemaValidations{tp.schema}
+	case responseTypable:
+		return headerValidations{tp.header}
+	}
+	return nil
+}
+
+// setUnsigned gives the value of an unsigned go type a minimum of 0
+func setUnsigned(prop swaggerTypable) {
+	if v := typableValidations(prop); v != nil {
+		v.SetMinimum(0, false)
+	}
+}
+
 type valueParser interface {
 	Parse([]string) error
 	Matches(string) bool
@@ -583,3 +607,205 @@ func (ss *setOpResponses) Parse(lines []string) error {
 	ss.set(def, scr)
 	return nil
 }
+
+// valueType the declared type a default or an example is checked against
+type valueType struct {
+	Type   string
+	Format string
+	Items  *valueType
+}
+
+func schemaValueType(schema *spec.Schema) valueType {
+	var vt valueType
+	if len(schema.Type) > 0 {
+		vt.Type = schema.Type[0]
+	}
+	vt.Format = schema.Format
+	if schema.Items != nil && schema.Items.Schema != nil {
+		items := schemaValueType(schema.Items.Schema)
+		vt.Items = &items
+	}
+	return vt
+}
+
+func simpleValueType(tpe, format string, items *spec.Items) valueType {
+	vt := valueType{Type: tpe, Format: format}
+	if items != nil {
+		it := simpleValueType(items.Type, items.Format, items.Items)
+		vt.Items = &it
+	}
+	return vt
+}
+
+func newSetDefault(tpe valueType, set func(interface{})) *setValue {
+	return &setValue{name: "default", tpe: tpe, set: set, rx: rxDefault}
+}
+
+func newSetExample(tpe valueType, set func(interface{})) *setValue {
+	return &setValue{name: "example", tpe: tpe, set: set, rx: rxExample}
+}
+
+// setValue parses a default or an example, the value has to be valid for the declared type.
+// Objects and arrays are written as json, arrays of primitives can be comma separated too.
+type setValue struct {
+	name string
+	tpe  valueType
+	set  func(interface{})
+	rx   *regexp.Regexp
+}
+
+func (sv *setValue) Matches(line string) bool {
+	return sv.rx.MatchString(line)
+}
+
+func (sv *setValue) Parse(lines []string) error {
+	if len(lines) == 0 || (len(lines) == 1 && len(lines[0]) == 0) {
+		return nil
+	}
+	matches := sv.rx.FindStringSubmatch(lines[0])
+	if len(matches) > 1 && len(strings.TrimSpace(matches[1])) > 0 {
+		value, err := parseValue(sv.tpe, strings.TrimSpace(matches[1]))
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %v", sv.name, matches[1], err)
+		}
+		sv.set(value)
+	}
+	return nil
+}
+
+func parseValue(tpe valueType, raw string) (interface{}, error) {
+	switch tpe.Type {
+	case "string":
+		if strings.HasPrefix(raw, `"`) {
+			var str string
+			if err := json.Unmarshal([]byte(raw), &str); err != nil {
+				return nil, err
+			}
+			return str, nil
+		}
+		return raw, nil
+	case "boolean":
+		return strconv.ParseBool(raw)
+	case "number", "integer":
+		return parseNumber(tpe, raw)
+	case "array":
+		if strings.HasPrefix(raw, "[") {
+			value, err := decodeJSONValue(raw)
+			if err != nil {
+				return nil, err
+			}
+			return checkValue(tpe, value)
+		}
+		var values []interface{}
+		for _, part := range strings.Split(raw, ",") {
+			var itemType valueType
+			if tpe.Items != nil {
+				itemType = *tpe.Items
+			}
+			value, err := parseValue(itemType, strings.TrimSpace(part))
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	case "object", "":
+		value, err := decodeJSONValue(raw)
+		if err != nil {
+			return nil, err
+		}
+		return checkValue(tpe, value)
+	}
+	return nil, fmt.Errorf("a value of type %s is not supported", tpe.Type)
+}
+
+func decodeJSONValue(raw string) (interface{}, error) {
+	var value interface{}
+	dec := json.NewDecoder(strings.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("expected a single json value")
+	}
+	return value, nil
+}
+
+func isIntegerFormat(format string) bool {
+	switch format {
+	case "int8", "int16", "int32", "int64", "uint8", "uint16", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+func parseNumber(tpe valueType, raw string) (interface{}, error) {
+	if tpe.Type == "integer" || isIntegerFormat(tpe.Format) {
+		if strings.HasPrefix(tpe.Format, "uint") {
+			return strconv.ParseUint(raw, 10, 64)
+		}
+		return strconv.ParseInt(raw, 10, 64)
+	}
+	return strconv.ParseFloat(raw, 64)
+}
+
+// checkValue checks a decoded json value against the declared type, the numbers become int64 or float64
+func checkValue(tpe valueType, value interface{}) (interface{}, error) {
+	switch tpe.Type {
+	case "":
+		return normalizeValue(value), nil
+	case "string":
+		if str, ok := value.(string); ok {
+			return str, nil
+		}
+	case "boolean":
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+	case "number", "integer":
+		if num, ok := value.(json.Number); ok {
+			return parseNumber(tpe, num.String())
+		}
+	case "array":
+		if values, ok := value.([]interface{}); ok {
+			var itemType valueType
+			if tpe.Items != nil {
+				itemType = *tpe.Items
+			}
+			for i, v := range values {
+				item, err := checkValue(itemType, v)
+				if err != nil {
+					return nil, err
+				}
+				values[i] = item
+			}
+			return values, nil
+		}
+	case "object":
+		if obj, ok := value.(map[string]interface{}); ok {
+			return normalizeValue(obj), nil
+		}
+	}
+	return nil, fmt.Errorf("%v is not a valid %s", value, tpe.Type)
+}
+
+func normalizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeValue(item)
+		}
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = normalizeValue(item)
+		}
+	}
+	return value
+}
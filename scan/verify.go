@@ -0,0 +1,292 @@
+package scan
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vikstrous/go-swagger/errors"
+	"github.com/vikstrous/go-swagger/spec"
+)
+
+// Verification the differences between the spec that is scanned from the code of a server
+// and the spec document the server implements.
+// The sections list what only one of the two has, the mismatches are the parameters they declare differently.
+type Verification struct {
+	Operations  *errors.APIVerificationFailed `json:"operations,omitempty"`
+	Parameters  *errors.APIVerificationFailed `json:"parameters,omitempty"`
+	Definitions *errors.APIVerificationFailed `json:"definitions,omitempty"`
+	Mismatches  []string                      `json:"mismatches,omitempty"`
+}
+
+// Failed is true when the code and the spec differ
+func (v *Verification) Failed() bool {
+	return len(v.Errors()) > 0
+}
+
+// Errors the differences as errors, one for each section and one for the mismatches
+func (v *Verification) Errors() []error {
+	var result []error
+	for _, section := range []*errors.APIVerificationFailed{v.Operations, v.Parameters, v.Definitions} {
+		if section != nil {
+			result = append(result, section)
+		}
+	}
+	if len(v.Mismatches) > 0 {
+		result = append(result, fmt.Errorf("mismatched parameters:\n%s", strings.Join(v.Mismatches, "\n")))
+	}
+	return result
+}
+
+// Verify scans the code of a server and compares it with the spec document it implements.
+//
+// The operations of the code are the annotated routes, the routes registered with a router,
+// the operation ids registered with an untyped api or in the handlers of a generated server,
+// and the operation ids of swagger:parameters.
+// An operation of the spec is found in the code when its id or its method and path are there,
+// the paths routers register can include the base path of the spec.
+// The parameters of each operation are compared by location and name, their types and whether they're required have to match.
+// The parameters of a generated server are read from the BindRequest methods of its params types.
+// The definitions are the types annotated with swagger:model and the types the operations use.
+//
+// The input of the options is ignored, the code is scanned on its own.
+func Verify(opts *Opts, reference *spec.Swagger) (*Verification, error) {
+	o := *opts
+	o.Input = nil
+	o.CacheDir = ""
+	o.ScanModels = true
+	o.DiscoverRoutes = true
+	if o.Warn == nil {
+		// the differences with the spec are reported by the verification
+		o.Warn = func(string) {}
+	}
+	parser, err := newAppScanner(&o)
+	if err != nil {
+		return nil, err
+	}
+	scanned, err := parser.Parse()
+	if err != nil {
+		return nil, err
+	}
+	if err := parser.parseGeneratedParameters(); err != nil {
+		return nil, err
+	}
+	return parser.verify(scanned, reference), nil
+}
+
+func (a *appScanner) verify(scanned, reference *spec.Swagger) *Verification {
+	result := new(Verification)
+	refRoutes := annotatedRoutes(reference.Paths)
+	codeRoutes := annotatedRoutes(scanned.Paths)
+
+	refIDs := make(map[string]bool)
+	for _, route := range refRoutes {
+		if route.Operation.ID != "" {
+			refIDs[route.Operation.ID] = true
+		}
+	}
+	codeIDs := make(map[string]bool)
+	for id := range a.operations {
+		codeIDs[id] = true
+	}
+	for _, reg := range a.registrations {
+		if reg.OperationID != "" {
+			codeIDs[reg.OperationID] = true
+		}
+	}
+
+	operations := &errors.APIVerificationFailed{Section: "operations"}
+	parameters := &errors.APIVerificationFailed{Section: "parameters"}
+	for _, route := range refRoutes {
+		op, found := a.codeOperation(route, codeRoutes, reference.BasePath)
+		if !found {
+			operations.MissingRegistration = append(operations.MissingRegistration, routeName(route))
+			continue
+		}
+		var codeParams []spec.Parameter
+		if op != nil {
+			codeParams = op.Parameters
+		}
+		a.compareParameters(result, parameters, operationName(route), referenceParameters(reference, route), codeParams)
+	}
+
+	for _, route := range codeRoutes {
+		if refIDs[route.Operation.ID] {
+			continue
+		}
+		matched := false
+		for _, refRoute := range refRoutes {
+			if refRoute.Method == route.Method && refRoute.Path == relativePath(route.Path, reference.BasePath) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			operations.MissingSpecification = append(operations.MissingSpecification, routeName(route))
+		}
+		delete(codeIDs, route.Operation.ID)
+	}
+	for id := range codeIDs {
+		if !refIDs[id] {
+			operations.MissingSpecification = append(operations.MissingSpecification, id)
+		}
+	}
+
+	definitions := &errors.APIVerificationFailed{Section: "definitions"}
+	for name := range reference.Definitions {
+		if _, ok := scanned.Definitions[name]; !ok {
+			definitions.MissingRegistration = append(definitions.MissingRegistration, name)
+		}
+	}
+	for name := range scanned.Definitions {
+		if _, ok := reference.Definitions[name]; !ok {
+			definitions.MissingSpecification = append(definitions.MissingSpecification, name)
+		}
+	}
+
+	result.Operations = verificationSection(operations)
+	result.Parameters = verificationSection(parameters)
+	result.Definitions = verificationSection(definitions)
+	return result
+}
+
+// codeOperation finds the operation of the code for an operation of the spec,
+// the operation is nil when the code only registers it
+func (a *appScanner) codeOperation(route annotatedRoute, codeRoutes []annotatedRoute, basePath string) (*spec.Operation, bool) {
+	if op, ok := a.operations[route.Operation.ID]; ok {
+		return op, true
+	}
+	for _, codeRoute := range codeRoutes {
+		if codeRoute.Method == route.Method && relativePath(codeRoute.Path, basePath) == route.Path {
+			return codeRoute.Operation, true
+		}
+	}
+	for _, reg := range a.registrations {
+		if reg.matches(route, basePath) {
+			return nil, true
+		}
+	}
+	return nil, false
+}
+
+// compareParameters compares the parameters of an operation by location and name
+func (a *appScanner) compareParameters(result *Verification, section *errors.APIVerificationFailed, opName string, refParams, codeParams []spec.Parameter) {
+	code := make(map[string]spec.Parameter, len(codeParams))
+	for _, p := range codeParams {
+		code[p.In+" "+p.Name] = p
+	}
+	ref := make(map[string]bool, len(refParams))
+	for _, rp := range refParams {
+		key := rp.In + " " + rp.Name
+		ref[key] = true
+		cp, ok := code[key]
+		if !ok {
+			section.MissingRegistration = append(section.MissingRegistration, opName+" "+key)
+			continue
+		}
+		if rt, ct := parameterType(&rp), parameterType(&cp); rt != ct {
+			result.Mismatches = append(result.Mismatches, fmt.Sprintf("%s %s: the type is %s in the spec and %s in the code", opName, key, rt, ct))
+		}
+		if rp.Required != cp.Required {
+			result.Mismatches = append(result.Mismatches, fmt.Sprintf("%s %s: required is %t in the spec and %t in the code", opName, key, rp.Required, cp.Required))
+		}
+	}
+	for _, cp := range codeParams {
+		if key := cp.In + " " + cp.Name; !ref[key] {
+			section.MissingSpecification = append(section.MissingSpecification, opName+" "+key)
+		}
+	}
+}
+
+// referenceParameters the parameters of an operation of the spec, including the ones of its path.
+// References to the parameters of the spec are resolved, the parameters of the operation override the ones of the path.
+func referenceParameters(reference *spec.Swagger, route annotatedRoute) []spec.Parameter {
+	var params []spec.Parameter
+	add := func(p spec.Parameter) {
+		if p.Ref.String() != "" {
+			name := strings.TrimPrefix(p.Ref.String(), "#/parameters/")
+			if resolved, ok := reference.Parameters[name]; ok {
+				p = resolved
+			}
+		}
+		for i, existing := range params {
+			if existing.In == p.In && existing.Name == p.Name {
+				params[i] = p
+				return
+			}
+		}
+		params = append(params, p)
+	}
+	for _, p := range reference.Paths.Paths[route.Path].Parameters {
+		add(p)
+	}
+	for _, p := range route.Operation.Parameters {
+		add(p)
+	}
+	return params
+}
+
+// parameterType describes the type of a parameter, like integer(int32), array of string or the definition a body refers to
+func parameterType(p *spec.Parameter) string {
+	if p.In == "body" {
+		return schemaType(p.Schema)
+	}
+	if p.Type == "array" && p.Items != nil {
+		return "array of " + itemsType(p.Items)
+	}
+	return typeName(p.Type, p.Format)
+}
+
+func itemsType(items *spec.Items) string {
+	if items.Type == "array" && items.Items != nil {
+		return "array of " + itemsType(items.Items)
+	}
+	return typeName(items.Type, items.Format)
+}
+
+func schemaType(schema *spec.Schema) string {
+	if schema == nil {
+		return "any"
+	}
+	if ref := schema.Ref.String(); ref != "" {
+		return strings.TrimPrefix(ref, "#/definitions/")
+	}
+	if schema.Type.Contains("array") && schema.Items != nil && schema.Items.Schema != nil {
+		return "array of " + schemaType(schema.Items.Schema)
+	}
+	return typeName(strings.Join(schema.Type, ","), schema.Format)
+}
+
+func typeName(tpe, format string) string {
+	if tpe == "" {
+		tpe = "any"
+	}
+	if format != "" {
+		return tpe + "(" + format + ")"
+	}
+	return tpe
+}
+
+func routeName(route annotatedRoute) string {
+	if route.Operation.ID == "" {
+		return route.Method + " " + route.Path
+	}
+	return fmt.Sprintf("%s (%s %s)", route.Operation.ID, route.Method, route.Path)
+}
+
+func operationName(route annotatedRoute) string {
+	if route.Operation.ID == "" {
+		return route.Method + " " + route.Path
+	}
+	return route.Operation.ID
+}
+
+// verificationSection sorts the section, it's nil when nothing is missing
+func verificationSection(section *errors.APIVerificationFailed) *errors.APIVerificationFailed {
+	if len(section.MissingRegistration) == 0 && len(section.MissingSpecification) == 0 {
+		return nil
+	}
+	sort.Strings(section.MissingRegistration)
+	sort.Strings(section.MissingSpecification)
+	return section
+}
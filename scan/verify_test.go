@@ -0,0 +1,108 @@
+package scan
+
+import (
+	"testing"
+
+	"github.com/vikstrous/go-swagger/spec"
+	"github.com/stretchr/testify/assert"
+)
+
+const generatedServer = "../examples/generated/restapi/operations"
+
+func TestVerify(t *testing.T) {
+	reference, err := Run(&Opts{BasePath: petstoreFixture, ScanModels: true})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// the spec of the code matches the code
+	verification, err := Verify(&Opts{BasePath: petstoreFixture}, reference)
+	if assert.NoError(t, err) {
+		assert.False(t, verification.Failed())
+		assert.Empty(t, verification.Errors())
+	}
+
+	// an operation the code doesn't have and an operation the spec doesn't have
+	users := reference.Paths.Paths["/users/{id}"]
+	users.Get = new(spec.Operation)
+	users.Get.ID = "getUser"
+	reference.Paths.Paths["/users/{id}"] = users
+	pets := reference.Paths.Paths["/pets/{id}"]
+	pets.Delete = nil
+	// a parameter with another type, a required parameter the code doesn't have and one the spec doesn't have
+	pets.Get.Parameters[0].Type = "integer"
+	pets.Get.Parameters[0].Format = "int32"
+	pets.Get.Parameters = append(pets.Get.Parameters, *spec.QueryParam("verbose").Typed("boolean", "").AsRequired())
+	reference.Paths.Paths["/pets/{id}"] = pets
+	list := reference.Paths.Paths["/pets"]
+	list.Get.Parameters = nil
+	reference.Paths.Paths["/pets"] = list
+	// a model the code doesn't have and one the spec doesn't have
+	reference.Definitions["category"] = *spec.StringProperty()
+	delete(reference.Definitions, "user")
+
+	verification, err = Verify(&Opts{BasePath: petstoreFixture}, reference)
+	if assert.NoError(t, err) {
+		assert.True(t, verification.Failed())
+		assert.Len(t, verification.Errors(), 4)
+
+		if assert.NotNil(t, verification.Operations) {
+			assert.Equal(t, []string{"getUser (GET /users/{id})"}, verification.Operations.MissingRegistration)
+			assert.Equal(t, []string{"deletePet (DELETE /pets/{id})"}, verification.Operations.MissingSpecification)
+		}
+		if assert.NotNil(t, verification.Parameters) {
+			assert.Equal(t, []string{"getPetById query verbose"}, verification.Parameters.MissingRegistration)
+			assert.Equal(t, []string{"listPets query status"}, verification.Parameters.MissingSpecification)
+		}
+		if assert.NotNil(t, verification.Definitions) {
+			assert.Equal(t, []string{"category"}, verification.Definitions.MissingRegistration)
+			assert.Equal(t, []string{"user"}, verification.Definitions.MissingSpecification)
+		}
+		if assert.Len(t, verification.Mismatches, 1) {
+			assert.Contains(t, verification.Mismatches[0], "getPetById path id: the type is integer(int32) in the spec")
+		}
+	}
+}
+
+func TestVerify_GeneratedServer(t *testing.T) {
+	doc, err := spec.Load("../examples/generated/swagger.json")
+	if !assert.NoError(t, err) {
+		return
+	}
+	reference := doc.Spec()
+
+	// the operations are registered in the handlers of the api and the params are read from their binders
+	verification, err := Verify(&Opts{BasePath: generatedServer}, reference)
+	if assert.NoError(t, err) {
+		assert.Empty(t, verification.Errors())
+	}
+
+	item := reference.Paths.Paths["/pets/{petId}"]
+	item.Get.Parameters[0].Format = "int32"
+	item.Delete.Parameters[0].Name = "apiKey"
+	reference.Paths.Paths["/pets/{petId}"] = item
+
+	verification, err = Verify(&Opts{BasePath: generatedServer}, reference)
+	if assert.NoError(t, err) {
+		if assert.NotNil(t, verification.Parameters) {
+			assert.Equal(t, []string{"deletePet header apiKey"}, verification.Parameters.MissingRegistration)
+			assert.Equal(t, []string{"deletePet header api_key"}, verification.Parameters.MissingSpecification)
+		}
+		if assert.Len(t, verification.Mismatches, 1) {
+			assert.Contains(t, verification.Mismatches[0], "getPetById path petId: the type is integer(int32) in the spec and integer(int64) in the code")
+		}
+	}
+}
+
+func TestRouteRegistrationBasePath(t *testing.T) {
+	route := annotatedRoute{Method: "GET", Path: "/pets/{id}", Operation: new(spec.Operation)}
+	reg := &routeRegistration{Methods: []string{"GET"}, Path: "/v2/pets/{id}"}
+	assert.True(t, reg.matches(route, "/v2"))
+	assert.True(t, reg.matches(route, "/v2/"))
+	assert.False(t, reg.matches(route, "/"))
+	assert.False(t, reg.matches(route, "/v1"))
+
+	reg.Path = "/pets/{id}"
+	assert.True(t, reg.matches(route, "/v2"))
+	assert.True(t, reg.matches(route, ""))
+}
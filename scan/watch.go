@@ -0,0 +1,86 @@
+package scan
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vikstrous/go-swagger/spec"
+)
+
+// Watch scans the application and scans it again every time the go files of its packages change,
+// until stop is closed. The spec or the error of each scan is passed to fn, a failed scan doesn't stop the watching.
+// The packages that didn't change are taken from the cache, it's kept in memory between the scans
+// and in the cache dir of the options when there is one.
+func Watch(opts *Opts, interval time.Duration, stop <-chan struct{}, fn func(*spec.Swagger, error)) error {
+	cache, err := loadScanCache(opts)
+	if err != nil {
+		return err
+	}
+	// the scan adds to the input spec, so each scan starts from a copy of it
+	var input []byte
+	if opts.Input != nil {
+		if input, err = json.Marshal(opts.Input); err != nil {
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var last string
+	for first := true; ; first = false {
+		if !first {
+			select {
+			case <-stop:
+				return nil
+			case <-ticker.C:
+			}
+		}
+
+		current := fingerprint(cache.sources)
+		if !first && current != "" && current == last {
+			continue
+		}
+		o := *opts
+		if input != nil {
+			o.Input = new(spec.Swagger)
+			if err := json.Unmarshal(input, o.Input); err != nil {
+				return err
+			}
+		}
+		fn(run(&o, cache))
+		// the packages can be different after the scan
+		last = fingerprint(cache.sources)
+	}
+}
+
+// fingerprint the names, sizes and modification times of the go files in the directories of the packages,
+// it's empty when there are no packages yet
+func fingerprint(sources map[string]*packageSource) string {
+	if len(sources) == 0 {
+		return ""
+	}
+	dirs := make([]string, 0, len(sources))
+	for dir := range sources {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	var fp []string
+	for _, dir := range dirs {
+		fis, err := ioutil.ReadDir(dir)
+		if err != nil {
+			fp = append(fp, dir+": "+err.Error())
+			continue
+		}
+		for _, fi := range fis {
+			if !fi.IsDir() && strings.HasSuffix(fi.Name(), ".go") {
+				fp = append(fp, fmt.Sprintf("%s/%s %d %d", dir, fi.Name(), fi.Size(), fi.ModTime().UnixNano()))
+			}
+		}
+	}
+	return strings.Join(fp, "\n")
+}
@@ -0,0 +1,499 @@
+package spec
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ChangeKind describes what happened to an element of the spec
+type ChangeKind string
+
+const (
+	// ChangeAdded an element was added to the spec
+	ChangeAdded ChangeKind = "added"
+	// ChangeRemoved an element was removed from the spec
+	ChangeRemoved ChangeKind = "removed"
+	// ChangeModified an element was modified in the spec
+	ChangeModified ChangeKind = "changed"
+)
+
+// SpecChange describes a single semantic difference between 2 versions of a spec
+type SpecChange struct {
+	Kind     ChangeKind `json:"kind"`
+	Location string     `json:"location"`
+	Message  string     `json:"message"`
+	Breaking bool       `json:"breaking"`
+}
+
+func (c SpecChange) String() string {
+	compat := "non-breaking"
+	if c.Breaking {
+		compat = "breaking"
+	}
+	return fmt.Sprintf("[%s] %s: %s", compat, c.Location, c.Message)
+}
+
+// SpecDifferences the list of semantic differences between 2 versions of a spec
+type SpecDifferences []SpecChange
+
+// Breaking returns the changes that will break existing clients
+func (d SpecDifferences) Breaking() SpecDifferences {
+	var result SpecDifferences
+	for _, c := range d {
+		if c.Breaking {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// HasBreakingChanges returns true when at least one of the changes is breaking
+func (d SpecDifferences) HasBreakingChanges() bool {
+	return len(d.Breaking()) > 0
+}
+
+func (d SpecDifferences) Len() int      { return len(d) }
+func (d SpecDifferences) Swap(i, j int) { d[i], d[j] = d[j], d[i] }
+func (d SpecDifferences) Less(i, j int) bool {
+	if d[i].Location == d[j].Location {
+		return d[i].Message < d[j].Message
+	}
+	return d[i].Location < d[j].Location
+}
+
+// the direction data flows in, determines if a change breaks clients
+type flow int
+
+const (
+	requestFlow flow = iota
+	responseFlow
+	definitionFlow
+)
+
+// breaks is true when a change that narrows or widens the accepted values breaks clients:
+// clients send request data and read response data, a definition can be used for both
+func (fl flow) breaks(narrowed bool) bool {
+	if fl == definitionFlow {
+		return true
+	}
+	return narrowed == (fl == requestFlow)
+}
+
+type differ struct {
+	left    *Document
+	right   *Document
+	changes SpecDifferences
+}
+
+// Diff compares 2 versions of a spec document and classifies each difference
+// as a breaking or non-breaking change for existing clients of the API.
+//
+// Endpoints, parameters, media types, response codes, response headers and
+// the properties of schemas are compared. References are not followed, a
+// reference that points to another model is reported as a type change.
+func Diff(left, right *Document) SpecDifferences {
+	d := &differ{left: left, right: right}
+	d.compareOperations()
+	d.compareDefinitions()
+	sort.Sort(d.changes)
+	return d.changes
+}
+
+func (d *differ) add(kind ChangeKind, breaking bool, location, message string, args ...interface{}) {
+	d.changes = append(d.changes, SpecChange{
+		Kind:     kind,
+		Location: location,
+		Message:  fmt.Sprintf(message, args...),
+		Breaking: breaking,
+	})
+}
+
+func (d *differ) compareOperations() {
+	leftOps, rightOps := d.left.Operations(), d.right.Operations()
+	for method, paths := range leftOps {
+		for path, op := range paths {
+			loc := method + " " + path
+			rop, ok := rightOps[method][path]
+			if !ok {
+				d.add(ChangeRemoved, true, loc, "endpoint was removed")
+				continue
+			}
+			d.compareOperation(loc, method, path, op, rop)
+		}
+	}
+	for method, paths := range rightOps {
+		for path := range paths {
+			if _, ok := leftOps[method][path]; !ok {
+				d.add(ChangeAdded, false, method+" "+path, "endpoint was added")
+			}
+		}
+	}
+}
+
+func (d *differ) compareOperation(loc, method, path string, left, right *Operation) {
+	if left.ID != right.ID {
+		d.add(ChangeModified, false, loc, "operation id changed from %q to %q", left.ID, right.ID)
+	}
+	if !left.Deprecated && right.Deprecated {
+		d.add(ChangeModified, false, loc, "endpoint was deprecated")
+	}
+
+	d.compareMediaTypes(loc, "consumes", d.left.ConsumesFor(left), d.right.ConsumesFor(right))
+	d.compareMediaTypes(loc, "produces", d.left.ProducesFor(left), d.right.ProducesFor(right))
+	d.compareParams(loc, d.left.ParamsFor(method, path), d.right.ParamsFor(method, path))
+	d.compareResponses(loc, left.Responses, right.Responses)
+}
+
+func (d *differ) compareMediaTypes(loc, name string, left, right []string) {
+	for _, mt := range left {
+		if !containsString(right, mt) {
+			d.add(ChangeRemoved, true, loc, "%s media type %q was removed", name, mt)
+		}
+	}
+	for _, mt := range right {
+		if !containsString(left, mt) {
+			d.add(ChangeAdded, false, loc, "%s media type %q was added", name, mt)
+		}
+	}
+}
+
+func paramsByLocation(params map[string]Parameter) map[string]Parameter {
+	result := make(map[string]Parameter, len(params))
+	for _, p := range params {
+		result[p.In+" "+p.Name] = p
+	}
+	return result
+}
+
+func (d *differ) compareParams(loc string, left, right map[string]Parameter) {
+	lp, rp := paramsByLocation(left), paramsByLocation(right)
+	for k, l := range lp {
+		ploc := loc + " param " + k
+		r, ok := rp[k]
+		if !ok {
+			d.add(ChangeRemoved, true, ploc, "parameter was removed")
+			continue
+		}
+		d.compareParam(ploc, &l, &r)
+	}
+	for k, r := range rp {
+		if _, ok := lp[k]; !ok {
+			if r.Required {
+				d.add(ChangeAdded, true, loc+" param "+k, "required parameter was added")
+				continue
+			}
+			d.add(ChangeAdded, false, loc+" param "+k, "optional parameter was added")
+		}
+	}
+}
+
+func (d *differ) compareParam(loc string, left, right *Parameter) {
+	if !left.Required && right.Required {
+		d.add(ChangeModified, true, loc, "parameter is now required")
+	}
+	if left.Required && !right.Required {
+		d.add(ChangeModified, false, loc, "parameter is now optional")
+	}
+	if left.In == "body" {
+		d.compareSchema(loc, left.Schema, right.Schema, requestFlow)
+		return
+	}
+	d.compareSimpleSchema(loc, &left.simpleSchema, &right.simpleSchema)
+	d.compareValidations(loc, &left.commonValidations, &right.commonValidations, requestFlow)
+}
+
+func (d *differ) compareSimpleSchema(loc string, left, right *simpleSchema) {
+	if left.Type != right.Type || left.Format != right.Format {
+		d.add(ChangeModified, true, loc, "type changed from %s to %s", simpleTypeName(left), simpleTypeName(right))
+		return
+	}
+	if left.CollectionFormat != right.CollectionFormat {
+		d.add(ChangeModified, true, loc, "collection format changed from %q to %q", left.CollectionFormat, right.CollectionFormat)
+	}
+	if left.Items != nil && right.Items != nil {
+		d.compareSimpleSchema(loc+" items", &left.Items.simpleSchema, &right.Items.simpleSchema)
+	}
+}
+
+func simpleTypeName(s *simpleSchema) string {
+	if s.Format != "" {
+		return s.Type + "(" + s.Format + ")"
+	}
+	return s.Type
+}
+
+func (d *differ) compareValidations(loc string, left, right *commonValidations, fl flow) {
+	narrowed := fl.breaks(true)
+	widened := fl.breaks(false)
+
+	if len(left.Enum) == 0 && len(right.Enum) > 0 {
+		d.add(ChangeModified, narrowed, loc, "values are now restricted to %v", right.Enum)
+	}
+	if len(left.Enum) > 0 && len(right.Enum) == 0 {
+		// clients that switch on the values of a response get values they don't know about
+		d.add(ChangeModified, widened, loc, "values are no longer restricted to %v", left.Enum)
+	}
+	removed, added := diffEnum(left.Enum, right.Enum)
+	if len(removed) > 0 {
+		d.add(ChangeModified, narrowed, loc, "enum values %v were removed", removed)
+	}
+	if len(added) > 0 {
+		d.add(ChangeModified, widened, loc, "enum values %v were added", added)
+	}
+
+	if left.Pattern != right.Pattern {
+		d.add(ChangeModified, fl != responseFlow && right.Pattern != "", loc, "pattern changed from %q to %q", left.Pattern, right.Pattern)
+	}
+	d.compareLimit(loc, "maximum", left.Maximum, right.Maximum, false, fl)
+	d.compareLimit(loc, "minimum", left.Minimum, right.Minimum, true, fl)
+	d.compareLimit(loc, "maxLength", intLimit(left.MaxLength), intLimit(right.MaxLength), false, fl)
+	d.compareLimit(loc, "minLength", intLimit(left.MinLength), intLimit(right.MinLength), true, fl)
+	d.compareLimit(loc, "maxItems", intLimit(left.MaxItems), intLimit(right.MaxItems), false, fl)
+	d.compareLimit(loc, "minItems", intLimit(left.MinItems), intLimit(right.MinItems), true, fl)
+}
+
+func intLimit(v *int64) *float64 {
+	if v == nil {
+		return nil
+	}
+	f := float64(*v)
+	return &f
+}
+
+// compareLimit reports a change to a boundary, a boundary that rejects values
+// which used to be accepted breaks clients that send data
+func (d *differ) compareLimit(loc, name string, left, right *float64, lower bool, fl flow) {
+	if left == nil && right == nil {
+		return
+	}
+	if left != nil && right != nil && *left == *right {
+		return
+	}
+	var tightened bool
+	switch {
+	case left == nil:
+		tightened = true
+	case right == nil:
+		tightened = false
+	case lower:
+		tightened = *right > *left
+	default:
+		tightened = *right < *left
+	}
+	d.add(ChangeModified, fl.breaks(tightened), loc, "%s changed from %s to %s", name, formatLimit(left), formatLimit(right))
+}
+
+func formatLimit(v *float64) string {
+	if v == nil {
+		return "none"
+	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
+}
+
+func diffEnum(left, right []interface{}) (removed, added []interface{}) {
+	if len(right) > 0 {
+		for _, l := range left {
+			if !containsValue(right, l) {
+				removed = append(removed, l)
+			}
+		}
+	}
+	if len(left) > 0 {
+		for _, r := range right {
+			if !containsValue(left, r) {
+				added = append(added, r)
+			}
+		}
+	}
+	return
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *differ) compareResponses(loc string, left, right *Responses) {
+	if left == nil {
+		left = new(Responses)
+	}
+	if right == nil {
+		right = new(Responses)
+	}
+	for code, l := range left.StatusCodeResponses {
+		rloc := loc + " response " + strconv.Itoa(code)
+		r, ok := right.StatusCodeResponses[code]
+		if !ok {
+			d.add(ChangeRemoved, true, rloc, "response was removed")
+			continue
+		}
+		d.compareResponse(rloc, &l, &r)
+	}
+	for code := range right.StatusCodeResponses {
+		if _, ok := left.StatusCodeResponses[code]; !ok {
+			d.add(ChangeAdded, false, loc+" response "+strconv.Itoa(code), "response was added")
+		}
+	}
+
+	switch {
+	case left.Default != nil && right.Default == nil:
+		d.add(ChangeRemoved, true, loc+" response default", "response was removed")
+	case left.Default == nil && right.Default != nil:
+		d.add(ChangeAdded, false, loc+" response default", "response was added")
+	case left.Default != nil && right.Default != nil:
+		d.compareResponse(loc+" response default", left.Default, right.Default)
+	}
+}
+
+func (d *differ) compareResponse(loc string, left, right *Response) {
+	if left.Ref.String() != right.Ref.String() {
+		d.add(ChangeModified, true, loc, "reference changed from %q to %q", left.Ref.String(), right.Ref.String())
+		return
+	}
+	d.compareSchema(loc, left.Schema, right.Schema, responseFlow)
+
+	for name := range left.Headers {
+		if _, ok := right.Headers[name]; !ok {
+			d.add(ChangeRemoved, true, loc+" header "+name, "response header was removed")
+		}
+	}
+	for name, r := range right.Headers {
+		l, ok := left.Headers[name]
+		if !ok {
+			d.add(ChangeAdded, false, loc+" header "+name, "response header was added")
+			continue
+		}
+		d.compareSimpleSchema(loc+" header "+name, &l.simpleSchema, &r.simpleSchema)
+	}
+}
+
+func (d *differ) compareDefinitions() {
+	left, right := d.left.Spec().Definitions, d.right.Spec().Definitions
+	for name, l := range left {
+		loc := "definitions " + name
+		r, ok := right[name]
+		if !ok {
+			d.add(ChangeRemoved, true, loc, "definition was removed")
+			continue
+		}
+		d.compareSchema(loc, &l, &r, definitionFlow)
+	}
+	for name := range right {
+		if _, ok := left[name]; !ok {
+			d.add(ChangeAdded, false, "definitions "+name, "definition was added")
+		}
+	}
+}
+
+func schemaTypeName(s *Schema) string {
+	if s.Ref.String() != "" {
+		return s.Ref.String()
+	}
+	nm := strings.Join(s.Type, ",")
+	if s.Format != "" {
+		nm += "(" + s.Format + ")"
+	}
+	if nm == "" {
+		return "any"
+	}
+	return nm
+}
+
+func (d *differ) compareSchema(loc string, left, right *Schema, fl flow) {
+	switch {
+	case left == nil && right == nil:
+		return
+	case left == nil:
+		d.add(ChangeAdded, fl == requestFlow, loc, "schema was added")
+		return
+	case right == nil:
+		d.add(ChangeRemoved, fl != requestFlow, loc, "schema was removed")
+		return
+	}
+
+	if schemaTypeName(left) != schemaTypeName(right) {
+		d.add(ChangeModified, true, loc, "type changed from %s to %s", schemaTypeName(left), schemaTypeName(right))
+		return
+	}
+	if left.Ref.String() != "" {
+		return
+	}
+
+	d.compareValidations(loc, schemaValidations(left), schemaValidations(right), fl)
+
+	if left.Items != nil && right.Items != nil {
+		d.compareSchema(loc+" items", left.Items.Schema, right.Items.Schema, fl)
+	}
+	if left.AdditionalProperties != nil && right.AdditionalProperties != nil {
+		d.compareSchema(loc+" additionalProperties", left.AdditionalProperties.Schema, right.AdditionalProperties.Schema, fl)
+	}
+	for i := range left.AllOf {
+		if i < len(right.AllOf) {
+			d.compareSchema(loc+" allOf "+strconv.Itoa(i), &left.AllOf[i], &right.AllOf[i], fl)
+		}
+	}
+	if len(left.AllOf) != len(right.AllOf) {
+		d.add(ChangeModified, true, loc, "composition changed from %d to %d schemas", len(left.AllOf), len(right.AllOf))
+	}
+
+	for name, l := range left.Properties {
+		ploc := loc + "." + name
+		r, ok := right.Properties[name]
+		if !ok {
+			d.add(ChangeRemoved, fl != requestFlow, ploc, "property was removed")
+			continue
+		}
+		d.compareSchema(ploc, &l, &r, fl)
+
+		wasRequired, isRequired := containsString(left.Required, name), containsString(right.Required, name)
+		if !wasRequired && isRequired {
+			d.add(ChangeModified, fl != responseFlow, ploc, "property is now required")
+		}
+		if wasRequired && !isRequired {
+			d.add(ChangeModified, fl != requestFlow, ploc, "property is now optional")
+		}
+	}
+	for name := range right.Properties {
+		if _, ok := left.Properties[name]; !ok {
+			required := containsString(right.Required, name)
+			if required {
+				d.add(ChangeAdded, fl != responseFlow, loc+"."+name, "required property was added")
+				continue
+			}
+			d.add(ChangeAdded, false, loc+"."+name, "optional property was added")
+		}
+	}
+}
+
+func schemaValidations(s *Schema) *commonValidations {
+	return &commonValidations{
+		Maximum:          s.Maximum,
+		ExclusiveMaximum: s.ExclusiveMaximum,
+		Minimum:          s.Minimum,
+		ExclusiveMinimum: s.ExclusiveMinimum,
+		MaxLength:        s.MaxLength,
+		MinLength:        s.MinLength,
+		Pattern:          s.Pattern,
+		MaxItems:         s.MaxItems,
+		MinItems:         s.MinItems,
+		UniqueItems:      s.UniqueItems,
+		MultipleOf:       s.MultipleOf,
+		Enum:             s.Enum,
+	}
+}
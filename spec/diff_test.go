@@ -0,0 +1,204 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func diffFixture(modify func(*Swagger)) *Document {
+	sw := petStoreBuilt()
+	if modify != nil {
+		modify(sw)
+	}
+	doc, err := NewDocument(sw)
+	if err != nil {
+		panic(err)
+	}
+	return doc
+}
+
+func findChange(changes SpecDifferences, location, message string) (SpecChange, bool) {
+	for _, c := range changes {
+		if c.Location == location && c.Message == message {
+			return c, true
+		}
+	}
+	return SpecChange{}, false
+}
+
+func TestDiff_NoChanges(t *testing.T) {
+	changes := Diff(diffFixture(nil), diffFixture(nil))
+	assert.Empty(t, changes)
+	assert.False(t, changes.HasBreakingChanges())
+}
+
+func TestDiff_Endpoints(t *testing.T) {
+	left := diffFixture(nil)
+	right := diffFixture(func(sw *Swagger) {
+		sw.RemoveOperation("GET", "/pets")
+		sw.AddOperation("DELETE", "/pets/{id}", NewOperation("deletePet").
+			AddParam(PathParam("id").Typed("integer", "int64")).
+			RespondsWith(204, NewResponse().WithDescription("deleted")))
+	})
+
+	changes := Diff(left, right)
+	c, ok := findChange(changes, "GET /pets", "endpoint was removed")
+	if assert.True(t, ok) {
+		assert.True(t, c.Breaking)
+		assert.Equal(t, ChangeRemoved, c.Kind)
+	}
+	c, ok = findChange(changes, "DELETE /pets/{id}", "endpoint was added")
+	if assert.True(t, ok) {
+		assert.False(t, c.Breaking)
+	}
+	assert.Len(t, changes.Breaking(), 1)
+}
+
+func TestDiff_Parameters(t *testing.T) {
+	left := diffFixture(func(sw *Swagger) {
+		sw.Paths.Paths["/pets"].Get.AddParam(QueryParam("status").Typed("string", "").WithEnum("available", "sold", "pending"))
+	})
+	right := diffFixture(func(sw *Swagger) {
+		sw.Paths.Paths["/pets"].Get.
+			AddParam(QueryParam("status").Typed("string", "").WithEnum("available", "sold", "adopted")).
+			AddParam(QueryParam("limit").Typed("integer", "int64")).
+			AddParam(QueryParam("owner").Typed("string", "").AsRequired()).
+			AddParam(QueryParam("tag").Typed("string", ""))
+	})
+
+	changes := Diff(left, right)
+	c, ok := findChange(changes, "GET /pets param query status", "enum values [pending] were removed")
+	if assert.True(t, ok) {
+		assert.True(t, c.Breaking)
+	}
+	c, ok = findChange(changes, "GET /pets param query status", "enum values [adopted] were added")
+	if assert.True(t, ok) {
+		assert.False(t, c.Breaking)
+	}
+	c, ok = findChange(changes, "GET /pets param query limit", "type changed from integer(int32) to integer(int64)")
+	if assert.True(t, ok) {
+		assert.True(t, c.Breaking)
+	}
+	c, ok = findChange(changes, "GET /pets param query owner", "required parameter was added")
+	if assert.True(t, ok) {
+		assert.True(t, c.Breaking)
+	}
+	c, ok = findChange(changes, "GET /pets param query tag", "optional parameter was added")
+	if assert.True(t, ok) {
+		assert.False(t, c.Breaking)
+	}
+}
+
+func TestDiff_ResponsesAndSchemas(t *testing.T) {
+	left := diffFixture(nil)
+	right := diffFixture(func(sw *Swagger) {
+		op := sw.Paths.Paths["/pets"].Get
+		op.RespondsWith(200, nil).
+			RespondsWith(201, NewResponse().WithDescription("the pets"))
+
+		pet := sw.Definitions["Pet"]
+		delete(pet.Properties, "categoryName")
+		pet.SetProperty("age", *Int32Property())
+		pet.SetProperty("name", *StringProperty().WithMaxLength(20))
+		sw.Definitions["Pet"] = pet
+	})
+
+	changes := Diff(left, right)
+	c, ok := findChange(changes, "GET /pets response 200", "response was removed")
+	if assert.True(t, ok) {
+		assert.True(t, c.Breaking)
+	}
+	c, ok = findChange(changes, "GET /pets response 201", "response was added")
+	if assert.True(t, ok) {
+		assert.False(t, c.Breaking)
+	}
+	c, ok = findChange(changes, "definitions Pet.categoryName", "property was removed")
+	if assert.True(t, ok) {
+		assert.True(t, c.Breaking)
+	}
+	c, ok = findChange(changes, "definitions Pet.age", "optional property was added")
+	if assert.True(t, ok) {
+		assert.False(t, c.Breaking)
+	}
+	c, ok = findChange(changes, "definitions Pet.name", "maxLength changed from none to 20")
+	if assert.True(t, ok) {
+		assert.True(t, c.Breaking)
+	}
+}
+
+func TestDiff_EnumRemoved(t *testing.T) {
+	left := diffFixture(func(sw *Swagger) {
+		sw.Paths.Paths["/pets"].Get.
+			AddParam(QueryParam("status").Typed("string", "").WithEnum("available", "sold")).
+			RespondsWith(202, NewResponse().WithDescription("the status").
+				WithSchema(StringProperty().WithEnum("queued", "running")))
+	})
+	right := diffFixture(func(sw *Swagger) {
+		sw.Paths.Paths["/pets"].Get.
+			AddParam(QueryParam("status").Typed("string", "")).
+			RespondsWith(202, NewResponse().WithDescription("the status").
+				WithSchema(StringProperty()))
+	})
+
+	changes := Diff(left, right)
+	c, ok := findChange(changes, "GET /pets param query status", "values are no longer restricted to [available sold]")
+	if assert.True(t, ok) {
+		assert.False(t, c.Breaking)
+	}
+	c, ok = findChange(changes, "GET /pets response 202", "values are no longer restricted to [queued running]")
+	if assert.True(t, ok) {
+		assert.True(t, c.Breaking)
+	}
+}
+
+func TestDiff_DefinitionEnums(t *testing.T) {
+	// Pet is both sent and returned, getPet responds with it through a $ref
+	withStatus := func(values ...interface{}) func(*Swagger) {
+		return func(sw *Swagger) {
+			pet := sw.Definitions["Pet"]
+			status := StringProperty()
+			if len(values) > 0 {
+				status.WithEnum(values...)
+			}
+			pet.SetProperty("status", *status)
+			sw.Definitions["Pet"] = pet
+		}
+	}
+	left := diffFixture(withStatus("available", "sold"))
+
+	changes := Diff(left, diffFixture(withStatus("available", "sold", "pending")))
+	c, ok := findChange(changes, "definitions Pet.status", "enum values [pending] were added")
+	if assert.True(t, ok) {
+		assert.True(t, c.Breaking)
+	}
+
+	changes = Diff(left, diffFixture(withStatus("available")))
+	c, ok = findChange(changes, "definitions Pet.status", "enum values [sold] were removed")
+	if assert.True(t, ok) {
+		assert.True(t, c.Breaking)
+	}
+
+	changes = Diff(left, diffFixture(withStatus()))
+	c, ok = findChange(changes, "definitions Pet.status", "values are no longer restricted to [available sold]")
+	if assert.True(t, ok) {
+		assert.True(t, c.Breaking)
+	}
+}
+
+func TestDiff_MediaTypes(t *testing.T) {
+	left := diffFixture(nil)
+	right := diffFixture(func(sw *Swagger) {
+		sw.Produces = []string{"application/xml"}
+	})
+
+	changes := Diff(left, right)
+	c, ok := findChange(changes, "GET /pets", `produces media type "application/json" was removed`)
+	if assert.True(t, ok) {
+		assert.True(t, c.Breaking)
+	}
+	c, ok = findChange(changes, "GET /pets", `produces media type "application/xml" was added`)
+	if assert.True(t, ok) {
+		assert.False(t, c.Breaking)
+	}
+}
@@ -0,0 +1,386 @@
+package spec
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vikstrous/go-swagger/jsonpointer"
+)
+
+const (
+	definitionsPrefix = "#/definitions/"
+	parametersPrefix  = "#/parameters/"
+	responsesPrefix   = "#/responses/"
+)
+
+// AddOperation registers an operation for the method and path on this spec.
+// When the path doesn't exist yet it will be created, when the method already
+// had an operation registered it will be replaced.
+func (s *Swagger) AddOperation(method, path string, operation *Operation) error {
+	if operation == nil {
+		return fmt.Errorf("operation for %s %s can't be nil", method, path)
+	}
+	if s.Paths == nil {
+		s.Paths = new(Paths)
+	}
+	if s.Paths.Paths == nil {
+		s.Paths.Paths = make(map[string]PathItem)
+	}
+
+	pi := s.Paths.Paths[path]
+	if !pi.setOperation(method, operation) {
+		return fmt.Errorf("method %q is not supported in a swagger 2.0 spec", method)
+	}
+	s.Paths.Paths[path] = pi
+	return nil
+}
+
+// RemoveOperation removes the operation for the method and path from this spec.
+// When the path has no operations left it is removed too.
+func (s *Swagger) RemoveOperation(method, path string) *Swagger {
+	if s.Paths == nil {
+		return s
+	}
+	pi, ok := s.Paths.Paths[path]
+	if !ok {
+		return s
+	}
+	pi.setOperation(method, nil)
+	if pi.Get == nil && pi.Put == nil && pi.Post == nil && pi.Delete == nil &&
+		pi.Options == nil && pi.Head == nil && pi.Patch == nil && pi.Ref.String() == "" {
+		delete(s.Paths.Paths, path)
+		return s
+	}
+	s.Paths.Paths[path] = pi
+	return s
+}
+
+// AddDefinition adds a named model definition to this spec, replacing any definition with the same name
+func (s *Swagger) AddDefinition(name string, schema *Schema) *Swagger {
+	if schema == nil {
+		return s
+	}
+	if s.Definitions == nil {
+		s.Definitions = make(Definitions)
+	}
+	s.Definitions[name] = *schema
+	return s
+}
+
+// AddParameter adds a named parameter to this spec, it can be referenced as #/parameters/{name}
+func (s *Swagger) AddParameter(name string, param *Parameter) *Swagger {
+	if param == nil {
+		return s
+	}
+	if s.Parameters == nil {
+		s.Parameters = make(map[string]Parameter)
+	}
+	s.Parameters[name] = *param
+	return s
+}
+
+// AddResponse adds a named response to this spec, it can be referenced as #/responses/{name}
+func (s *Swagger) AddResponse(name string, response *Response) *Swagger {
+	if response == nil {
+		return s
+	}
+	if s.Responses == nil {
+		s.Responses = make(map[string]Response)
+	}
+	s.Responses[name] = *response
+	return s
+}
+
+// AddSecurityDefinition adds a named security scheme to this spec
+func (s *Swagger) AddSecurityDefinition(name string, scheme *SecurityScheme) *Swagger {
+	if scheme == nil {
+		return s
+	}
+	if s.SecurityDefinitions == nil {
+		s.SecurityDefinitions = make(SecurityDefinitions)
+	}
+	s.SecurityDefinitions[name] = scheme
+	return s
+}
+
+// AddTag adds a tag to this spec, replacing the tag with the same name when it exists
+func (s *Swagger) AddTag(tag Tag) *Swagger {
+	for i, t := range s.Tags {
+		if t.Name == tag.Name {
+			s.Tags[i] = tag
+			return s
+		}
+	}
+	s.Tags = append(s.Tags, tag)
+	return s
+}
+
+// RenameDefinition renames a model definition and rewrites all the references to it,
+// including references that point inside of the definition.
+func (s *Swagger) RenameDefinition(from, to string) error {
+	if _, ok := s.Definitions[from]; !ok {
+		return fmt.Errorf("definition %q doesn't exist", from)
+	}
+	if _, ok := s.Definitions[to]; ok {
+		return fmt.Errorf("definition %q already exists", to)
+	}
+	s.Definitions[to] = s.Definitions[from]
+	delete(s.Definitions, from)
+	_, err := s.RewriteRefs(definitionsPrefix+jsonpointer.Escape(from), definitionsPrefix+jsonpointer.Escape(to))
+	return err
+}
+
+// MoveDefinition removes a model definition from this spec and points all the references
+// that used it to the provided target, for example a definition in a shared document.
+func (s *Swagger) MoveDefinition(name, target string) error {
+	if _, ok := s.Definitions[name]; !ok {
+		return fmt.Errorf("definition %q doesn't exist", name)
+	}
+	if _, err := NewRef(target); err != nil {
+		return err
+	}
+	delete(s.Definitions, name)
+	_, err := s.RewriteRefs(definitionsPrefix+jsonpointer.Escape(name), target)
+	return err
+}
+
+// RemoveDefinition removes a model definition from this spec.
+// A definition that is still referenced can't be removed, the error lists the referrers.
+func (s *Swagger) RemoveDefinition(name string) error {
+	if _, ok := s.Definitions[name]; !ok {
+		return fmt.Errorf("definition %q doesn't exist", name)
+	}
+	if refs := s.ReferencesTo(definitionsPrefix + jsonpointer.Escape(name)); len(refs) > 0 {
+		return fmt.Errorf("definition %q is still referenced from %s", name, strings.Join(refs, ", "))
+	}
+	delete(s.Definitions, name)
+	return nil
+}
+
+// RenameParameter renames a shared parameter and rewrites all the references to it
+func (s *Swagger) RenameParameter(from, to string) error {
+	if _, ok := s.Parameters[from]; !ok {
+		return fmt.Errorf("parameter %q doesn't exist", from)
+	}
+	if _, ok := s.Parameters[to]; ok {
+		return fmt.Errorf("parameter %q already exists", to)
+	}
+	s.Parameters[to] = s.Parameters[from]
+	delete(s.Parameters, from)
+	_, err := s.RewriteRefs(parametersPrefix+jsonpointer.Escape(from), parametersPrefix+jsonpointer.Escape(to))
+	return err
+}
+
+// RenameResponse renames a shared response and rewrites all the references to it
+func (s *Swagger) RenameResponse(from, to string) error {
+	if _, ok := s.Responses[from]; !ok {
+		return fmt.Errorf("response %q doesn't exist", from)
+	}
+	if _, ok := s.Responses[to]; ok {
+		return fmt.Errorf("response %q already exists", to)
+	}
+	s.Responses[to] = s.Responses[from]
+	delete(s.Responses, from)
+	_, err := s.RewriteRefs(responsesPrefix+jsonpointer.Escape(from), responsesPrefix+jsonpointer.Escape(to))
+	return err
+}
+
+// RewriteRefs replaces every reference that points to from, or to a location inside of from,
+// so that it points to the same location relative to to.
+// It returns the number of references that were rewritten.
+func (s *Swagger) RewriteRefs(from, to string) (int, error) {
+	var count int
+	var firstErr error
+	s.walkRefs(func(_ string, ref *Ref) {
+		if firstErr != nil {
+			return
+		}
+		rest, ok := refUnder(ref, from)
+		if !ok {
+			return
+		}
+		nr, err := NewRef(to + rest)
+		if err != nil {
+			firstErr = err
+			return
+		}
+		*ref = nr
+		count++
+	})
+	return count, firstErr
+}
+
+// ReferencesTo returns the json pointers of all the places in this spec that
+// reference the target or a location inside of the target.
+func (s *Swagger) ReferencesTo(target string) []string {
+	var result []string
+	s.walkRefs(func(pointer string, ref *Ref) {
+		if _, ok := refUnder(ref, target); ok {
+			result = append(result, pointer)
+		}
+	})
+	sort.Strings(result)
+	return result
+}
+
+func refUnder(ref *Ref, target string) (string, bool) {
+	str := ref.String()
+	if str == "" {
+		return "", false
+	}
+	if str == target {
+		return "", true
+	}
+	if strings.HasPrefix(str, target+"/") {
+		return str[len(target):], true
+	}
+	return "", false
+}
+
+func (p *PathItem) setOperation(method string, operation *Operation) bool {
+	switch strings.ToUpper(method) {
+	case "GET":
+		p.Get = operation
+	case "PUT":
+		p.Put = operation
+	case "POST":
+		p.Post = operation
+	case "DELETE":
+		p.Delete = operation
+	case "OPTIONS":
+		p.Options = operation
+	case "HEAD":
+		p.Head = operation
+	case "PATCH":
+		p.Patch = operation
+	default:
+		return false
+	}
+	return true
+}
+
+type refVisitor func(pointer string, ref *Ref)
+
+func (s *Swagger) walkRefs(visit refVisitor) {
+	for k, v := range s.Definitions {
+		walkSchemaRefs("/definitions/"+jsonpointer.Escape(k), &v, visit)
+		s.Definitions[k] = v
+	}
+	for k, v := range s.Parameters {
+		walkParamRefs("/parameters/"+jsonpointer.Escape(k), &v, visit)
+		s.Parameters[k] = v
+	}
+	for k, v := range s.Responses {
+		walkResponseRefs("/responses/"+jsonpointer.Escape(k), &v, visit)
+		s.Responses[k] = v
+	}
+	if s.Paths == nil {
+		return
+	}
+	for k, v := range s.Paths.Paths {
+		ptr := "/paths/" + jsonpointer.Escape(k)
+		visit(ptr, &v.Ref)
+		for i := range v.Parameters {
+			walkParamRefs(ptr+"/parameters/"+strconv.Itoa(i), &v.Parameters[i], visit)
+		}
+		walkOperationRefs(ptr+"/get", v.Get, visit)
+		walkOperationRefs(ptr+"/put", v.Put, visit)
+		walkOperationRefs(ptr+"/post", v.Post, visit)
+		walkOperationRefs(ptr+"/delete", v.Delete, visit)
+		walkOperationRefs(ptr+"/options", v.Options, visit)
+		walkOperationRefs(ptr+"/head", v.Head, visit)
+		walkOperationRefs(ptr+"/patch", v.Patch, visit)
+		s.Paths.Paths[k] = v
+	}
+}
+
+func walkOperationRefs(pointer string, op *Operation, visit refVisitor) {
+	if op == nil {
+		return
+	}
+	for i := range op.Parameters {
+		walkParamRefs(pointer+"/parameters/"+strconv.Itoa(i), &op.Parameters[i], visit)
+	}
+	if op.Responses == nil {
+		return
+	}
+	walkResponseRefs(pointer+"/responses/default", op.Responses.Default, visit)
+	for k, v := range op.Responses.StatusCodeResponses {
+		walkResponseRefs(pointer+"/responses/"+strconv.Itoa(k), &v, visit)
+		op.Responses.StatusCodeResponses[k] = v
+	}
+}
+
+func walkParamRefs(pointer string, param *Parameter, visit refVisitor) {
+	if param == nil {
+		return
+	}
+	visit(pointer, &param.Ref)
+	walkSchemaRefs(pointer+"/schema", param.Schema, visit)
+	walkItemsRefs(pointer+"/items", param.Items, visit)
+}
+
+func walkResponseRefs(pointer string, response *Response, visit refVisitor) {
+	if response == nil {
+		return
+	}
+	visit(pointer, &response.Ref)
+	walkSchemaRefs(pointer+"/schema", response.Schema, visit)
+	for k, v := range response.Headers {
+		walkItemsRefs(pointer+"/headers/"+jsonpointer.Escape(k)+"/items", v.Items, visit)
+	}
+}
+
+func walkItemsRefs(pointer string, items *Items, visit refVisitor) {
+	for items != nil {
+		visit(pointer, &items.Ref)
+		items = items.Items
+		pointer += "/items"
+	}
+}
+
+func walkSchemaRefs(pointer string, schema *Schema, visit refVisitor) {
+	if schema == nil {
+		return
+	}
+	visit(pointer, &schema.Ref)
+	if schema.Items != nil {
+		walkSchemaRefs(pointer+"/items", schema.Items.Schema, visit)
+		for i := range schema.Items.Schemas {
+			walkSchemaRefs(pointer+"/items/"+strconv.Itoa(i), &schema.Items.Schemas[i], visit)
+		}
+	}
+	for i := range schema.AllOf {
+		walkSchemaRefs(pointer+"/allOf/"+strconv.Itoa(i), &schema.AllOf[i], visit)
+	}
+	for i := range schema.AnyOf {
+		walkSchemaRefs(pointer+"/anyOf/"+strconv.Itoa(i), &schema.AnyOf[i], visit)
+	}
+	for i := range schema.OneOf {
+		walkSchemaRefs(pointer+"/oneOf/"+strconv.Itoa(i), &schema.OneOf[i], visit)
+	}
+	walkSchemaRefs(pointer+"/not", schema.Not, visit)
+	for k, v := range schema.Properties {
+		walkSchemaRefs(pointer+"/properties/"+jsonpointer.Escape(k), &v, visit)
+		schema.Properties[k] = v
+	}
+	if schema.AdditionalProperties != nil {
+		walkSchemaRefs(pointer+"/additionalProperties", schema.AdditionalProperties.Schema, visit)
+	}
+	for k, v := range schema.PatternProperties {
+		walkSchemaRefs(pointer+"/patternProperties/"+jsonpointer.Escape(k), &v, visit)
+		schema.PatternProperties[k] = v
+	}
+	for k, v := range schema.Dependencies {
+		walkSchemaRefs(pointer+"/dependencies/"+jsonpointer.Escape(k), v.Schema, visit)
+	}
+	if schema.AdditionalItems != nil {
+		walkSchemaRefs(pointer+"/additionalItems", schema.AdditionalItems.Schema, visit)
+	}
+	for k, v := range schema.Definitions {
+		walkSchemaRefs(pointer+"/definitions/"+jsonpointer.Escape(k), &v, visit)
+		schema.Definitions[k] = v
+	}
+}
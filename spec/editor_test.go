@@ -0,0 +1,158 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func petStoreBuilt() *Swagger {
+	sw := new(Swagger)
+	sw.Info = new(Info)
+	sw.Info.Title = "Pet store"
+	sw.Info.Version = "1.0.0"
+	sw.Consumes = []string{"application/json"}
+	sw.Produces = []string{"application/json"}
+
+	pet := new(Schema).Typed("object", "")
+	pet.WithRequired("name")
+	pet.SetProperty("name", *StringProperty())
+	pet.SetProperty("category", *RefProperty("#/definitions/Category"))
+	pet.SetProperty("categoryName", *RefProperty("#/definitions/Category/properties/name"))
+
+	category := new(Schema).Typed("object", "")
+	category.SetProperty("name", *StringProperty())
+
+	sw.AddDefinition("Pet", pet).
+		AddDefinition("Category", category).
+		AddParameter("idParam", PathParam("id").Typed("integer", "int64")).
+		AddResponse("petResponse", NewResponse().WithDescription("a pet").WithSchema(RefProperty("#/definitions/Pet")))
+
+	listPets := NewOperation("listPets").
+		WithSummary("lists the pets").
+		WithTags("pets").
+		AddParam(QueryParam("limit").Typed("integer", "int32")).
+		RespondsWith(200, NewResponse().WithDescription("the pets").WithSchema(ArrayProperty(RefProperty("#/definitions/Pet")))).
+		WithDefaultResponse(NewResponse().WithDescription("an error"))
+
+	getPet := NewOperation("getPet").
+		AddParam(ParamRef("#/parameters/idParam")).
+		RespondsWith(200, ResponseRef("#/responses/petResponse"))
+
+	sw.AddOperation("get", "/pets", listPets)
+	sw.AddOperation("GET", "/pets/{id}", getPet)
+	return sw
+}
+
+func TestBuilder_Operations(t *testing.T) {
+	sw := petStoreBuilt()
+
+	if assert.NotNil(t, sw.Paths) {
+		assert.Len(t, sw.Paths.Paths, 2)
+		pi := sw.Paths.Paths["/pets"]
+		if assert.NotNil(t, pi.Get) {
+			assert.Equal(t, "listPets", pi.Get.ID)
+			assert.Len(t, pi.Get.Parameters, 1)
+			assert.NotNil(t, pi.Get.Responses.Default)
+			assert.Contains(t, pi.Get.Responses.StatusCodeResponses, 200)
+		}
+	}
+
+	assert.Error(t, sw.AddOperation("TRACE", "/pets", NewOperation("tracePets")))
+	assert.Error(t, sw.AddOperation("GET", "/pets", nil))
+
+	op := sw.Paths.Paths["/pets"].Get
+	op.AddParam(QueryParam("limit").Typed("integer", "int64"))
+	assert.Len(t, op.Parameters, 1)
+	assert.Equal(t, "int64", op.Parameters[0].Format)
+	op.RemoveParam("limit", "query")
+	assert.Empty(t, op.Parameters)
+
+	op.AddParam(ParamRef("#/parameters/idParam")).AddParam(ParamRef("#/parameters/limitParam"))
+	if assert.Len(t, op.Parameters, 2) {
+		assert.Equal(t, "#/parameters/idParam", op.Parameters[0].Ref.String())
+		assert.Equal(t, "#/parameters/limitParam", op.Parameters[1].Ref.String())
+	}
+	op.AddParam(ParamRef("#/parameters/idParam")).AddParam(new(Parameter))
+	assert.Len(t, op.Parameters, 3)
+	op.Parameters = nil
+
+	op.WithTags("pets", "animals").WithConsumes("application/json", "application/json")
+	assert.Equal(t, []string{"pets", "animals"}, op.Tags)
+	assert.Equal(t, []string{"application/json"}, op.Consumes)
+
+	sw.RemoveOperation("GET", "/pets")
+	assert.NotContains(t, sw.Paths.Paths, "/pets")
+
+	doc, err := NewDocument(sw)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "2.0", doc.Version())
+		_, ok := doc.OperationFor("GET", "/pets/{id}")
+		assert.True(t, ok)
+	}
+}
+
+func TestEditor_RenameDefinition(t *testing.T) {
+	sw := petStoreBuilt()
+
+	assert.Error(t, sw.RenameDefinition("Dog", "Cat"))
+	assert.Error(t, sw.RenameDefinition("Pet", "Category"))
+
+	if assert.NoError(t, sw.RenameDefinition("Category", "Kind")) {
+		assert.NotContains(t, sw.Definitions, "Category")
+		pet := sw.Definitions["Pet"]
+		category, name := pet.Properties["category"], pet.Properties["categoryName"]
+		assert.Equal(t, "#/definitions/Kind", category.Ref.String())
+		assert.Equal(t, "#/definitions/Kind/properties/name", name.Ref.String())
+	}
+
+	if assert.NoError(t, sw.RenameDefinition("Pet", "Animal")) {
+		resp := sw.Responses["petResponse"]
+		assert.Equal(t, "#/definitions/Animal", resp.Schema.Ref.String())
+		op := sw.Paths.Paths["/pets"].Get
+		resp = op.Responses.StatusCodeResponses[200]
+		assert.Equal(t, "#/definitions/Animal", resp.Schema.Items.Schema.Ref.String())
+	}
+	assert.Empty(t, sw.ReferencesTo("#/definitions/Pet"))
+}
+
+func TestEditor_RemoveAndMoveDefinition(t *testing.T) {
+	sw := petStoreBuilt()
+
+	err := sw.RemoveDefinition("Category")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "/definitions/Pet/properties/category")
+	}
+	assert.Equal(t, []string{
+		"/definitions/Pet/properties/category",
+		"/definitions/Pet/properties/categoryName",
+	}, sw.ReferencesTo("#/definitions/Category"))
+
+	if assert.NoError(t, sw.MoveDefinition("Category", "common.json#/definitions/Category")) {
+		assert.NotContains(t, sw.Definitions, "Category")
+		pet := sw.Definitions["Pet"]
+		category, name := pet.Properties["category"], pet.Properties["categoryName"]
+		assert.Equal(t, "common.json#/definitions/Category", category.Ref.String())
+		assert.Equal(t, "common.json#/definitions/Category/properties/name", name.Ref.String())
+	}
+
+	sw.AddDefinition("Unused", StringProperty())
+	assert.NoError(t, sw.RemoveDefinition("Unused"))
+	assert.NotContains(t, sw.Definitions, "Unused")
+}
+
+func TestEditor_RenameSharedObjects(t *testing.T) {
+	sw := petStoreBuilt()
+
+	if assert.NoError(t, sw.RenameParameter("idParam", "petID")) {
+		op := sw.Paths.Paths["/pets/{id}"].Get
+		assert.Equal(t, "#/parameters/petID", op.Parameters[0].Ref.String())
+	}
+	if assert.NoError(t, sw.RenameResponse("petResponse", "singlePet")) {
+		op := sw.Paths.Paths["/pets/{id}"].Get
+		resp := op.Responses.StatusCodeResponses[200]
+		assert.Equal(t, "#/responses/singlePet", resp.Ref.String())
+	}
+	assert.Error(t, sw.RenameParameter("idParam", "other"))
+	assert.Error(t, sw.RenameResponse("petResponse", "other"))
+}
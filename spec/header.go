@@ -19,6 +19,17 @@ type Header struct {
 	headerProps
 }
 
+// ResponseHeader creates a new header instance for use in a response
+func ResponseHeader() *Header {
+	return new(Header)
+}
+
+// WithDescription sets the description on this header, allows for chaining
+func (h *Header) WithDescription(description string) *Header {
+	h.Description = description
+	return h
+}
+
 // Typed a fluent builder method for the type of parameter
 func (h *Header) Typed(tpe, format string) *Header {
 	h.Type = tpe
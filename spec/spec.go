@@ -92,8 +92,9 @@ func Swagger20Schema() (*Schema, error) {
 // Document represents a swagger spec document
 type Document struct {
 	specAnalyzer
-	spec *Swagger
-	raw  json.RawMessage
+	spec      *Swagger
+	raw       json.RawMessage
+	positions *swag.PositionIndex
 }
 
 var swaggerSchema *Schema
@@ -119,6 +120,33 @@ func Load(path string) (*Document, error) {
 	return JSONSpec(path)
 }
 
+// LoadWithPositions loads a new spec document like Load does,
+// it also keeps track of the position of each node in the source document
+// so errors can tell where they occurred
+func LoadWithPositions(path string) (*Document, error) {
+	specURL, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+
+	loader := swag.JSONDocWithPositions
+	ext := filepath.Ext(specURL.Path)
+	if ext == ".yaml" || ext == ".yml" {
+		loader = swag.YAMLDocWithPositions
+	}
+
+	data, positions, err := loader(path)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := New(data, "")
+	if err != nil {
+		return nil, err
+	}
+	doc.positions = positions
+	return doc, nil
+}
+
 // New creates a new shema document
 func New(data json.RawMessage, version string) (*Document, error) {
 	if version == "" {
@@ -148,6 +176,39 @@ func New(data json.RawMessage, version string) (*Document, error) {
 	return d, nil
 }
 
+// NewDocument creates a new spec document for a spec object model,
+// this allows specs that were built in code to be analyzed and validated
+func NewDocument(swspec *Swagger) (*Document, error) {
+	if swspec == nil {
+		return nil, fmt.Errorf("swagger spec can't be nil")
+	}
+	if swspec.Swagger == "" {
+		swspec.Swagger = "2.0"
+	}
+	if swspec.Swagger != "2.0" {
+		return nil, fmt.Errorf("spec version %q is not supported", swspec.Swagger)
+	}
+
+	data, err := json.Marshal(swspec)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Document{
+		specAnalyzer: specAnalyzer{
+			spec:        swspec,
+			consumes:    make(map[string]struct{}),
+			produces:    make(map[string]struct{}),
+			authSchemes: make(map[string]struct{}),
+			operations:  make(map[string]map[string]*Operation),
+		},
+		spec: swspec,
+		raw:  data,
+	}
+	d.initialize()
+	return d, nil
+}
+
 // Expanded expands the ref fields in the spec document and returns a new spec document
 func (d *Document) Expanded() (*Document, error) {
 	spec := new(Swagger)
@@ -166,8 +227,9 @@ func (d *Document) Expanded() (*Document, error) {
 			authSchemes: make(map[string]struct{}),
 			operations:  make(map[string]map[string]*Operation),
 		},
-		spec: spec,
-		raw:  d.raw,
+		spec:      spec,
+		raw:       d.raw,
+		positions: d.positions,
 	}
 	dd.initialize()
 	return dd, nil
@@ -203,6 +265,12 @@ func (d *Document) Raw() json.RawMessage {
 	return d.raw
 }
 
+// PositionOf returns the position in the source document of the node the json pointer refers to,
+// positions are only known for documents loaded with LoadWithPositions
+func (d *Document) PositionOf(pointer string) (swag.Position, bool) {
+	return d.positions.Lookup(pointer)
+}
+
 // Reload reanalyzes the spec
 func (d *Document) Reload() *Document {
 	d.specAnalyzer = specAnalyzer{
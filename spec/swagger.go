@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/vikstrous/go-swagger/jsonpointer"
+	"github.com/vikstrous/go-swagger/swag"
 )
 
 // Swagger this is the root document object for the API specification.
@@ -14,12 +15,30 @@ import (
 //
 // For more information: http://goo.gl/8us55a#swagger-object-
 type Swagger struct {
+	vendorExtensible
 	swaggerProps
 }
 
+// JSONLookup look up a value by the json property name
+func (s Swagger) JSONLookup(token string) (interface{}, error) {
+	if ex, ok := s.Extensions[token]; ok {
+		return &ex, nil
+	}
+	r, _, err := jsonpointer.GetForToken(s.swaggerProps, token)
+	return r, err
+}
+
 // MarshalJSON marshals this swagger structure to json
 func (s Swagger) MarshalJSON() ([]byte, error) {
-	return json.Marshal(s.swaggerProps)
+	b1, err := json.Marshal(s.swaggerProps)
+	if err != nil {
+		return nil, err
+	}
+	b2, err := json.Marshal(s.vendorExtensible)
+	if err != nil {
+		return nil, err
+	}
+	return swag.ConcatJSON(b1, b2), nil
 }
 
 // UnmarshalJSON unmarshals a swagger spec from json
@@ -28,6 +47,9 @@ func (s *Swagger) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &sw.swaggerProps); err != nil {
 		return err
 	}
+	if err := json.Unmarshal(data, &sw.vendorExtensible); err != nil {
+		return err
+	}
 	*s = sw
 	return nil
 }
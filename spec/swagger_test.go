@@ -15,6 +15,7 @@ func IsZero(data reflect.Value) bool {
 }
 
 var spec = Swagger{
+	vendorExtensible: vendorExtensible{map[string]interface{}{"x-generator": "go-swagger"}},
 	swaggerProps: swaggerProps{
 		ID:          "http://localhost:3849/api-docs",
 		Swagger:     "2.0",
@@ -89,7 +90,8 @@ var specJSON = `{
 	},
 	"security": [{"internalApiKey":[]}],
 	"tags": [{"name":"pets"}],
-	"externalDocs": {"description":"the name","url":"the url"}
+	"externalDocs": {"description":"the name","url":"the url"},
+	"x-generator": "go-swagger"
 }`
 
 func verifySpecSerialize(specJSON []byte, spec Swagger) {
@@ -154,6 +156,7 @@ func compareSpecMaps(actual, expected map[string]interface{}) {
 	So(actual["securityDefinitions"], ShouldResemble, expected["securityDefinitions"])
 	So(actual["tags"], ShouldResemble, expected["tags"])
 	So(actual["externalDocs"], ShouldResemble, expected["externalDocs"])
+	So(actual["x-generator"], ShouldEqual, expected["x-generator"])
 }
 
 func compareSpecs(actual Swagger, spec Swagger) {
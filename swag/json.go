@@ -3,6 +3,7 @@ package swag
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"strings"
 	"sync"
@@ -107,6 +108,109 @@ func FromDynamicJSON(data, target interface{}) error {
 	return json.Unmarshal(b, target)
 }
 
+// JSONMapItem represents the value of a key in a JSON object held by a JSONMapSlice
+type JSONMapItem struct {
+	Key   string
+	Value interface{}
+}
+
+// JSONMapSlice represents a JSON object, with the order of its keys preserved.
+// When it's unmarshaled the nested objects are JSONMapSlices too and numbers are json.Numbers.
+type JSONMapSlice []JSONMapItem
+
+// MarshalJSON renders a JSONMapSlice as a JSON object, the keys are written in order
+func (s JSONMapSlice) MarshalJSON() ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	buf.WriteByte('{')
+	for i, item := range s {
+		if i > 0 {
+			buf.WriteByte(comma)
+		}
+		k, err := json.Marshal(item.Key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(item.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON makes a JSONMapSlice from a JSON object, the keys keep the order of the document
+func (s *JSONMapSlice) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("expected a json object but got %v", tok)
+	}
+	res, err := decodeJSONObject(dec)
+	if err != nil {
+		return err
+	}
+	*s = res
+	return nil
+}
+
+func decodeJSONObject(dec *json.Decoder) (JSONMapSlice, error) {
+	res := JSONMapSlice{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a key in a json object but got %v", tok)
+		}
+		value, err := decodeJSONValue(dec)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, JSONMapItem{Key: key, Value: value})
+	}
+	// consume the closing brace
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func decodeJSONValue(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		return decodeJSONObject(dec)
+	case json.Delim('['):
+		res := []interface{}{}
+		for dec.More() {
+			value, err := decodeJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, value)
+		}
+		// consume the closing bracket
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+	return tok, nil
+}
+
 // NameProvider represents an object capabale of translating from go property names
 // to json property names
 // This type is thread-safe.
@@ -132,12 +236,18 @@ func buildnameIndex(tpe reflect.Type, idx, reverseIdx map[string]string) {
 	for i := 0; i < tpe.NumField(); i++ {
 		targetDes := tpe.Field(i)
 
-		if targetDes.PkgPath != "" { // unexported
+		if targetDes.Anonymous { // walk embedded structures tree down first, their exported fields are promoted even when they're unexported
+			embedded := targetDes.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				buildnameIndex(embedded, idx, reverseIdx)
+			}
 			continue
 		}
 
-		if targetDes.Anonymous { // walk embedded structures tree down first
-			buildnameIndex(targetDes.Type, idx, reverseIdx)
+		if targetDes.PkgPath != "" { // unexported
 			continue
 		}
 
@@ -1,6 +1,7 @@
 package swag
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 
@@ -125,6 +126,40 @@ func TestNameProvider(t *testing.T) {
 
 }
 
+type testEmbeddedName struct {
+	Embedded string `json:"embedded"`
+}
+
+type testPointerName struct {
+	Pointed string `json:"pointed"`
+}
+
+type testEmbeddingStruct struct {
+	testEmbeddedName
+	*testPointerName
+	Own string `json:"own"`
+}
+
+func TestNameProviderEmbedded(t *testing.T) {
+	provider := NewNameProvider()
+	var obj testEmbeddingStruct
+
+	// the fields of embedded structs and of embedded pointers are promoted, even when the embedded type is unexported
+	nm, ok := provider.GetGoName(obj, "embedded")
+	assert.True(t, ok)
+	assert.Equal(t, "Embedded", nm)
+
+	nm, ok = provider.GetGoName(obj, "pointed")
+	assert.True(t, ok)
+	assert.Equal(t, "Pointed", nm)
+
+	nm, ok = provider.GetJSONName(obj, "Own")
+	assert.True(t, ok)
+	assert.Equal(t, "own", nm)
+
+	assert.Len(t, provider.GetJSONNames(obj), 3)
+}
+
 func TestJSONConcatenation(t *testing.T) {
 	Convey("JSON concatenation should", t, func() {
 
@@ -261,3 +296,28 @@ func TestJSONConcatenation(t *testing.T) {
 		})
 	})
 }
+
+func TestJSONMapSlice(t *testing.T) {
+	doc := []byte(`{"zeta":1,"alpha":{"b":[1.5,"x",{"z":true,"a":null}],"a":"y"},"mid":[]}`)
+
+	var slice JSONMapSlice
+	if assert.NoError(t, json.Unmarshal(doc, &slice)) {
+		assert.Len(t, slice, 3)
+		assert.Equal(t, "zeta", slice[0].Key)
+		assert.Equal(t, json.Number("1"), slice[0].Value)
+		nested, ok := slice[1].Value.(JSONMapSlice)
+		if assert.True(t, ok) {
+			assert.Equal(t, "b", nested[0].Key)
+			assert.Equal(t, "a", nested[1].Key)
+		}
+		assert.Equal(t, []interface{}{}, slice[2].Value)
+
+		b, err := json.Marshal(slice)
+		if assert.NoError(t, err) {
+			assert.Equal(t, string(doc), string(b))
+		}
+	}
+
+	assert.Error(t, json.Unmarshal([]byte(`[1, 2]`), &slice))
+	assert.Error(t, json.Unmarshal([]byte(`{"a":`), &slice))
+}
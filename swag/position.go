@@ -0,0 +1,491 @@
+package swag
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Position is a location in a source document, lines and columns start at 1
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	if p.File == "" {
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// PositionIndex maps json pointers to the position of the nodes in the source document
+type PositionIndex struct {
+	File      string
+	positions map[string]Position
+}
+
+func newPositionIndex(file string) *PositionIndex {
+	return &PositionIndex{File: file, positions: make(map[string]Position)}
+}
+
+func (p *PositionIndex) set(pointer string, line, column int) {
+	if _, ok := p.positions[pointer]; !ok {
+		p.positions[pointer] = Position{File: p.File, Line: line, Column: column}
+	}
+}
+
+// Len returns the number of nodes in the index
+func (p *PositionIndex) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.positions)
+}
+
+// Lookup returns the position of the node the json pointer refers to.
+// When the node isn't in the index the position of the closest parent is returned,
+// this way an error about a missing property points at the object that should have it.
+func (p *PositionIndex) Lookup(pointer string) (Position, bool) {
+	if p == nil {
+		return Position{}, false
+	}
+	for {
+		if pos, ok := p.positions[pointer]; ok {
+			return pos, true
+		}
+		if pointer == "" {
+			return Position{}, false
+		}
+		idx := strings.LastIndex(pointer, "/")
+		if idx < 0 {
+			pointer = ""
+		} else {
+			pointer = pointer[:idx]
+		}
+	}
+}
+
+func escapePointerToken(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
+
+// JSONPositions builds a position index for a json document
+func JSONPositions(file string, data []byte) (*PositionIndex, error) {
+	s := &jsonPositionScanner{data: data, line: 1, column: 1, index: newPositionIndex(file)}
+	s.skipSpace()
+	if err := s.value(""); err != nil {
+		return nil, err
+	}
+	return s.index, nil
+}
+
+type jsonPositionScanner struct {
+	data   []byte
+	offset int
+	line   int
+	column int
+	index  *PositionIndex
+}
+
+func (s *jsonPositionScanner) errorf(message string, args ...interface{}) error {
+	return fmt.Errorf("%s at line %d, column %d", fmt.Sprintf(message, args...), s.line, s.column)
+}
+
+func (s *jsonPositionScanner) advance() {
+	b := s.data[s.offset]
+	s.offset++
+	if b == '\n' {
+		s.line++
+		s.column = 1
+		return
+	}
+	// only count the first byte of utf-8 sequences
+	if b&0xC0 != 0x80 {
+		s.column++
+	}
+}
+
+func (s *jsonPositionScanner) skipSpace() {
+	for s.offset < len(s.data) {
+		switch s.data[s.offset] {
+		case ' ', '\t', '\r', '\n':
+			s.advance()
+		default:
+			return
+		}
+	}
+}
+
+func (s *jsonPositionScanner) expect(b byte) error {
+	s.skipSpace()
+	if s.offset >= len(s.data) || s.data[s.offset] != b {
+		return s.errorf("expected %q", b)
+	}
+	s.advance()
+	return nil
+}
+
+func (s *jsonPositionScanner) value(pointer string) error {
+	if s.offset >= len(s.data) {
+		return s.errorf("unexpected end of input")
+	}
+	s.index.set(pointer, s.line, s.column)
+
+	switch s.data[s.offset] {
+	case '{':
+		return s.object(pointer)
+	case '[':
+		return s.array(pointer)
+	case '"':
+		_, err := s.str()
+		return err
+	}
+	for s.offset < len(s.data) {
+		switch s.data[s.offset] {
+		case ',', '}', ']', ' ', '\t', '\r', '\n':
+			return nil
+		}
+		s.advance()
+	}
+	return nil
+}
+
+func (s *jsonPositionScanner) object(pointer string) error {
+	s.advance()
+	s.skipSpace()
+	if s.offset < len(s.data) && s.data[s.offset] == '}' {
+		s.advance()
+		return nil
+	}
+	for {
+		s.skipSpace()
+		line, column := s.line, s.column
+		key, err := s.str()
+		if err != nil {
+			return err
+		}
+		child := pointer + "/" + escapePointerToken(key)
+		s.index.set(child, line, column)
+		if err := s.expect(':'); err != nil {
+			return err
+		}
+		s.skipSpace()
+		if err := s.value(child); err != nil {
+			return err
+		}
+		s.skipSpace()
+		if s.offset < len(s.data) && s.data[s.offset] == '}' {
+			s.advance()
+			return nil
+		}
+		if err := s.expect(','); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *jsonPositionScanner) array(pointer string) error {
+	s.advance()
+	s.skipSpace()
+	if s.offset < len(s.data) && s.data[s.offset] == ']' {
+		s.advance()
+		return nil
+	}
+	for i := 0; ; i++ {
+		s.skipSpace()
+		if err := s.value(pointer + "/" + strconv.Itoa(i)); err != nil {
+			return err
+		}
+		s.skipSpace()
+		if s.offset < len(s.data) && s.data[s.offset] == ']' {
+			s.advance()
+			return nil
+		}
+		if err := s.expect(','); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *jsonPositionScanner) str() (string, error) {
+	if s.offset >= len(s.data) || s.data[s.offset] != '"' {
+		return "", s.errorf("expected a string")
+	}
+	start := s.offset
+	s.advance()
+	for s.offset < len(s.data) {
+		switch s.data[s.offset] {
+		case '\\':
+			s.advance()
+		case '"':
+			s.advance()
+			var result string
+			if err := json.Unmarshal(s.data[start:s.offset], &result); err != nil {
+				return "", err
+			}
+			return result, nil
+		}
+		if s.offset < len(s.data) {
+			s.advance()
+		}
+	}
+	return "", s.errorf("unterminated string")
+}
+
+// YAMLPositions builds a position index for a yaml document.
+// It understands the block style swagger specs are written in,
+// the content of flow collections and multi line scalars is not indexed.
+func YAMLPositions(file string, data []byte) *PositionIndex {
+	y := &yamlPositionScanner{
+		index: newPositionIndex(file),
+		stack: []*yamlFrame{{indent: -1}},
+		skip:  -1,
+	}
+
+	for n, raw := range strings.Split(string(data), "\n") {
+		line := strings.TrimRight(raw, "\r")
+		content := strings.TrimLeft(line, " ")
+		indent := len(line) - len(content)
+		if content == "" || content[0] == '#' {
+			continue
+		}
+		if y.skip >= 0 {
+			if indent > y.skip {
+				continue
+			}
+			y.skip = -1
+		}
+		if indent == 0 && (strings.HasPrefix(content, "---") || strings.HasPrefix(content, "...")) {
+			if y.started {
+				// only the first document is indexed
+				break
+			}
+			continue
+		}
+		if !y.started {
+			y.index.set("", n+1, indent+1)
+			y.started = true
+		}
+		y.node(n+1, indent, content)
+	}
+	return y.index
+}
+
+// yamlFrame is a mapping or a sequence that is being scanned,
+// frames that don't know their indentation yet take it from the first line of their content
+type yamlFrame struct {
+	indent  int
+	pointer string
+	seq     bool
+	items   int
+}
+
+type yamlPositionScanner struct {
+	index   *PositionIndex
+	stack   []*yamlFrame
+	skip    int
+	started bool
+}
+
+func (y *yamlPositionScanner) top() *yamlFrame {
+	return y.stack[len(y.stack)-1]
+}
+
+func (y *yamlPositionScanner) push(pointer string) {
+	y.stack = append(y.stack, &yamlFrame{indent: -1, pointer: pointer})
+}
+
+func (y *yamlPositionScanner) pop() {
+	y.stack = y.stack[:len(y.stack)-1]
+}
+
+// settle finds the frame a node with the provided indentation belongs to
+func (y *yamlPositionScanner) settle(indent int, dash bool) *yamlFrame {
+	for len(y.stack) > 1 {
+		top := y.top()
+		if top.indent < 0 {
+			parent := y.stack[len(y.stack)-2]
+			if indent > parent.indent || (dash && indent == parent.indent && !parent.seq) {
+				top.indent = indent
+				top.seq = dash
+				return top
+			}
+			y.pop()
+			continue
+		}
+		if indent < top.indent || (indent == top.indent && top.seq != dash) {
+			y.pop()
+			continue
+		}
+		return top
+	}
+	root := y.stack[0]
+	if root.indent < 0 {
+		root.indent = indent
+		root.seq = dash
+	}
+	return root
+}
+
+func isYAMLDash(content string) bool {
+	return content == "-" || strings.HasPrefix(content, "- ")
+}
+
+func (y *yamlPositionScanner) node(line, indent int, content string) {
+	dash := isYAMLDash(content)
+	frame := y.settle(indent, dash)
+
+	if dash {
+		pointer := frame.pointer + "/" + strconv.Itoa(frame.items)
+		frame.items++
+		y.index.set(pointer, line, indent+1)
+
+		rest := strings.TrimLeft(content[1:], " ")
+		if rest == "" || rest[0] == '#' {
+			y.push(pointer)
+			return
+		}
+		col := indent + len(content) - len(rest)
+		if _, _, ok := splitYAMLKey(rest); ok || isYAMLDash(rest) {
+			y.push(pointer)
+			y.node(line, col, rest)
+			return
+		}
+		y.skip = indent
+		return
+	}
+
+	key, value, ok := splitYAMLKey(content)
+	if !ok {
+		y.skip = indent
+		return
+	}
+	pointer := frame.pointer + "/" + escapePointerToken(key)
+	y.index.set(pointer, line, indent+1)
+	if isEmptyYAMLValue(value) {
+		y.push(pointer)
+		return
+	}
+	// block scalars, multi line scalars and flow collections continue on more indented lines
+	y.skip = indent
+}
+
+// isEmptyYAMLValue returns true when the value of a key is on the following lines
+func isEmptyYAMLValue(value string) bool {
+	if value == "" || value[0] == '#' {
+		return true
+	}
+	// a lone anchor or tag
+	return (value[0] == '&' || value[0] == '!') && !strings.ContainsAny(value, " \t")
+}
+
+// splitYAMLKey splits a "key: value" line, the value has its comments removed
+func splitYAMLKey(content string) (key, value string, ok bool) {
+	var rest string
+	switch content[0] {
+	case '"':
+		end := strings.Index(content[1:], `"`)
+		for end >= 0 && content[end] == '\\' {
+			next := strings.Index(content[end+2:], `"`)
+			if next < 0 {
+				end = -1
+				break
+			}
+			end += next + 1
+		}
+		if end < 0 {
+			return "", "", false
+		}
+		k, err := strconv.Unquote(content[:end+2])
+		if err != nil {
+			return "", "", false
+		}
+		key, rest = k, strings.TrimLeft(content[end+2:], " ")
+	case '\'':
+		i := 1
+		var buf []byte
+		for ; i < len(content); i++ {
+			if content[i] == '\'' {
+				if i+1 < len(content) && content[i+1] == '\'' {
+					buf = append(buf, '\'')
+					i++
+					continue
+				}
+				break
+			}
+			buf = append(buf, content[i])
+		}
+		if i >= len(content) {
+			return "", "", false
+		}
+		key, rest = string(buf), strings.TrimLeft(content[i+1:], " ")
+	case '{', '[', '?', '|', '>', '&', '*', '!':
+		return "", "", false
+	default:
+		idx := strings.Index(content, ": ")
+		if idx < 0 && strings.HasSuffix(content, ":") {
+			idx = len(content) - 1
+		}
+		if idx < 0 {
+			return "", "", false
+		}
+		key, rest = strings.TrimRight(content[:idx], " "), content[idx:]
+	}
+
+	if !strings.HasPrefix(rest, ":") || (len(rest) > 1 && rest[1] != ' ' && rest[1] != '\t') {
+		return "", "", false
+	}
+	value = strings.TrimSpace(rest[1:])
+	if value != "" && value[0] == '#' {
+		value = ""
+	}
+	return key, value, true
+}
+
+func loadWithPositions(path string, index func([]byte) (*PositionIndex, error)) ([]byte, *PositionIndex, error) {
+	data, err := LoadFromFileOrHTTP(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	positions, err := index(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, positions, nil
+}
+
+// JSONDocWithPositions loads a json document like JSONDoc does,
+// it also returns an index of the positions of the nodes in the document
+func JSONDocWithPositions(path string) (json.RawMessage, *PositionIndex, error) {
+	data, positions, err := loadWithPositions(path, func(data []byte) (*PositionIndex, error) {
+		return JSONPositions(path, data)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return json.RawMessage(data), positions, nil
+}
+
+// YAMLDocWithPositions loads a yaml document like YAMLDoc does,
+// it also returns an index of the positions of the nodes in the document
+func YAMLDocWithPositions(path string) (json.RawMessage, *PositionIndex, error) {
+	data, positions, err := loadWithPositions(path, func(data []byte) (*PositionIndex, error) {
+		return YAMLPositions(path, data), nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	yamlDoc, err := bytesToYAMLDoc(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	jsonDoc, err := YAMLToJSON(yamlDoc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return jsonDoc, positions, nil
+}
@@ -0,0 +1,126 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const positionsJSON = `{
+  "swagger": "2.0",
+  "paths": {
+    "/pets/{id}": {
+      "get": {
+        "parameters": [
+          {"name": "id", "in": "path"},
+          {
+            "name": "ünïcode \"quoted\"",
+            "in": "query"
+          }
+        ]
+      }
+    }
+  },
+  "definitions": {"a~b": {"type": "object"}}
+}`
+
+const positionsYAML = `# a comment
+swagger: '2.0'
+info:
+  title: pets
+  description: |
+    a long description: with a colon
+    over: multiple lines
+paths:
+  /pets/{id}:
+    get:
+      tags: [pets, "other"]
+      parameters:
+      - name: id
+        in: path
+      -
+        name: "limit"
+        in: query
+      responses:
+        200:
+          description: ok
+definitions:
+  'a~b':
+    type: object
+    required:
+      - name
+      - age
+---
+other: document
+`
+
+func TestJSONPositions(t *testing.T) {
+	idx, err := JSONPositions("swagger.json", []byte(positionsJSON))
+	if assert.NoError(t, err) {
+		assertPosition(t, idx, "", 1, 1)
+		assertPosition(t, idx, "/swagger", 2, 3)
+		assertPosition(t, idx, "/paths/~1pets~1{id}/get", 5, 7)
+		assertPosition(t, idx, "/paths/~1pets~1{id}/get/parameters/0", 7, 11)
+		assertPosition(t, idx, "/paths/~1pets~1{id}/get/parameters/0/in", 7, 26)
+		assertPosition(t, idx, "/paths/~1pets~1{id}/get/parameters/1", 8, 11)
+		assertPosition(t, idx, "/paths/~1pets~1{id}/get/parameters/1/in", 10, 13)
+		assertPosition(t, idx, "/definitions/a~0b/type", 16, 27)
+
+		pos, ok := idx.Lookup("/paths/~1pets~1{id}/get/responses")
+		if assert.True(t, ok) {
+			assert.Equal(t, "swagger.json:5:7", pos.String())
+		}
+	}
+
+	_, err = JSONPositions("swagger.json", []byte(`{"swagger": "2.0",`))
+	assert.Error(t, err)
+}
+
+func TestYAMLPositions(t *testing.T) {
+	idx := YAMLPositions("swagger.yml", []byte(positionsYAML))
+	assertPosition(t, idx, "", 2, 1)
+	assertPosition(t, idx, "/swagger", 2, 1)
+	assertPosition(t, idx, "/info/description", 5, 3)
+	assertPosition(t, idx, "/paths", 8, 1)
+	assertPosition(t, idx, "/paths/~1pets~1{id}/get/tags", 11, 7)
+	assertPosition(t, idx, "/paths/~1pets~1{id}/get/parameters/0", 13, 7)
+	assertPosition(t, idx, "/paths/~1pets~1{id}/get/parameters/0/in", 14, 9)
+	assertPosition(t, idx, "/paths/~1pets~1{id}/get/parameters/1", 15, 7)
+	assertPosition(t, idx, "/paths/~1pets~1{id}/get/parameters/1/name", 16, 9)
+	assertPosition(t, idx, "/paths/~1pets~1{id}/get/responses/200/description", 20, 11)
+	assertPosition(t, idx, "/definitions/a~0b/required/1", 26, 7)
+
+	_, ok := idx.positions["/info/over"]
+	assert.False(t, ok)
+	_, ok = idx.positions["/other"]
+	assert.False(t, ok)
+}
+
+func TestSplitYAMLKey(t *testing.T) {
+	for _, tc := range []struct {
+		content, key, value string
+		ok                  bool
+	}{
+		{"name: value", "name", "value", true},
+		{"name:", "name", "", true},
+		{"name: # comment", "name", "", true},
+		{`"quoted \" key": 1`, `quoted " key`, "1", true},
+		{"'it''s': yes", "it's", "yes", true},
+		{"http://example.com", "", "", false},
+		{"{a: 1}", "", "", false},
+		{"plain scalar", "", "", false},
+	} {
+		key, value, ok := splitYAMLKey(tc.content)
+		assert.Equal(t, tc.ok, ok, tc.content)
+		assert.Equal(t, tc.key, key, tc.content)
+		assert.Equal(t, tc.value, value, tc.content)
+	}
+}
+
+func assertPosition(t testing.TB, idx *PositionIndex, pointer string, line, column int) {
+	pos, ok := idx.positions[pointer]
+	if assert.True(t, ok, "no position for %q", pointer) {
+		assert.Equal(t, line, pos.Line, "line for %q", pointer)
+		assert.Equal(t, column, pos.Column, "column for %q", pointer)
+	}
+}
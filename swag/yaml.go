@@ -1,9 +1,12 @@
 package swag
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"strconv"
+
+	"gopkg.in/yaml.v2"
 )
 
 // YAMLToJSON converts YAML unmarshaled data into json compatible data
@@ -52,6 +55,43 @@ func transformData(in interface{}) (out interface{}, err error) {
 	return in, nil
 }
 
+// JSONToYAML converts a json document to yaml, the keys of the objects keep the order of the json document
+func JSONToYAML(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	value, err := decodeJSONValue(dec)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(toYAMLValue(value))
+}
+
+func toYAMLValue(in interface{}) interface{} {
+	switch value := in.(type) {
+	case JSONMapSlice:
+		res := make(yaml.MapSlice, 0, len(value))
+		for _, item := range value {
+			res = append(res, yaml.MapItem{Key: item.Key, Value: toYAMLValue(item.Value)})
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, 0, len(value))
+		for _, item := range value {
+			res = append(res, toYAMLValue(item))
+		}
+		return res
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return i
+		}
+		if f, err := value.Float64(); err == nil {
+			return f
+		}
+		return value.String()
+	}
+	return in
+}
+
 // YAMLDoc loads a yaml document from either http or a file and converts it to json
 func YAMLDoc(path string) (json.RawMessage, error) {
 	yamlDoc, err := YAMLData(path)
@@ -101,3 +101,30 @@ func TestYAMLToJSON(t *testing.T) {
 	assert.Equal(t, json.RawMessage(`{"description":"object created"}`), d)
 
 }
+
+func TestJSONToYAML(t *testing.T) {
+	doc := []byte(`{"swagger":"2.0","info":{"version":"1.0.0","title":"Pets"},"paths":{"/pets":{"get":{"responses":{"200":{"description":"ok"}}}}},"x-max":12,"x-ratio":0.5,"tags":["b","a"]}`)
+	expected := `swagger: "2.0"
+info:
+  version: 1.0.0
+  title: Pets
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok
+x-max: 12
+x-ratio: 0.5
+tags:
+- b
+- a
+`
+	d, err := JSONToYAML(doc)
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected, string(d))
+	}
+
+	_, err = JSONToYAML([]byte(`{"a":`))
+	assert.Error(t, err)
+}
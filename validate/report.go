@@ -0,0 +1,176 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vikstrous/go-swagger/errors"
+	"github.com/vikstrous/go-swagger/internal/validate"
+	"github.com/vikstrous/go-swagger/jsonpointer"
+	"github.com/vikstrous/go-swagger/spec"
+	"github.com/vikstrous/go-swagger/strfmt"
+)
+
+// The severities of a finding
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Finding is an error or a warning found while validating a spec document.
+// The pointer is a json pointer to the offending node in the document,
+// line and column are only known when the document was loaded with spec.LoadWithPositions.
+type Finding struct {
+	Severity string `json:"severity"`
+	Rule     string `json:"rule"`
+	Pointer  string `json:"pointer"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Message  string `json:"message"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s (%s) at %s", f.Severity, f.Message, f.Rule, f.location())
+}
+
+func (f Finding) location() string {
+	loc := f.Pointer
+	if loc == "" {
+		loc = "/"
+	}
+	if f.Line > 0 {
+		loc += fmt.Sprintf(" (line %d, column %d)", f.Line, f.Column)
+	}
+	return loc
+}
+
+// Findings a list of findings, errors come before warnings and
+// each of them is sorted by the location in the document
+type Findings []Finding
+
+func (f Findings) Len() int      { return len(f) }
+func (f Findings) Swap(i, j int) { f[i], f[j] = f[j], f[i] }
+func (f Findings) Less(i, j int) bool {
+	if f[i].Severity != f[j].Severity {
+		return f[i].Severity == SeverityError
+	}
+	if f[i].Pointer != f[j].Pointer {
+		return f[i].Pointer < f[j].Pointer
+	}
+	return f[i].Message < f[j].Message
+}
+
+// Errors returns the findings that make the document invalid
+func (f Findings) Errors() Findings {
+	return f.withSeverity(SeverityError)
+}
+
+// Warnings returns the findings that don't make the document invalid
+func (f Findings) Warnings() Findings {
+	return f.withSeverity(SeverityWarning)
+}
+
+// HasErrors returns true when the document is invalid
+func (f Findings) HasErrors() bool {
+	return len(f.Errors()) > 0
+}
+
+func (f Findings) withSeverity(severity string) Findings {
+	var result Findings
+	for _, finding := range f {
+		if finding.Severity == severity {
+			result = append(result, finding)
+		}
+	}
+	return result
+}
+
+// SpecFindings validates a spec document like Spec does,
+// but it also reports the warnings and where in the document each finding was made
+func SpecFindings(doc *spec.Document, formats strfmt.Registry) Findings {
+	errs, warns := validate.NewSpecValidator(doc.Schema(), formats).Validate(doc)
+
+	var raw interface{}
+	if err := json.Unmarshal(doc.Raw(), &raw); err != nil {
+		raw = nil
+	}
+
+	var findings Findings
+	if errs != nil {
+		findings = appendFindings(findings, SeverityError, raw, errs.Errors)
+	}
+	if warns != nil {
+		findings = appendFindings(findings, SeverityWarning, raw, warns.Errors)
+	}
+	for i, finding := range findings {
+		if pos, ok := doc.PositionOf(finding.Pointer); ok {
+			findings[i].Line = pos.Line
+			findings[i].Column = pos.Column
+		}
+	}
+	sort.Sort(findings)
+	return findings
+}
+
+func appendFindings(findings Findings, severity string, raw interface{}, errs []error) Findings {
+	for _, err := range errs {
+		switch e := err.(type) {
+		case *errors.CompositeError:
+			findings = appendFindings(findings, severity, raw, e.Errors)
+		case *validate.SpecError:
+			findings = append(findings, Finding{Severity: severity, Rule: e.Rule, Pointer: e.Pointer, Message: e.Error()})
+		case *errors.Validation:
+			findings = append(findings, Finding{Severity: severity, Rule: validate.RuleSchema, Pointer: pointerFor(raw, e.Name), Message: e.Error()})
+		default:
+			findings = append(findings, Finding{Severity: severity, Rule: validate.RuleSchema, Message: err.Error()})
+		}
+	}
+	return findings
+}
+
+// pointerFor turns the dotted name the schema validator uses into a json pointer.
+// Keys in a swagger document can contain dots too, so the name is matched against the document
+// and the pointer to the deepest node that could be found is returned.
+func pointerFor(doc interface{}, name string) string {
+	name = strings.TrimPrefix(name, ".")
+	if name == "" {
+		return ""
+	}
+	segments := strings.Split(name, ".")
+
+	var pointer string
+	node := doc
+	for i := 0; i < len(segments); {
+		switch tv := node.(type) {
+		case map[string]interface{}:
+			found := false
+			for j := len(segments); j > i; j-- {
+				key := strings.Join(segments[i:j], ".")
+				if v, ok := tv[key]; ok {
+					node = v
+					pointer += "/" + jsonpointer.Escape(key)
+					i = j
+					found = true
+					break
+				}
+			}
+			if !found {
+				return pointer
+			}
+		case []interface{}:
+			idx, err := strconv.Atoi(segments[i])
+			if err != nil || idx < 0 || idx >= len(tv) {
+				return pointer
+			}
+			node = tv[idx]
+			pointer += "/" + segments[i]
+			i++
+		default:
+			return pointer
+		}
+	}
+	return pointer
+}
@@ -0,0 +1,106 @@
+package validate
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	intvalidate "github.com/vikstrous/go-swagger/internal/validate"
+	"github.com/vikstrous/go-swagger/spec"
+	"github.com/vikstrous/go-swagger/strfmt"
+)
+
+func findingsFixture() *spec.Swagger {
+	sw := new(spec.Swagger)
+	sw.Info = new(spec.Info)
+	sw.Info.Title = "plugin api"
+	sw.Info.Version = "1.0.0"
+	sw.Produces = []string{"application/json"}
+
+	thing := new(spec.Schema).Typed("object", "")
+	thing.SetProperty("name", *spec.StringProperty())
+	sw.AddDefinition("Thing", thing)
+	sw.AddDefinition("Unused", new(spec.Schema).Typed("object", ""))
+
+	getThing := spec.NewOperation("getThing").
+		AddParam(spec.PathParam("id").Typed("string", "")).
+		RespondsWith(200, spec.NewResponse().WithDescription("a thing").WithSchema(spec.RefProperty("#/definitions/Thing")))
+	sw.AddOperation("GET", "/things/{id}", getThing)
+	return sw
+}
+
+func TestSpecFindings(t *testing.T) {
+	sw := findingsFixture()
+	doc, err := spec.NewDocument(sw)
+	if assert.NoError(t, err) {
+		findings := SpecFindings(doc, strfmt.Default)
+		assert.False(t, findings.HasErrors())
+		if assert.Len(t, findings.Warnings(), 1) {
+			w := findings.Warnings()[0]
+			assert.Equal(t, SeverityWarning, w.Severity)
+			assert.Equal(t, intvalidate.RuleUnused, w.Rule)
+			assert.Equal(t, "/definitions/Unused", w.Pointer)
+		}
+	}
+
+	sw.Paths.Paths["/things/{id}"].Get.AddParam(spec.PathParam("other").Typed("string", ""))
+	doc, err = spec.NewDocument(sw)
+	if assert.NoError(t, err) {
+		findings := SpecFindings(doc, strfmt.Default)
+		assert.True(t, findings.HasErrors())
+		if assert.Len(t, findings.Errors(), 1) {
+			e := findings[0]
+			assert.Equal(t, SeverityError, e.Severity)
+			assert.Equal(t, intvalidate.RulePathParam, e.Rule)
+			assert.Equal(t, "/paths/~1things~1{id}/get/parameters/1", e.Pointer)
+		}
+		assert.Len(t, findings.Warnings(), 1)
+	}
+}
+
+func TestSpecFindings_Schema(t *testing.T) {
+	sw := findingsFixture()
+	sw.Info = nil
+	doc, err := spec.NewDocument(sw)
+	if assert.NoError(t, err) {
+		findings := SpecFindings(doc, strfmt.Default)
+		if assert.True(t, findings.HasErrors()) {
+			assert.Equal(t, intvalidate.RuleSchema, findings[0].Rule)
+			assert.Equal(t, ".info in body is required", findings[0].Message)
+		}
+	}
+}
+
+func TestPointerFor(t *testing.T) {
+	doc := map[string]interface{}{
+		"paths": map[string]interface{}{
+			"/v1.0/pets": map[string]interface{}{
+				"get": map[string]interface{}{
+					"parameters": []interface{}{
+						map[string]interface{}{"name": "limit"},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, "", pointerFor(doc, ""))
+	assert.Equal(t, "/paths", pointerFor(doc, ".paths"))
+	assert.Equal(t, "/paths/~1v1.0~1pets/get/parameters/0/name", pointerFor(doc, "paths./v1.0/pets.get.parameters.0.name"))
+	assert.Equal(t, "/paths/~1v1.0~1pets/get", pointerFor(doc, "paths./v1.0/pets.get.responses"))
+	assert.Equal(t, "/paths/~1v1.0~1pets/get/parameters", pointerFor(doc, "paths./v1.0/pets.get.parameters.3"))
+}
+
+func TestSpecFindings_Positions(t *testing.T) {
+	doc, err := spec.LoadWithPositions(filepath.Join("..", "fixtures", "validation", "invalid-default-value-parameter.json"))
+	if assert.NoError(t, err) {
+		findings := SpecFindings(doc, strfmt.Default)
+		if assert.Len(t, findings.Errors(), 1) {
+			e := findings[0]
+			assert.Equal(t, intvalidate.RuleInvalidDefault, e.Rule)
+			assert.Equal(t, "/paths/~1pets/get/parameters/0/default", e.Pointer)
+			assert.Equal(t, 34, e.Line)
+			assert.Equal(t, 17, e.Column)
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 // 	- each referencable defintion must have references
 // 	- each definition property listed in the required array must be defined in the properties of the model
 // 	- each parameter should have a unique `name` and `type` combination
+// 	- each structured query parameter should have a known x-query-style and a valid x-query-schema
 // 	- each operation should have only 1 parameter of type body
 // 	- each reference must point to a valid object
 // 	- every default value that is specified must validate against the schema for that property
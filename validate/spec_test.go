@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/vikstrous/go-swagger/errors"
+	"github.com/vikstrous/go-swagger/httpkit"
 	intvalidate "github.com/vikstrous/go-swagger/internal/validate"
 	"github.com/vikstrous/go-swagger/spec"
 	"github.com/vikstrous/go-swagger/strfmt"
@@ -97,3 +99,87 @@ func TestIssue61_ResolvedRef(t *testing.T) {
 		assert.True(t, res.IsValid())
 	}
 }
+
+func TestBuiltSpec(t *testing.T) {
+	sw := new(spec.Swagger)
+	sw.Info = new(spec.Info)
+	sw.Info.Title = "plugin api"
+	sw.Info.Version = "1.0.0"
+	sw.Produces = []string{"application/json"}
+
+	thing := new(spec.Schema).Typed("object", "")
+	thing.SetProperty("name", *spec.StringProperty())
+	sw.AddDefinition("Thing", thing)
+
+	getThing := spec.NewOperation("getThing").
+		AddParam(spec.PathParam("id").Typed("string", "")).
+		RespondsWith(200, spec.NewResponse().WithDescription("a thing").WithSchema(spec.RefProperty("#/definitions/Thing")))
+	if assert.NoError(t, sw.AddOperation("GET", "/things/{id}", getThing)) {
+		doc, err := spec.NewDocument(sw)
+		if assert.NoError(t, err) {
+			assert.NoError(t, Spec(doc, strfmt.Default))
+		}
+
+		if assert.NoError(t, sw.RenameDefinition("Thing", "Widget")) {
+			doc, err := spec.NewDocument(sw)
+			if assert.NoError(t, err) {
+				assert.NoError(t, Spec(doc, strfmt.Default))
+			}
+		}
+	}
+
+	getThing.AddParam(spec.PathParam("other").Typed("string", ""))
+	doc, err := spec.NewDocument(sw)
+	if assert.NoError(t, err) {
+		assert.Error(t, Spec(doc, strfmt.Default))
+	}
+}
+
+func TestStructuredQueryParams(t *testing.T) {
+	build := func(filter *spec.Parameter) *spec.Document {
+		sw := new(spec.Swagger)
+		sw.Info = new(spec.Info)
+		sw.Info.Title = "pets api"
+		sw.Info.Version = "1.0.0"
+		sw.Produces = []string{"application/json"}
+		sw.AddDefinition("Filter", new(spec.Schema).Typed("object", "").SetProperty("status", *spec.StringProperty()))
+		listPets := spec.NewOperation("listPets").
+			AddParam(filter).
+			RespondsWith(200, spec.NewResponse().WithDescription("the pets"))
+		if err := sw.AddOperation("GET", "/pets", listPets); err != nil {
+			t.Fatal(err)
+		}
+		doc, err := spec.NewDocument(sw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return doc
+	}
+	styled := func(in, style string, schema interface{}) *spec.Parameter {
+		param := spec.QueryParam("filter").Typed("string", "")
+		param.In = in
+		param.AddExtension(httpkit.ExtQueryStyle, style)
+		param.AddExtension(httpkit.ExtQuerySchema, schema)
+		return param
+	}
+
+	ref := map[string]interface{}{"$ref": "#/definitions/Filter"}
+	assert.NoError(t, Spec(build(styled("query", httpkit.QueryStyleDeepObject, ref)), strfmt.Default))
+	assert.NoError(t, Spec(build(styled("query", httpkit.QueryStyleJSON, spec.ArrayProperty(spec.StringProperty()))), strfmt.Default))
+
+	for _, tc := range []struct {
+		param   *spec.Parameter
+		message string
+	}{
+		{styled("query", "form", ref), `unknown query style "form"`},
+		{styled("header", httpkit.QueryStyleJSON, ref), "only query params can have a query style"},
+		{styled("query", httpkit.QueryStyleDeepObject, map[string]interface{}{"$ref": "#/definitions/Missing"}), "can't resolve the reference #/definitions/Missing"},
+		{styled("query", httpkit.QueryStyleDeepObject, spec.StringProperty()), "its schema has to be an object"},
+		{styled("query", httpkit.QueryStyleDeepObject, "filter"), "x-query-schema is not a schema"},
+	} {
+		err := Spec(build(tc.param), strfmt.Default)
+		if assert.Error(t, err, tc.message) {
+			assert.Contains(t, err.(*errors.CompositeError).Errors[0].Error(), tc.message)
+		}
+	}
+}